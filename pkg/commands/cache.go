@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"context"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+)
+
+func (e *Executor) initCache() {
+	cacheCmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Cache control",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := cmd.Help(); err != nil {
+				e.log.Fatalf("Can't run help: %s", err)
+			}
+		},
+	}
+	e.rootCmd.AddCommand(cacheCmd)
+
+	warmCmd := &cobra.Command{
+		Use:   "warm",
+		Short: "Load and typecheck packages to fill go build cache before running linters",
+		Run:   e.executeCacheWarm,
+	}
+	e.initRunConfiguration(warmCmd) // allow to select linters: they define load mode
+	cacheCmd.AddCommand(warmCmd)
+
+	cleanCmd := &cobra.Command{
+		Use:   "clean",
+		Short: "Remove caches of issues and autogenerated files",
+		Args:  cobra.NoArgs,
+		Run:   e.executeCacheClean,
+	}
+	cleanCmd.Flags().StringVar(&e.cfg.Run.CacheDir, "cache-dir", "",
+		wh("Dir of caches, default is golangci-lint dir in user cache dir"))
+	cacheCmd.AddCommand(cleanCmd)
+}
+
+func (e *Executor) executeCacheClean(cmd *cobra.Command, args []string) {
+	dir, err := e.cfg.GetCacheDir()
+	if err != nil {
+		e.log.Fatalf("Can't get cache dir: %s", err)
+	}
+
+	if err = fsutils.RemoveCacheDir(dir); err != nil {
+		e.log.Fatalf("Can't remove cache dir %s: %s", dir, err)
+	}
+
+	e.log.Infof("Removed cache dir %s", dir)
+	os.Exit(exitcodes.Success)
+}
+
+func (e *Executor) executeCacheWarm(cmd *cobra.Command, args []string) {
+	e.applyDeprecatedDeadline(cmd)
+	ctx, cancel := context.WithTimeout(context.Background(), e.cfg.Run.Timeout)
+	defer cancel()
+
+	if err := e.goenv.Discover(ctx); err != nil {
+		e.log.Warnf("Failed to discover go env: %s", err)
+	}
+
+	if err := e.warmCache(ctx, args); err != nil {
+		e.log.Fatalf("Can't warm cache: %s", err)
+	}
+
+	os.Exit(exitcodes.Success)
+}
+
+// warmCache loads packages by args for enabled linters: loading of packages
+// runs 'go list' and builds cgo files, results are cached by go
+func (e *Executor) warmCache(ctx context.Context, args []string) error {
+	e.cfg.Run.Args = args
+	enabledLinters, err := e.EnabledLintersSet.Get()
+	if err != nil {
+		return errors.Wrap(err, "can't get enabled linters")
+	}
+
+	_, err = e.contextLoader.Load(ctx, enabledLinters)
+	return err
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/goutil"
+	"github.com/golangci/golangci-lint/pkg/lint"
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func newWarmCacheExecutor(enabledLinters ...string) *Executor {
+	log := logutils.NewStderrLog("")
+	cfg := config.NewDefault()
+	cfg.Linters.DisableAll = true
+	cfg.Linters.Enable = enabledLinters
+
+	m := lintersdb.NewManager()
+	goenv := goutil.NewEnv(log.Child("goenv"))
+	return &Executor{
+		cfg:               cfg,
+		log:               log,
+		DBManager:         m,
+		EnabledLintersSet: lintersdb.NewEnabledSet(m, lintersdb.NewValidator(m), log.Child("lintersdb"), cfg),
+		contextLoader:     lint.NewContextLoader(cfg, log.Child("loader"), goenv),
+		goenv:             goenv,
+	}
+}
+
+func TestWarmCache(t *testing.T) {
+	ctx := context.Background()
+
+	// gofmt needs only files of packages: they are listed by 'go list' without typechecking
+	e := newWarmCacheExecutor("gofmt")
+	assert.NoError(t, e.goenv.Discover(ctx))
+	assert.NoError(t, e.warmCache(ctx, []string{"../gitutil"}))
+	assert.Equal(t, []string{"../gitutil"}, e.cfg.Run.Args)
+
+	e = newWarmCacheExecutor("no-such-linter")
+	err := e.warmCache(ctx, []string{"../gitutil"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "can't get enabled linters")
+}
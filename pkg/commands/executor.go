@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"time"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
@@ -15,16 +17,24 @@ import (
 type Executor struct {
 	rootCmd *cobra.Command
 	runCmd  *cobra.Command
+	lspCmd  *cobra.Command
 
 	exitCode              int
+	exitMessage           string
+	memoryLimitExceeded   int32 // accessed atomically
+	deadline              time.Duration
+	deadlineTimer         *time.Timer
+	startedAt             time.Time
 	version, commit, date string
 
 	cfg               *config.Config
 	log               logutils.Log
 	reportData        report.Data
+	summary           report.Summary
 	DBManager         *lintersdb.Manager
 	EnabledLintersSet *lintersdb.EnabledSet
 	contextLoader     *lint.ContextLoader
+	runner            *lint.Runner
 	goenv             *goutil.Env
 }
 
@@ -56,12 +66,14 @@ func NewExecutor(version, commit, date string) *Executor {
 	e.initHelp()
 	e.initLinters()
 	e.initConfig()
+	e.initLsp()
+	e.initCache()
 
 	// init e.cfg by values from config: flags parse will see these values
 	// like the default ones. It will overwrite them only if the same option
 	// is found in command-line: it's ok, command-line has higher priority.
 
-	r := config.NewFileReader(e.cfg, commandLineCfg, e.log.Child("config_reader"))
+	r := config.NewFileReader(e.cfg, commandLineCfg, e.DBManager.GetAllLinterNames(), e.log.Child("config_reader"))
 	if err := r.Read(); err != nil {
 		e.log.Fatalf("Can't read config: %s", err)
 	}
@@ -71,8 +83,20 @@ func NewExecutor(version, commit, date string) *Executor {
 		e.log.Fatalf("Invalid gocritic settings: %s", err)
 	}
 
+	if err := e.cfg.LintersSettings.Govet.Validate(); err != nil {
+		e.log.Fatalf("Invalid govet settings: %s", err)
+	}
+
+	if len(e.cfg.LintersSettings.Custom) != 0 {
+		if err := lintersdb.LoadCustomLinters(e.cfg.LintersSettings.Custom); err != nil {
+			e.log.Fatalf("Can't load custom linters: %s", err)
+		}
+		e.DBManager = lintersdb.NewManager() // with loaded custom linters
+	}
+
 	// Slice options must be explicitly set for proper merging of config and command-line options.
 	fixSlicesFlags(e.runCmd.Flags())
+	fixSlicesFlags(e.lspCmd.Flags())
 
 	e.EnabledLintersSet = lintersdb.NewEnabledSet(e.DBManager,
 		lintersdb.NewValidator(e.DBManager), e.log.Child("lintersdb"), e.cfg)
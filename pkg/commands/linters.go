@@ -1,13 +1,18 @@
 package commands
 
 import (
+	"encoding/json"
+	"fmt"
 	"log"
 	"os"
+	"sort"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
+	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/logutils"
 )
 
 func (e *Executor) initLinters() {
@@ -30,14 +35,26 @@ func IsLinterInConfigsList(name string, linters []linter.Config) bool {
 	return false
 }
 
+// linterInfo describes a linter in json output of linters command
+type linterInfo struct {
+	Name             string
+	AlternativeNames []string `json:",omitempty"`
+	Desc             string
+	Enabled          bool // enabled by the current configuration
+	Presets          []string
+	Fast             bool
+}
+
 func (e *Executor) executeLinters(cmd *cobra.Command, args []string) {
 	enabledLCs, err := e.EnabledLintersSet.Get()
 	if err != nil {
 		log.Fatalf("Can't get enabled linters: %s", err)
 	}
 
-	color.Green("Enabled by your configuration linters:\n")
-	printLinterConfigs(enabledLCs)
+	// order of enabled linters isn't stable: sort them to print the same output every time
+	sort.Slice(enabledLCs, func(i, j int) bool {
+		return enabledLCs[i].Name() < enabledLCs[j].Name()
+	})
 
 	var disabledLCs []linter.Config
 	for _, lc := range e.DBManager.GetAllSupportedLinterConfigs() {
@@ -46,8 +63,40 @@ func (e *Executor) executeLinters(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	if e.cfg.Output.Format == config.OutFormatJSON {
+		printLinterInfosJSON(enabledLCs, disabledLCs)
+		os.Exit(0)
+	}
+
+	color.Green("Enabled by your configuration linters:\n")
+	printLinterConfigs(enabledLCs)
+
 	color.Red("\nDisabled by your configuration linters:\n")
 	printLinterConfigs(disabledLCs)
 
 	os.Exit(0)
 }
+
+func printLinterInfosJSON(enabledLCs, disabledLCs []linter.Config) {
+	infos := []linterInfo{}
+	addInfos := func(lcs []linter.Config, enabled bool) {
+		for _, lc := range lcs {
+			infos = append(infos, linterInfo{
+				Name:             lc.Name(),
+				AlternativeNames: lc.AlternativeNames,
+				Desc:             lc.Linter.Desc(),
+				Enabled:          enabled,
+				Presets:          lc.InPresets,
+				Fast:             !lc.NeedsSSARepr,
+			})
+		}
+	}
+	addInfos(enabledLCs, true)
+	addInfos(disabledLCs, false)
+
+	out, err := json.Marshal(struct{ Linters []linterInfo }{infos})
+	if err != nil {
+		log.Fatalf("Can't marshal linters: %s", err)
+	}
+	fmt.Fprintln(logutils.StdOut, string(out))
+}
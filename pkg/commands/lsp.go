@@ -0,0 +1,244 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/lint"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const (
+	diagMethodDidOpen   = "didOpen"
+	diagMethodDidChange = "didChange"
+	diagMethodDidSave   = "didSave"
+	diagMethodDidClose  = "didClose"
+)
+
+// diagRequest is a notification from an editor about a file,
+// it's read from stdin as one JSON object per line.
+type diagRequest struct {
+	ID     int
+	Method string
+	File   string
+
+	// Contents are unsaved contents of the file: they're required for didChange
+	Contents *string `json:",omitempty"`
+}
+
+// diagResponse contains diagnostics for the file of a request,
+// it's written to stdout as one JSON object per line.
+type diagResponse struct {
+	ID     int
+	File   string
+	Issues []result.Issue
+	Error  string `json:",omitempty"`
+}
+
+// diagServer answers requests by issues of files: issues of a dir are cached
+// until a file of the dir is saved or closed. Changed files are linted with
+// their unsaved contents on every request, their issues aren't cached.
+type diagServer struct {
+	lintDir  func(dir string) ([]result.Issue, error)
+	lintFile func(file string, contents []byte) ([]result.Issue, error)
+	cache    map[string][]result.Issue
+}
+
+func newDiagServer(lintDir func(dir string) ([]result.Issue, error),
+	lintFile func(file string, contents []byte) ([]result.Issue, error)) *diagServer {
+	return &diagServer{
+		lintDir:  lintDir,
+		lintFile: lintFile,
+		cache:    map[string][]result.Issue{},
+	}
+}
+
+func (e *Executor) initLsp() {
+	e.lspCmd = &cobra.Command{
+		Use:   "lsp",
+		Short: "Read file notifications from stdin and stream diagnostics for them to stdout",
+		Run:   e.executeLsp,
+	}
+	e.rootCmd.AddCommand(e.lspCmd)
+	e.initRunConfiguration(e.lspCmd)
+}
+
+func (e *Executor) executeLsp(cmd *cobra.Command, args []string) {
+	e.applyDeprecatedDeadline(cmd)
+	if err := e.goenv.Discover(context.Background()); err != nil {
+		e.log.Warnf("Failed to discover go env: %s", err)
+	}
+
+	// stdout is used for diagnostics
+	restoreOutput := e.hideLintersOutput()
+	defer restoreOutput()
+
+	if err := newDiagServer(e.lintDir, e.lintChangedFile).serve(os.Stdin, logutils.StdOut); err != nil {
+		e.log.Fatalf("%s", err)
+	}
+}
+
+func (s *diagServer) serve(in io.Reader, out io.Writer) error {
+	enc := json.NewEncoder(out)
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var req diagRequest
+		var resp diagResponse
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			resp.Issues = []result.Issue{}
+			resp.Error = fmt.Sprintf("can't parse request: %s", err)
+		} else {
+			resp = s.process(&req)
+		}
+
+		if err := enc.Encode(&resp); err != nil {
+			return fmt.Errorf("can't write diagnostics: %s", err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("can't read requests: %s", err)
+	}
+
+	return nil
+}
+
+func (s *diagServer) process(req *diagRequest) diagResponse {
+	resp := diagResponse{
+		ID:     req.ID,
+		File:   req.File,
+		Issues: []result.Issue{},
+	}
+
+	file, err := fsutils.ShortestRelPath(req.File, "")
+	if err != nil {
+		resp.Error = fmt.Sprintf("can't make relative path for %q: %s", req.File, err)
+		return resp
+	}
+	issues, err := s.fileIssues(req, file)
+	if err != nil {
+		resp.Error = err.Error()
+		return resp
+	}
+
+	for _, i := range issues {
+		if filepath.Clean(i.FilePath()) == file {
+			resp.Issues = append(resp.Issues, i)
+		}
+	}
+
+	return resp
+}
+
+// fileIssues returns issues for the request: they're issues of the whole dir of the file,
+// no issues are returned for didClose
+func (s *diagServer) fileIssues(req *diagRequest, file string) ([]result.Issue, error) {
+	dir := filepath.Dir(file)
+	switch req.Method {
+	case diagMethodDidOpen:
+		return s.dirIssues(dir)
+	case diagMethodDidChange:
+		if req.Contents == nil {
+			return nil, errors.New("contents of the changed file weren't passed")
+		}
+		return s.lintFile(file, []byte(*req.Contents))
+	case diagMethodDidSave:
+		delete(s.cache, dir)
+		return s.dirIssues(dir)
+	case diagMethodDidClose:
+		delete(s.cache, dir)
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown method %q", req.Method)
+	}
+}
+
+// dirIssues returns cached issues of the dir: the dir is linted if they aren't cached
+func (s *diagServer) dirIssues(dir string) ([]result.Issue, error) {
+	if issues, ok := s.cache[dir]; ok {
+		return issues, nil
+	}
+
+	issues, err := s.lintDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cache[dir] = issues
+	return issues, nil
+}
+
+func (e *Executor) lintDir(dir string) ([]result.Issue, error) {
+	return e.lintByConfigCopy(e.cfg.Copy(), []string{dir})
+}
+
+// lintChangedFile lints unsaved contents of the file like a file from stdin
+// (--files-from-stdin): the whole package of the file is analyzed, but only
+// issues of the file are reported. Linters reading files by themselves
+// (e.g. gofmt) still see the file on disk.
+func (e *Executor) lintChangedFile(file string, contents []byte) ([]result.Issue, error) {
+	absPath, err := filepath.Abs(file)
+	if err != nil {
+		return nil, fmt.Errorf("can't make absolute path for %q: %s", file, err)
+	}
+
+	cfg := e.cfg.Copy()
+	cfg.Run.OnlyFiles = []string{file}
+	cfg.Run.Overlay = map[string][]byte{absPath: contents}
+	// cached issues and fixes are bound to contents of files on disk
+	cfg.Run.NoCache = true
+	cfg.Issues.NeedFix = false
+
+	return e.lintByConfigCopy(cfg, packagesOfFiles(cfg.Run.OnlyFiles))
+}
+
+// lintByConfigCopy lints packages by a copy of the config: the executor's config
+// and report data aren't changed by requests of the long-running process.
+func (e *Executor) lintByConfigCopy(cfg *config.Config, args []string) ([]result.Issue, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Run.Timeout)
+	defer cancel()
+
+	// stdin is used for requests
+	cfg.Run.FilesFromStdin = false
+	cfg.Run.PackagesFromStdin = false
+
+	var reportData report.Data
+	runLog := report.NewLogWrapper(logutils.NewStderrLog(""), &reportData)
+	logutils.SetupVerboseLog(runLog, cfg.Run.IsVerbose)
+
+	analysis, err := lint.NewAnalysis(ctx, cfg, args, runLog, e.goenv)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = analysis.Load(ctx); err != nil {
+		return nil, err
+	}
+
+	var issues []result.Issue
+	for i := range analysis.Issues(ctx) {
+		issues = append(issues, i)
+	}
+
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("timeout exceeded: try increase it by passing --timeout option")
+	}
+
+	return issues, nil
+}
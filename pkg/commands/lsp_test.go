@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestDiagServer(t *testing.T) {
+	// requested files must exist: paths are made relative by evaluating symlinks
+	var lintedDirs, lintedFiles []string
+	s := newDiagServer(func(dir string) ([]result.Issue, error) {
+		lintedDirs = append(lintedDirs, dir)
+		if dir == "../lint" {
+			return nil, errors.New("can't load packages")
+		}
+		return []result.Issue{
+			{FromLinter: "govet", Text: "a", Pos: token.Position{Filename: "lsp.go", Line: 1}},
+			{FromLinter: "golint", Text: "b", Pos: token.Position{Filename: "run.go", Line: 2}},
+		}, nil
+	}, func(file string, contents []byte) ([]result.Issue, error) {
+		lintedFiles = append(lintedFiles, file+": "+string(contents))
+		return []result.Issue{
+			{FromLinter: "govet", Text: "c", Pos: token.Position{Filename: file, Line: 1}},
+		}, nil
+	})
+
+	in := strings.Join([]string{
+		`{"ID":1,"Method":"didOpen","File":"lsp.go"}`,
+		`{"ID":2,"Method":"didOpen","File":"run.go"}`, // cached
+		``,
+		`{"ID":3,"Method":"didChange","File":"lsp.go","Contents":"package commands"}`, // unsaved contents are linted
+		`{"ID":4,"Method":"didOpen","File":"lsp.go"}`,                                 // cached: disk isn't changed
+		`{"ID":5,"Method":"didSave","File":"lsp.go"}`,
+		`{"ID":6,"Method":"didClose","File":"lsp.go"}`,
+		`{"ID":7,"Method":"didOpen","File":"../lint/run.go"}`,
+		`{"ID":8,"Method":"rename","File":"lsp.go"}`,
+		`{"ID":9,"Method":"didChange","File":"lsp.go"}`,
+		`not json`,
+	}, "\n")
+
+	var out bytes.Buffer
+	require.NoError(t, s.serve(strings.NewReader(in), &out))
+	assert.Equal(t, []string{".", ".", "../lint"}, lintedDirs)
+	assert.Equal(t, []string{"lsp.go: package commands"}, lintedFiles)
+
+	dec := json.NewDecoder(&out)
+	var resps []diagResponse
+	for dec.More() {
+		var resp diagResponse
+		require.NoError(t, dec.Decode(&resp))
+		resps = append(resps, resp)
+	}
+	require.Len(t, resps, 10)
+
+	issueTexts := func(resp diagResponse) []string {
+		texts := []string{}
+		for _, i := range resp.Issues {
+			texts = append(texts, i.Text)
+		}
+		return texts
+	}
+
+	for i, expected := range [][]string{{"a"}, {"b"}, {"c"}, {"a"}, {"a"}, {}} {
+		assert.Equal(t, i+1, resps[i].ID)
+		assert.Empty(t, resps[i].Error)
+		assert.Equal(t, expected, issueTexts(resps[i]))
+	}
+	assert.Equal(t, "run.go", resps[1].File)
+
+	assert.Equal(t, 7, resps[6].ID)
+	assert.Equal(t, "can't load packages", resps[6].Error)
+	assert.Equal(t, `unknown method "rename"`, resps[7].Error)
+	assert.Equal(t, "contents of the changed file weren't passed", resps[8].Error)
+	assert.Contains(t, resps[9].Error, "can't parse request")
+	assert.NotNil(t, resps[9].Issues)
+}
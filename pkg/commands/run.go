@@ -1,33 +1,47 @@
 package commands
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
+	"sync/atomic"
+	"text/tabwriter"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	yaml "gopkg.in/yaml.v2"
 
 	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/gitutil"
+	"github.com/golangci/golangci-lint/pkg/goutil"
 	"github.com/golangci/golangci-lint/pkg/lint"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/lintlock"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/printers"
+	"github.com/golangci/golangci-lint/pkg/report"
 	"github.com/golangci/golangci-lint/pkg/result"
+	"github.com/golangci/golangci-lint/pkg/result/processors"
 )
 
 func getDefaultExcludeHelp() string {
 	parts := []string{"Use or not use default excludes:"}
 	for _, ep := range config.DefaultExcludePatterns {
-		parts = append(parts, fmt.Sprintf("  # %s: %s", ep.Linter, ep.Why))
+		parts = append(parts, fmt.Sprintf("  # %s (%s): %s", ep.ID, strings.Join(ep.Linters, ", "), ep.Why))
 		parts = append(parts, fmt.Sprintf("  - %s", color.YellowString(ep.Pattern)))
 		parts = append(parts, "")
 	}
@@ -52,25 +66,128 @@ func initFlagSet(fs *pflag.FlagSet, cfg *config.Config, m *lintersdb.Manager) {
 	oc := &cfg.Output
 	fs.StringVar(&oc.Format, "out-format",
 		config.OutFormatColoredLineNumber,
-		wh(fmt.Sprintf("Format of output: %s", strings.Join(config.OutFormats, "|"))))
-	fs.BoolVar(&oc.PrintIssuedLine, "print-issued-lines", true, wh("Print lines of code with issue"))
+		wh(fmt.Sprintf("Formats of output: %s. Multiple comma-separated formats can be printed to their "+
+			"destinations: stdout (default), stderr or file path, e.g. colored-line-number,json:report.json",
+			strings.Join(config.OutFormats, "|"))))
+	fs.BoolVar(&oc.PrintIssuedLine, "print-issued-lines", true,
+		wh(fmt.Sprintf("Print lines of code with issue, in %s format they are printed in SourceLines field", config.OutFormatJSON)))
+	fs.IntVar(&oc.SourceContextLines, "source-context-lines", 0,
+		wh(fmt.Sprintf("Count of lines before and after lines of code with issue printed in SourceContext field "+
+			"of %s format", config.OutFormatJSON)))
+	fs.StringVar(&oc.Color, "color", config.ColorAuto,
+		wh(fmt.Sprintf("Use colors in colored-line-number format: %s (only if stdout is a terminal and "+
+			"NO_COLOR env var isn't set), %s or %s. Output to files is never colored",
+			config.ColorAuto, config.ColorAlways, config.ColorNever)))
+	fs.BoolVar(&oc.ShowCaret, "show-caret", true,
+		wh("Print caret under the issue column in printed lines of code"))
+	fs.BoolVar(&oc.HideZeroColumn, "hide-zero-column", false,
+		wh("Don't print unknown (zero) column of issue in checkstyle format, other formats never print it"))
+	fs.BoolVar(&oc.InferColumn, "infer-column", false,
+		wh("Set unknown (zero) column of issue to the column of the first code token on its line: it's heuristic"))
+	fs.StringVar(&oc.PathMode, "path-mode", config.PathModeRelative,
+		wh(fmt.Sprintf("Paths of issues in all formats: %s to the current dir or %s",
+			config.PathModeRelative, config.PathModeAbsolute)))
+	fs.BoolVar(&oc.SortResults, "sort-results", true,
+		wh("Sort issues by file, line, column and linter: if it's disabled, issues are printed as soon as "+
+			"linters finish, but their order isn't stable"))
+	fs.StringVar(&oc.StripPrefix, "strip-prefix", "",
+		wh("Strip path prefix `PATH` from file paths of issues, e.g. vendor/github.com/org/repo"))
+	fs.StringVar(&oc.PathPrefix, "path-prefix", "",
+		wh("Add path prefix `PATH` to relative file paths of issues after stripping --strip-prefix"))
+	fs.StringVar(&oc.OutFile, "out-file", "",
+		wh("Atomically write output in --out-format to file `PATH` and print issues to stdout in colored-line-number format"))
+	fs.BoolVar(&oc.PrintMetadata, "print-metadata", false,
+		wh("Print metadata of the run in json and sarif output: git commit and branch, go and golangci-lint versions and time"))
+	fs.BoolVar(&oc.ShowSuppressed, "show-suppressed", false,
+		wh("Print issues hidden by nolint directives in a separate section of json output"))
+	fs.BoolVar(&oc.PrintSummary, "print-summary", false,
+		wh("Print summary of the run in json to stderr: count of issues, counts of issues per linter, "+
+			"count of analyzed packages and elapsed time"))
+	fs.BoolVar(&oc.PrintLinterTime, "print-linter-time", false,
+		wh("Print table of wall-clock times of linters sorted from the slowest linter to stderr"))
 	fs.BoolVar(&oc.PrintLinterName, "print-linter-name", true, wh("Print linter name in issue line"))
+	fs.StringSliceVar(&oc.TabColumns, "tab-columns", nil,
+		wh(fmt.Sprintf("Columns of %s output format: issues are printed as tab-separated values of them without "+
+			"alignment, tabs and newlines in values are escaped. Allowed are %s. By default columns are aligned "+
+			"position, linter name and message", config.OutFormatTab, strings.Join(config.TabColumns, ","))))
 	fs.BoolVar(&oc.PrintWelcomeMessage, "print-welcome", false, wh("Print welcome message"))
 	hideFlag("print-welcome") // no longer used
 
 	// Run config
 	rc := &cfg.Run
 	fs.IntVar(&rc.ExitCodeIfIssuesFound, "issues-exit-code",
-		exitcodes.IssuesFound, wh("Exit code when issues were found"))
-	fs.StringSliceVar(&rc.BuildTags, "build-tags", nil, wh("Build tags"))
-	fs.DurationVar(&rc.Deadline, "deadline", time.Minute, wh("Deadline for total work"))
+		exitcodes.IssuesFound, wh("Exit code when issues were found: it doesn't affect exit codes of errors "+
+			"and timeouts, set to 0 to make found issues non-fatal"))
+	fs.BoolVar(&rc.FailOnLinterErrors, "fail-on-linter-errors", false,
+		wh("Exit with failure code if some linter failed to run: otherwise it's only a warning"))
+	fs.BoolVar(&rc.StopOnTypecheckErrors, "stop-on-typecheck-errors", false,
+		wh("Report only typecheck issues if they were found: issues of other linters in compiling "+
+			"packages aren't reported then"))
+	fs.StringSliceVar(&rc.BuildTags, "build-tags", nil,
+		wh("Build tags: files requiring them by build constraints are analyzed, files excluded by them are ignored"))
+	fs.StringVar(&rc.ModulesDownloadMode, "modules-download-mode", "",
+		wh(fmt.Sprintf("Modules download mode passed to go list as -mod flag: %s. By default -mod flag "+
+			"isn't passed and GOFLAGS or go defaults are used. It's ignored in GOPATH mode", strings.Join(config.ModulesDownloadModes, "|"))))
+	fs.StringVar(&rc.GoVersion, "go", "",
+		wh("Target Go version in format 1.N of megacheck checks and of release tags of the build context "+
+			"of megacheck and depguard. Packages are still loaded and type-checked and other linters are run "+
+			"by the running toolchain. By default the version of the running toolchain is used"))
+	fs.DurationVar(&rc.Timeout, "timeout", time.Minute, wh("Timeout for total work"))
+	fs.DurationVar(&rc.Deadline, "deadline", 0, wh("Deprecated: use --timeout"))
+	hideFlag("deadline")
+	fs.DurationVar(&rc.DeadlinePerPackage, "deadline-per-package", 0,
+		wh("Increment of timeout per every loaded package: timeout is computed as "+
+			"timeout + deadline-per-package * packages count. Set to 0 to disable"))
+	fs.DurationVar(&rc.PackageTimeout, "package-timeout", 0,
+		wh("Timeout of analysis of one package, it's supported only by golint and goconst: a package "+
+			"exceeding it is skipped with a warning, the timeout for total work still applies. Analysis of "+
+			"the skipped package can't be interrupted and the linter waits for it after other packages. "+
+			"Set to 0 to disable"))
+	fs.IntVar(&rc.MaxMemory, "max-memory", 0,
+		wh("Maximum memory usage in MB: analysis is stopped and found issues are printed "+
+			"if it's exceeded. Set to 0 to disable"))
 	fs.BoolVar(&rc.AnalyzeTests, "tests", true, wh("Analyze tests (*_test.go)"))
+	fs.BoolVar(&rc.NoCache, "no-cache", false,
+		wh("Don't use caches for this run: go build cache is replaced with an empty temporary one and kept intact, "+
+			"caches of issues and autogenerated files aren't used"))
+	fs.BoolVar(&rc.CacheAutogen, "cache-autogen", false,
+		wh("Cache results of autogenerated files detection between runs in cache dir"))
+	fs.BoolVar(&rc.CacheIssues, "cache-issues", false,
+		wh("Cache issues of linters per package between runs in cache dir"))
+	fs.StringVar(&rc.CacheDir, "cache-dir", "",
+		wh("Dir of caches of issues and autogenerated files between runs, "+
+			"default is golangci-lint dir in user cache dir"))
 	fs.BoolVar(&rc.PrintResourcesUsage, "print-resources-usage", false,
 		wh("Print avg and max memory usage of golangci-lint and total time"))
-	fs.StringVarP(&rc.Config, "config", "c", "", wh("Read config from file path `PATH`"))
-	fs.BoolVar(&rc.NoConfig, "no-config", false, wh("Don't read config"))
-	fs.StringSliceVar(&rc.SkipDirs, "skip-dirs", nil, wh("Regexps of directories to skip"))
-	fs.StringSliceVar(&rc.SkipFiles, "skip-files", nil, wh("Regexps of files to skip"))
+	fs.BoolVar(&rc.PrintProcessors, "print-processors", false,
+		wh("Print processors of found issues in order of their execution"))
+	fs.BoolVar(&rc.DryRun, "dry-run", false,
+		wh("Print packages with files to analyze, enabled linters and the effective config and exit without analysis"))
+	fs.StringVar(&rc.LintLock, "lint-lock", "",
+		wh("Lock file `PATH` with versions of golangci-lint and linters: it's created if it doesn't exist, "+
+			"otherwise run fails if versions differ from it"))
+	fs.BoolVar(&rc.UpdateLock, "update-lock", false, wh("Update lock file set by --lint-lock if versions differ from it"))
+	fs.StringVar(&rc.ExitReasonPath, "report-exit-reason", "",
+		wh("Write json with exit code, reason and message to file `PATH` on non-success exit"))
+	fs.StringVarP(&rc.Config, "config", "c", "", wh("Read config from file path `PATH` instead of searching for it, the file must exist"))
+	fs.BoolVar(&rc.NoConfig, "no-config", false, wh("Don't read config, can't be combined with --config"))
+	fs.StringSliceVar(&rc.SkipDirs, "skip-dirs", nil,
+		wh("Regexps of directories to skip: regexps starting with / are anchored at the current dir, "+
+			"e.g. /testdata skips only the top-level testdata dir"))
+	fs.BoolVar(&rc.UseDefaultSkipDirs, "skip-dirs-use-default", true,
+		wh("Use or not use default skipped dirs: vendor, third_party, testdata, examples, Godeps, builtin"))
+	fs.BoolVar(&rc.RespectGitignore, "respect-gitignore", false,
+		wh("Don't descend into dirs ignored by .gitignore files when expanding ... patterns: "+
+			"the root .gitignore of the git repository and nested ones are read"))
+	fs.StringSliceVar(&rc.SkipFiles, "skip-files", nil,
+		wh("Regexps of files to skip: they are matched against paths relative to the current dir"))
+	fs.BoolVar(&rc.PackagesFromStdin, "packages-from-stdin", false,
+		wh("Read packages to analyze from stdin if no packages were passed as arguments, e.g. from 'go list ./...'"))
+	fs.BoolVar(&rc.FilesFromStdin, "files-from-stdin", false,
+		wh("Read newline-separated go files from stdin, analyze their packages and report issues only in "+
+			"these files, e.g. changed files in a pre-commit hook. The same is done if the only argument is -"))
+	fs.StringVar(&rc.AtRevision, "at-rev", "",
+		wh("Analyze files checked out at git revision `REV` into a temporary worktree"))
 
 	// Linters settings config
 	lsc := &cfg.LintersSettings
@@ -143,19 +260,55 @@ func initFlagSet(fs *pflag.FlagSet, cfg *config.Config, m *lintersdb.Manager) {
 		wh(fmt.Sprintf("Enable presets (%s) of linters. Run 'golangci-lint linters' to see "+
 			"them. This option implies option --disable-all", strings.Join(m.AllPresets(), "|"))))
 	fs.BoolVar(&lc.Fast, "fast", false, wh("Run only fast linters from enabled linters set (first run won't be fast)"))
+	fs.BoolVar(&rc.OnlyExplicitLinters, "only-explicit-linters", false,
+		wh("Run exactly linters enabled by --enable: default linters and options --enable-all, "+
+			"--presets and --fast are ignored"))
+	fs.BoolVar(&lc.FailIfNoLinters, "fail-if-no-linters", false,
+		wh("Fail the run if no linters are enabled instead of warning about it"))
 
 	// Issues config
 	ic := &cfg.Issues
-	fs.StringSliceVarP(&ic.ExcludePatterns, "exclude", "e", nil, wh("Exclude issue by regexp"))
+	fs.StringSliceVarP(&ic.ExcludePatterns, "exclude", "e", nil, wh("Exclude issues with texts matching case-insensitive regexp"))
 	fs.BoolVar(&ic.UseDefaultExcludes, "exclude-use-default", true, getDefaultExcludeHelp())
+	fs.StringSliceVar(&ic.IncludeDefaultExcludes, "include", nil,
+		wh("Use default excludes with these ids, e.g. EXC0001, even if --exclude-use-default=false"))
+	fs.StringSliceVar(&ic.GeneratedDirs, "generated-dirs", nil,
+		wh("Globs of directories with only generated files, ** matches any count of dirs: issues from them are never reported"))
+	fs.StringSliceVar(&ic.GeneratedFiles, "generated-files", nil,
+		wh("Globs of generated files, ** matches any count of dirs: issues from them are never reported"))
+	fs.StringSliceVar(&ic.NeverGenerated, "never-generated", nil,
+		wh("Globs of files never treated as generated even with generated code markers, ** matches any count of dirs"))
+	fs.StringSliceVar(&ic.AutogenMarkers, "autogen-markers", nil,
+		wh("Case-insensitive substrings of header comments marking generated files in addition to "+
+			"default ones: code generated, do not edit, autogenerated file"))
+	fs.StringVar(&ic.AutogenMode, "autogen-mode", config.AutogenModeLax,
+		wh(fmt.Sprintf("Mode of detection of generated files by header comments: %s matches any of autogen markers, "+
+			"%s matches only a line \"// Code generated ... DO NOT EDIT.\" before package clause",
+			config.AutogenModeLax, config.AutogenModeStrict)))
+	fs.BoolVar(&ic.AutogenScanFullFile, "autogen-scan-full-file", false,
+		wh("If header comments don't contain autogen markers, search them in other comments of the file, "+
+			"e.g. in a footer comment: it's used only in lax autogen mode"))
+	fs.StringVar(&ic.GeneratedTag, "generated-tag", "generated",
+		wh("Build tag marking generated files: files requiring it by build constraints are treated as generated. "+
+			"Set to empty string to disable"))
+	fs.StringSliceVar(&ic.ExcludeAt, "exclude-at", nil,
+		wh("Hide issues of linters at file lines like nolint directives do: entries are in format file:line:linter"))
+	fs.BoolVar(&ic.ReportUnusedExcludeAt, "report-unused-exclude-at", false,
+		wh("Warn about exclude-at entries not matching any issue"))
+	fs.StringSliceVar(&ic.ExcludeStructTags, "exclude-struct-tags", nil,
+		wh("Exclude issues on struct fields with tag key or key:value, e.g. lint:ignore"))
 
 	fs.IntVar(&ic.MaxIssuesPerLinter, "max-issues-per-linter", 50,
 		wh("Maximum issues count per one linter. Set to 0 to disable"))
 	fs.IntVar(&ic.MaxSameIssues, "max-same-issues", 3,
-		wh("Maximum count of issues with the same text. Set to 0 to disable"))
+		wh("Maximum count of issues with the same text in the whole run: texts are compared without numbers "+
+			"and extra spaces. Set to 0 to disable"))
+	fs.StringVar(&ic.UniqBy, "uniq-by", config.UniqByLine,
+		wh(fmt.Sprintf("Key of deduplication of issues reported by different linters: %s keeps one issue per line, "+
+			"%s keeps one issue per position and text", config.UniqByLine, config.UniqByText)))
 
 	fs.BoolVarP(&ic.Diff, "new", "n", false,
-		wh("Show only new issues: if there are unstaged changes or untracked files, only those changes "+
+		wh("Show only new issues: if there are uncommitted changes or untracked files, only those changes "+
 			"are analyzed, else only changes in HEAD~ are analyzed.\nIt's a super-useful option for integration "+
 			"of golangci-lint into existing large codebase.\nIt's not practical to fix all existing issues at "+
 			"the moment of integration: much better to not allow issues in new code.\nFor CI setups, prefer "+
@@ -164,7 +317,35 @@ func initFlagSet(fs *pflag.FlagSet, cfg *config.Config, m *lintersdb.Manager) {
 	fs.StringVar(&ic.DiffFromRevision, "new-from-rev", "",
 		wh("Show only new issues created after git revision `REV`"))
 	fs.StringVar(&ic.DiffPatchFilePath, "new-from-patch", "",
-		wh("Show only new issues created in git patch with file path `PATH`"))
+		wh("Show only new issues created in git patch with file path `PATH`: it's unified diff with a/ and b/ "+
+			"path prefixes like output of git diff, git isn't run"))
+	fs.BoolVar(&ic.FailOnNewOnly, "fail-on-new-only", false,
+		wh("Show all issues, but use issues exit code only if new issues were found: "+
+			"it's used with --new, --new-from-rev, --new-from-patch or --baseline"))
+	fs.StringSliceVar(&ic.FailOnPresets, "fail-on-presets", nil,
+		wh(fmt.Sprintf("Show all issues, but use issues exit code only if issues from linters of "+
+			"presets (%s) were found", strings.Join(m.AllPresets(), "|"))))
+	fs.StringSliceVar(&ic.ErrorLinters, "error-linters", nil,
+		wh("Show all issues, but use issues exit code only if issues from these linters were found"))
+	fs.StringSliceVar(&ic.WarningLinters, "warning-linters", nil,
+		wh("Show all issues, but don't use issues exit code because of issues from these linters"))
+	fs.StringVar(&ic.Baseline, "baseline", "",
+		wh("Show only issues absent in the baseline file with path `PATH`: issues are matched by "+
+			"fingerprints, not by lines"))
+	fs.StringVar(&ic.WriteBaseline, "write-baseline", "",
+		wh("Write all found issues to the baseline file with path `PATH`"))
+	fs.BoolVar(&ic.OnlyNew, "only-new", false,
+		wh("Show only issues absent in the snapshot file committed to the repository: "+
+			"it has the baseline file format"))
+	fs.StringVar(&ic.Snapshot, "snapshot", ".golangci.issues",
+		wh("Path of the snapshot file used by --only-new"))
+	fs.BoolVar(&ic.UpdateSnapshot, "update-snapshot", false,
+		wh("Rewrite the snapshot file used by --only-new dropping fixed issues: new issues aren't added"))
+	fs.BoolVar(&ic.AllowRatchetLoosen, "allow-ratchet-loosen", false,
+		wh("Add new issues to the snapshot file by --update-snapshot"))
+	fs.BoolVar(&ic.NeedFix, "fix", false,
+		wh("Fix found issues if it's supported by the linter: fixed issues aren't printed, "+
+			"the run fails if a file can't be fixed"))
 
 }
 
@@ -239,17 +420,140 @@ func fixSlicesFlags(fs *pflag.FlagSet) {
 	})
 }
 
+// readPackages reads whitespace-separated packages, e.g. output of 'go list ./...'
+func readPackages(r io.Reader) ([]string, error) {
+	var pkgs []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		pkgs = append(pkgs, strings.Fields(scanner.Text())...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "can't read packages from stdin")
+	}
+
+	return pkgs, nil
+}
+
+// readFilesFromStdin reads newline-separated file paths from stdin: only go files
+// are returned, their paths are relative to the current dir if it's possible
+func (e *Executor) readFilesFromStdin() ([]string, error) {
+	var files []string
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		path := strings.TrimSpace(scanner.Text())
+		if path == "" {
+			continue
+		}
+		if !strings.HasSuffix(path, ".go") {
+			e.log.Infof("Skip non-go file %s from stdin", path)
+			continue
+		}
+
+		if relPath, err := fsutils.ShortestRelPath(path, ""); err == nil {
+			path = relPath
+		}
+		files = append(files, path)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "can't read files from stdin")
+	}
+
+	return files, nil
+}
+
+// packagesOfFiles returns dirs of files as packages to analyze
+func packagesOfFiles(files []string) []string {
+	var pkgs []string
+	seen := map[string]bool{}
+	for _, f := range files {
+		dir := filepath.Dir(f)
+		if !seen[dir] {
+			seen[dir] = true
+			pkgs = append(pkgs, dir)
+		}
+	}
+
+	return pkgs
+}
+
+// argsFromStdin returns packages to analyze: they are read from stdin if
+// --files-from-stdin ("-" argument) or --packages-from-stdin is passed
+func (e *Executor) argsFromStdin(args []string) ([]string, error) {
+	if len(args) == 1 && args[0] == "-" {
+		e.cfg.Run.FilesFromStdin = true
+		args = nil
+	}
+	if e.cfg.Run.FilesFromStdin && e.cfg.Run.PackagesFromStdin {
+		return nil, errors.New("can't combine options --files-from-stdin and --packages-from-stdin")
+	}
+
+	if e.cfg.Run.FilesFromStdin {
+		return e.fileArgsFromStdin(args)
+	}
+
+	if e.cfg.Run.PackagesFromStdin {
+		if len(args) != 0 {
+			e.log.Infof("Packages were passed as arguments: don't read them from stdin")
+			e.cfg.Run.PackagesFromStdin = false
+			return args, nil
+		}
+		return e.packageArgsFromStdin()
+	}
+
+	return args, nil
+}
+
+// fileArgsFromStdin reads files to analyze from stdin and returns their packages
+func (e *Executor) fileArgsFromStdin(args []string) ([]string, error) {
+	if len(args) != 0 {
+		return nil, errors.New("files are read from stdin: don't pass packages as arguments")
+	}
+	if e.cfg.Issues.UpdateSnapshot {
+		// issues out of files from stdin are hidden before the snapshot processor
+		return nil, errors.New("option --update-snapshot can't be used with files from stdin")
+	}
+
+	files, err := e.readFilesFromStdin()
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.Wrap(exitcodes.ErrNoGoFiles, "no go files were passed to stdin")
+	}
+
+	e.log.Infof("Read %d files from stdin", len(files))
+	e.cfg.Run.OnlyFiles = files
+	return packagesOfFiles(files), nil
+}
+
+func (e *Executor) packageArgsFromStdin() ([]string, error) {
+	pkgs, err := readPackages(os.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return nil, errors.Wrap(exitcodes.ErrNoGoFiles, "no packages were passed to stdin")
+	}
+
+	e.log.Infof("Read %d packages from stdin", len(pkgs))
+	return pkgs, nil
+}
+
 func (e *Executor) runAnalysis(ctx context.Context, args []string) (<-chan result.Issue, error) {
+	args, err := e.argsFromStdin(args)
+	if err != nil {
+		return nil, err
+	}
 	e.cfg.Run.Args = args
 
-	enabledLinters, err := e.EnabledLintersSet.Get()
+	analysis, err := lint.NewAnalysis(ctx, e.cfg, args, e.log, e.goenv)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, lc := range e.DBManager.GetAllSupportedLinterConfigs() {
 		isEnabled := false
-		for _, enabledLC := range enabledLinters {
+		for _, enabledLC := range analysis.Linters {
 			if enabledLC.Name() == lc.Name() {
 				isEnabled = true
 				break
@@ -258,18 +562,96 @@ func (e *Executor) runAnalysis(ctx context.Context, args []string) (<-chan resul
 		e.reportData.AddLinter(lc.Name(), isEnabled, lc.EnabledByDefault)
 	}
 
-	lintCtx, err := e.contextLoader.Load(ctx, enabledLinters)
+	if e.cfg.Run.DryRun {
+		return nil, e.printDryRun(ctx, analysis)
+	}
+
+	if err = analysis.Load(ctx); err != nil {
+		return nil, err
+	}
+	e.extendDeadlineForPackages(len(analysis.Context.Packages))
+	e.summary.Packages = len(analysis.Context.Packages)
+
+	if e.cfg.Run.PrintProcessors {
+		printProcessors(analysis.Runner.Processors)
+	}
+	e.runner = analysis.Runner
+
+	return analysis.Issues(ctx), nil
+}
+
+// extendDeadlineForPackages increases deadline of the run command
+// according to the run.deadline-per-package option
+func (e *Executor) extendDeadlineForPackages(pkgsCount int) {
+	if e.cfg.Run.DeadlinePerPackage <= 0 || e.deadlineTimer == nil {
+		return
+	}
+
+	e.deadline = e.cfg.Run.Timeout + time.Duration(pkgsCount)*e.cfg.Run.DeadlinePerPackage
+	e.log.Infof("Computed deadline %s for %d packages", e.deadline, pkgsCount)
+
+	if !e.deadlineTimer.Stop() {
+		return // deadline was already exceeded
+	}
+	e.deadlineTimer.Reset(e.deadline - time.Since(e.startedAt))
+}
+
+// printDryRun prints what would be analyzed: packages are only listed, not loaded
+func (e *Executor) printDryRun(ctx context.Context, analysis *lint.Analysis) error {
+	pkgs, err := analysis.ListPackages(ctx)
 	if err != nil {
-		return nil, errors.Wrap(err, "context loading failed")
+		return err
 	}
-	lintCtx.Log = e.log.Child("linters context")
 
-	runner, err := lint.NewRunner(lintCtx.ASTCache, e.cfg, e.log.Child("runner"), e.goenv)
+	settings, err := yaml.Marshal(e.cfg.Settings())
 	if err != nil {
-		return nil, err
+		return errors.Wrap(err, "can't marshal config")
+	}
+
+	fmt.Fprintf(logutils.StdOut, "Packages:\n")
+	for _, pkg := range pkgs {
+		fmt.Fprintf(logutils.StdOut, "  %s\n", pkg.ID)
+		for _, f := range pkg.GoFiles {
+			if relPath, err := fsutils.ShortestRelPath(f, ""); err == nil {
+				f = relPath
+			}
+			fmt.Fprintf(logutils.StdOut, "    %s\n", f)
+		}
 	}
 
-	return runner.Run(ctx, enabledLinters, lintCtx), nil
+	fmt.Fprintf(logutils.StdOut, "Enabled linters:\n")
+	for _, lc := range analysis.Linters {
+		fmt.Fprintf(logutils.StdOut, "  %s\n", lc.Name())
+	}
+
+	fmt.Fprintf(logutils.StdOut, "Config:\n")
+	for _, line := range strings.Split(strings.TrimSuffix(string(settings), "\n"), "\n") {
+		fmt.Fprintf(logutils.StdOut, "  %s\n", line)
+	}
+
+	return nil
+}
+
+func printProcessors(ps []processors.Processor) {
+	// print to stderr to not break machine-readable output formats
+	fmt.Fprintf(logutils.StdErr, "Processors pipeline:\n")
+	for i, p := range ps {
+		fmt.Fprintf(logutils.StdErr, "%d. %s\n", i+1, p.Name())
+	}
+}
+
+// hideLintersOutput doesn't allow linters and loader to print anything unless
+// linters_output debug tag is set. The returned func restores the output.
+func (e *Executor) hideLintersOutput() func() {
+	if logutils.HaveDebugTag("linters_output") {
+		return func() {}
+	}
+
+	log.SetOutput(ioutil.Discard)
+	savedStdout, savedStderr := e.setOutputToDevNull()
+	return func() {
+		os.Stdout, os.Stderr = savedStdout, savedStderr
+	}
 }
 
 func (e *Executor) setOutputToDevNull() (savedStdout, savedStderr *os.File) {
@@ -290,7 +672,9 @@ func (e *Executor) setExitCodeIfIssuesFound(issues <-chan result.Issue) <-chan r
 	go func() {
 		issuesFound := false
 		for i := range issues {
-			issuesFound = true
+			if !i.IsOld && e.isIssueFailing(&i) {
+				issuesFound = true
+			}
 			resCh <- i
 		}
 
@@ -304,58 +688,697 @@ func (e *Executor) setExitCodeIfIssuesFound(issues <-chan result.Issue) <-chan r
 	return resCh
 }
 
+// countIssues counts printed issues for the summary: the summary must be printed
+// only after the returned channel was drained
+func (e *Executor) countIssues(issues <-chan result.Issue) <-chan result.Issue {
+	resCh := make(chan result.Issue, 1024)
+	e.summary.IssuesByLinter = map[string]int{}
+
+	go func() {
+		for i := range issues {
+			e.summary.Issues++
+			e.summary.IssuesByLinter[i.FromLinter]++
+			resCh <- i
+		}
+
+		close(resCh)
+	}()
+
+	return resCh
+}
+
+// printSummary prints the summary to stderr to not break machine-readable output formats
+func (e *Executor) printSummary() error {
+	e.summary.ElapsedMs = int64(time.Since(e.startedAt) / time.Millisecond)
+
+	out, err := json.Marshal(e.summary)
+	if err != nil {
+		return fmt.Errorf("can't marshal summary: %s", err)
+	}
+
+	fmt.Fprintln(logutils.StdErr, string(out))
+	return nil
+}
+
+// printLinterTimes prints times of linters to stderr to not break machine-readable output formats
+func (e *Executor) printLinterTimes() {
+	w := tabwriter.NewWriter(logutils.StdErr, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Linter\tTime")
+	for _, lt := range e.runner.LinterTimes() {
+		fmt.Fprintf(w, "%s\t%s\n", lt.Name, lt.Duration.Round(time.Millisecond))
+	}
+	w.Flush()
+}
+
+func (e *Executor) isIssueFailing(i *result.Issue) bool {
+	ic := &e.cfg.Issues
+	if stringsContain(ic.WarningLinters, i.FromLinter) {
+		return false
+	}
+	if len(ic.ErrorLinters) != 0 && !stringsContain(ic.ErrorLinters, i.FromLinter) {
+		return false
+	}
+
+	presets := ic.FailOnPresets
+	if len(presets) == 0 {
+		return true
+	}
+
+	return e.DBManager.IsLinterInPresets(i.FromLinter, presets)
+}
+
+func stringsContain(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeErrorLinters validates names of linters in issues.error-linters and
+// issues.warning-linters options and replaces alternative names by main names
+func (e *Executor) normalizeErrorLinters() error {
+	ic := &e.cfg.Issues
+	for _, opt := range []struct {
+		name    string
+		linters []string
+	}{
+		{"error-linters", ic.ErrorLinters},
+		{"warning-linters", ic.WarningLinters},
+	} {
+		for idx, name := range opt.linters {
+			lc := e.DBManager.GetLinterConfig(strings.ToLower(name))
+			if lc == nil {
+				return fmt.Errorf("no such linter %q in option issues.%s", name, opt.name)
+			}
+			opt.linters[idx] = lc.Name()
+		}
+	}
+
+	for _, name := range ic.ErrorLinters {
+		if stringsContain(ic.WarningLinters, name) {
+			return fmt.Errorf("linter %s can't be in both issues.error-linters and issues.warning-linters", name)
+		}
+	}
+
+	return nil
+}
+
+// setupColors enables or disables colors of all output by output.color option
+func (e *Executor) setupColors() error {
+	switch e.cfg.Output.Color {
+	case config.ColorAuto:
+		// color package disables colors if stdout isn't a terminal
+		if _, ok := os.LookupEnv("NO_COLOR"); ok {
+			color.NoColor = true
+		}
+	case config.ColorAlways:
+		color.NoColor = false
+	case config.ColorNever:
+		color.NoColor = true
+	default:
+		return fmt.Errorf("invalid color mode %q: allowed are %s, %s, %s", e.cfg.Output.Color,
+			config.ColorAuto, config.ColorAlways, config.ColorNever)
+	}
+
+	return nil
+}
+
+func (e *Executor) validateModulesDownloadMode() error {
+	mode := e.cfg.Run.ModulesDownloadMode
+	if mode == "" {
+		return nil
+	}
+
+	for _, m := range config.ModulesDownloadModes {
+		if m == mode {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid modules download mode %q: allowed are %s",
+		mode, strings.Join(config.ModulesDownloadModes, "|"))
+}
+
+func (e *Executor) validateFailOnPresets() error {
+	allPresets := map[string]bool{}
+	for _, p := range e.DBManager.AllPresets() {
+		allPresets[p] = true
+	}
+
+	for _, p := range e.cfg.Issues.FailOnPresets {
+		if !allPresets[p] {
+			return fmt.Errorf("no such fail-on preset %q: only next presets exist: (%s)",
+				p, strings.Join(e.DBManager.AllPresets(), "|"))
+		}
+	}
+
+	return nil
+}
+
+func (e *Executor) validateLintersTests() error {
+	for name := range e.cfg.LintersSettings.Tests {
+		if e.DBManager.GetLinterConfig(name) == nil {
+			return fmt.Errorf("no such linter %q in option linters-settings.%s.tests", name, name)
+		}
+	}
+	for name := range e.cfg.LintersSettings.Timeouts {
+		if e.DBManager.GetLinterConfig(name) == nil {
+			return fmt.Errorf("no such linter %q in option linters-settings.%s.timeout", name, name)
+		}
+	}
+
+	return nil
+}
+
+func (e *Executor) validateIssuesExitCode() error {
+	code := e.cfg.Run.ExitCodeIfIssuesFound
+	if code < 0 || code > 255 {
+		return fmt.Errorf("invalid issues exit code %d: it must be in range [0, 255]", code)
+	}
+
+	if exitcodes.IsReserved(code) {
+		return fmt.Errorf("invalid issues exit code %d: it's reserved for %s",
+			code, exitcodes.Reason(code))
+	}
+
+	return nil
+}
+
+// validateRunConfig fails on invalid options before slow loading of packages
+// and returns formats of the output.
+func (e *Executor) validateRunConfig() ([]config.OutputFormat, error) {
+	if err := e.validateRunOptions(); err != nil {
+		return nil, err
+	}
+
+	if err := e.validateProcessorsConfig(); err != nil {
+		return nil, err
+	}
+
+	outFormats, err := e.cfg.GetOutputFormats()
+	if err != nil {
+		return nil, err
+	}
+	if err = checkOutputFilesAreWritable(outFormats); err != nil {
+		return nil, err
+	}
+
+	return outFormats, nil
+}
+
+func (e *Executor) validateRunOptions() error {
+	if err := e.validateIssuesExitCode(); err != nil {
+		return err
+	}
+
+	if e.cfg.Run.Concurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d: it must be positive", e.cfg.Run.Concurrency)
+	}
+
+	if err := e.setupColors(); err != nil {
+		return err
+	}
+
+	if err := e.validateModulesDownloadMode(); err != nil {
+		return err
+	}
+
+	if _, err := goutil.ReleaseTags(e.cfg.Run.GoVersion); err != nil {
+		return err
+	}
+
+	if err := e.validateFailOnPresets(); err != nil {
+		return err
+	}
+
+	if err := e.normalizeErrorLinters(); err != nil {
+		return err
+	}
+
+	if err := e.validateLintersTests(); err != nil {
+		return err
+	}
+
+	return e.validateSnapshot()
+}
+
+// validateProcessorsConfig creates processors configured by options to fail before
+// slow loading of packages: processors of the run are created after it.
+func (e *Executor) validateProcessorsConfig() error {
+	if _, err := processors.NewSkipFiles(e.cfg.Run.SkipFiles); err != nil {
+		return err
+	}
+	excludePatterns, err := lint.GetExcludePatterns(e.cfg)
+	if err != nil {
+		return err
+	}
+	if _, err = processors.NewExclude(excludePatterns); err != nil {
+		return err
+	}
+	if _, err = processors.NewExcludeRules(lint.GetExcludeRules(e.cfg), nil, e.log); err != nil {
+		return err
+	}
+	if _, err = processors.NewIncludeRules(lint.GetIncludeRules(e.cfg)); err != nil {
+		return err
+	}
+	ic := &e.cfg.Issues
+	if _, err = processors.NewDiff(ic.Diff, ic.DiffFromRevision, ic.DiffPatchFilePath, ic.FailOnNewOnly); err != nil {
+		return err
+	}
+	if _, err = processors.NewNolint(nil, e.log, false, e.cfg.Issues.ExcludeAt, false); err != nil {
+		return err
+	}
+	_, err = processors.NewCategories(e.cfg.Output.Categories)
+	return err
+}
+
 func (e *Executor) runAndPrint(ctx context.Context, args []string) error {
-	if err := e.goenv.Discover(ctx); err != nil {
+	outFormats, err := e.validateRunConfig()
+	if err != nil {
+		return err
+	}
+
+	if err = e.checkLintLock(); err != nil {
+		return err
+	}
+
+	restoreGoCache, err := e.useEmptyGoCache()
+	if err != nil {
+		return err
+	}
+	defer restoreGoCache()
+
+	if err = e.goenv.Discover(ctx); err != nil {
 		e.log.Warnf("Failed to discover go env: %s", err)
 	}
 
-	if !logutils.HaveDebugTag("linters_output") {
-		// Don't allow linters and loader to print anything
-		log.SetOutput(ioutil.Discard)
-		savedStdout, savedStderr := e.setOutputToDevNull()
-		defer func() {
-			os.Stdout, os.Stderr = savedStdout, savedStderr
-		}()
+	restoreOutput := e.hideLintersOutput()
+	defer restoreOutput()
+
+	restoreWorkDir, err := e.chdirToRevisionWorktree(ctx)
+	if err != nil {
+		return err
 	}
+	defer restoreWorkDir()
 
 	issues, err := e.runAnalysis(ctx, args)
 	if err != nil {
 		return err // XXX: don't loose type
 	}
+	if e.cfg.Run.DryRun {
+		return nil
+	}
 
-	p, err := e.createPrinter()
-	if err != nil {
+	issues = e.setExitCodeIfIssuesFound(issues)
+	if e.cfg.Output.PrintSummary {
+		issues = e.countIssues(issues)
+	}
+
+	if err = e.printIssues(ctx, outFormats, issues); err != nil {
 		return err
 	}
 
-	issues = e.setExitCodeIfIssuesFound(issues)
+	return e.finishRun()
+}
+
+// finishRun prints the summary and times of linters and writes the baseline or
+// the snapshot: it's called after issues were printed.
+func (e *Executor) finishRun() error {
+	if e.cfg.Output.PrintSummary {
+		if err := e.printSummary(); err != nil {
+			return err
+		}
+	}
+
+	if e.cfg.Output.PrintLinterTime {
+		e.printLinterTimes()
+	}
+
+	if failed := e.runner.FailedLinters(); len(failed) != 0 && e.cfg.Run.FailOnLinterErrors {
+		return fmt.Errorf("linters failed to run: %s", strings.Join(failed, ", "))
+	}
+
+	if e.cfg.Issues.WriteBaseline != "" {
+		return e.writeBaseline()
+	}
+
+	if e.cfg.Issues.UpdateSnapshot {
+		return e.updateSnapshot()
+	}
+
+	return nil
+}
+
+// writeBaseline atomically writes all issues found in this run to the baseline file
+func (e *Executor) writeBaseline() error {
+	path := e.cfg.Issues.WriteBaseline
+	if err := fsutils.WriteFileAtomically(path, e.runner.Baseline().WriteRecorded); err != nil {
+		return fmt.Errorf("can't write baseline file %s: %s", path, err)
+	}
+
+	e.log.Infof("Wrote baseline file %s", path)
+	return nil
+}
+
+func (e *Executor) validateSnapshot() error {
+	ic := &e.cfg.Issues
+	if err := validateSnapshotUpdate(ic); err != nil {
+		return err
+	}
+	if ic.OnlyNew && ic.Baseline != "" {
+		return errors.New("options --only-new and --baseline can't be used together")
+	}
+	if ic.OnlyNew && ic.Snapshot == "" {
+		return errors.New("option --only-new requires the snapshot file path")
+	}
+
+	return nil
+}
+
+func validateSnapshotUpdate(ic *config.Issues) error {
+	if !ic.UpdateSnapshot {
+		if ic.AllowRatchetLoosen {
+			return errors.New("option --allow-ratchet-loosen requires --update-snapshot")
+		}
+		return nil
+	}
+
+	if !ic.OnlyNew {
+		return errors.New("option --update-snapshot requires --only-new")
+	}
+	if ic.WriteBaseline != "" {
+		return errors.New("options --update-snapshot and --write-baseline can't be used together")
+	}
+	if ic.Diff || ic.DiffFromRevision != "" || ic.DiffPatchFilePath != "" {
+		// issues out of changes are hidden before the snapshot processor
+		return errors.New("option --update-snapshot can't be used with --new, --new-from-rev and --new-from-patch")
+	}
+
+	return nil
+}
 
-	if err = p.Print(ctx, issues); err != nil {
-		return fmt.Errorf("can't print %d issues: %s", len(issues), err)
+// updateSnapshot atomically rewrites the snapshot file: the ratchet only tightens,
+// new issues are added only with --allow-ratchet-loosen. Issues are dropped from
+// the snapshot only for files and linters analyzed by this run: a run of a subset
+// of packages or linters, failed linters and linters exceeding their timeouts
+// don't drop issues which weren't checked.
+func (e *Executor) updateSnapshot() error {
+	path := e.cfg.Issues.Snapshot
+	newCount := 0
+	err := fsutils.WriteFileAtomically(path, func(w io.Writer) error {
+		var err error
+		newCount, err = e.runner.Baseline().WriteRatcheted(w, e.cfg.Issues.AllowRatchetLoosen, e.runner.Analyzed)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("can't write snapshot file %s: %s", path, err)
+	}
+
+	if newCount != 0 {
+		e.log.Warnf("%d new issues weren't added to the snapshot file %s: "+
+			"use --allow-ratchet-loosen to add them", newCount, path)
+	}
+	e.log.Infof("Updated snapshot file %s", path)
+	return nil
+}
+
+// checkOutputFilesAreWritable fails fast if output files can't be written after slow analysis
+func checkOutputFilesAreWritable(formats []config.OutputFormat) error {
+	for _, f := range formats {
+		if f.Path == config.OutPathStdout || f.Path == config.OutPathStderr {
+			continue
+		}
+
+		tmpFile, err := ioutil.TempFile(filepath.Dir(f.Path), filepath.Base(f.Path)+".tmp")
+		if err != nil {
+			return fmt.Errorf("can't write output in %s format to file %s: %s", f.Format, f.Path, err)
+		}
+		tmpFile.Close()
+		os.Remove(tmpFile.Name())
+	}
+
+	return nil
+}
+
+// printIssues prints issues in all output formats: files are written atomically.
+// Issues are streamed to the printer only if there is the only format printed to stdout.
+func (e *Executor) printIssues(ctx context.Context, formats []config.OutputFormat, issues <-chan result.Issue) error {
+	if e.cfg.Output.ShowSuppressed && !hasOutputFormat(formats, config.OutFormatJSON) {
+		e.log.Warnf("Suppressed issues are printed only in %s output format", config.OutFormatJSON)
+	}
+
+	if len(formats) == 1 && formats[0].Path == config.OutPathStdout {
+		p, err := e.createPrinter(formats[0].Format, logutils.StdOut)
+		if err != nil {
+			return err
+		}
+		if err = p.Print(ctx, issues); err != nil {
+			return fmt.Errorf("can't print issues: %s", err)
+		}
+		return nil
+	}
+
+	var allIssues []result.Issue
+	for i := range issues {
+		allIssues = append(allIssues, i)
+	}
+
+	for _, f := range formats {
+		if err := e.printToDestination(ctx, f, allIssues); err != nil {
+			return fmt.Errorf("can't print %d issues in %s format to %s: %s", len(allIssues), f.Format, f.Path, err)
+		}
 	}
 
 	return nil
 }
 
-func (e *Executor) createPrinter() (printers.Printer, error) {
-	var p printers.Printer
-	format := e.cfg.Output.Format
+// printToDestination prints issues in the output format to its stdout, stderr or file
+func (e *Executor) printToDestination(ctx context.Context, f config.OutputFormat, issues []result.Issue) error {
+	switch f.Path {
+	case config.OutPathStdout:
+		return e.printTo(ctx, f.Format, logutils.StdOut, issues)
+	case config.OutPathStderr:
+		return e.printTo(ctx, f.Format, logutils.StdErr, issues)
+	default:
+		return fsutils.WriteFileAtomically(f.Path, func(w io.Writer) error {
+			return e.printWithoutColors(ctx, f.Format, w, issues)
+		})
+	}
+}
+
+func hasOutputFormat(formats []config.OutputFormat, format string) bool {
+	for _, f := range formats {
+		if f.Format == format {
+			return true
+		}
+	}
+
+	return false
+}
+
+// printTo prints issues in the format to w
+func (e *Executor) printTo(ctx context.Context, format string, w io.Writer, issues []result.Issue) error {
+	p, err := e.createPrinter(format, w)
+	if err != nil {
+		return err
+	}
+
+	return p.Print(ctx, issuesToChan(issues))
+}
+
+// printWithoutColors prints issues in the format to w with disabled colors, e.g. to files
+func (e *Executor) printWithoutColors(ctx context.Context, format string, w io.Writer, issues []result.Issue) error {
+	savedNoColor := color.NoColor
+	color.NoColor = true
+	defer func() {
+		color.NoColor = savedNoColor
+	}()
+
+	return e.printTo(ctx, format, w, issues)
+}
+
+func issuesToChan(issues []result.Issue) <-chan result.Issue {
+	ch := make(chan result.Issue, len(issues))
+	for _, i := range issues {
+		ch <- i
+	}
+	close(ch)
+
+	return ch
+}
+
+// useEmptyGoCache points GOCACHE to a new temporary dir to not use go build cache
+// (loaded packages, cgo files and type information) without clearing it if --no-cache
+// is passed
+func (e *Executor) useEmptyGoCache() (func(), error) {
+	if !e.cfg.Run.NoCache {
+		return func() {}, nil
+	}
+
+	dir, err := ioutil.TempDir("", "golangci-lint-gocache")
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create temp dir for go build cache")
+	}
+
+	savedGoCache, hadGoCache := os.LookupEnv("GOCACHE")
+	if err = os.Setenv("GOCACHE", dir); err != nil {
+		os.RemoveAll(dir)
+		return nil, errors.Wrap(err, "can't set GOCACHE")
+	}
+	e.log.Infof("Caches are skipped: using empty go build cache %s", dir)
+
+	return func() {
+		if hadGoCache {
+			os.Setenv("GOCACHE", savedGoCache)
+		} else {
+			os.Unsetenv("GOCACHE")
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			e.log.Warnf("Failed to remove temp go build cache %s: %s", dir, err)
+		}
+	}, nil
+}
+
+// checkLintLock compares versions of golangci-lint and linters with the lock file
+// passed by --lint-lock
+func (e *Executor) checkLintLock() error {
+	path := e.cfg.Run.LintLock
+	if path == "" {
+		return nil
+	}
+
+	actual := lintlock.Current(e.version)
+	locked, err := lintlock.Read(path)
+	if err != nil {
+		return err
+	}
+
+	if locked == nil {
+		e.log.Infof("Lock file %s doesn't exist: creating it", path)
+		return actual.Write(path)
+	}
+
+	diff := lintlock.Diff(locked, actual)
+	if len(diff) == 0 {
+		return nil
+	}
+
+	if e.cfg.Run.UpdateLock {
+		e.log.Infof("Updating lock file %s: %d versions changed", path, len(diff))
+		return actual.Write(path)
+	}
+
+	for _, d := range diff {
+		e.log.Errorf("Version mismatch: %s", d)
+	}
+	return fmt.Errorf("%d versions differ from lock file %s: pass --update-lock to update it", len(diff), path)
+}
+
+// chdirToRevisionWorktree changes current dir to the same dir in the worktree of the
+// revision passed by --at-rev: paths of issues are relative to the current dir,
+// so they stay valid for the original tree.
+func (e *Executor) chdirToRevisionWorktree(ctx context.Context) (func(), error) {
+	if e.cfg.Run.AtRevision == "" {
+		return func() {}, nil
+	}
+
+	wt, err := gitutil.NewWorktree(ctx, e.cfg.Run.AtRevision, e.log.Child("worktree"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't check out revision %s", e.cfg.Run.AtRevision)
+	}
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		wt.Remove()
+		return nil, errors.Wrap(err, "can't get working dir")
+	}
+
+	if err = os.Chdir(wt.WorkDir()); err != nil {
+		wt.Remove()
+		return nil, errors.Wrapf(err, "can't change dir to worktree")
+	}
+
+	// the cached working dir points to the original tree
+	fsutils.UseWdCache(false)
+
+	return func() {
+		if err := os.Chdir(origDir); err != nil {
+			e.log.Warnf("Can't change dir back to %s: %s", origDir, err)
+		}
+		fsutils.UseWdCache(true)
+		wt.Remove()
+	}, nil
+}
+
+func (e *Executor) createPrinter(format string, w io.Writer) (printers.Printer, error) {
 	switch format {
 	case config.OutFormatJSON:
-		p = printers.NewJSON(&e.reportData)
-	case config.OutFormatColoredLineNumber, config.OutFormatLineNumber:
-		p = printers.NewText(e.cfg.Output.PrintIssuedLine,
-			format == config.OutFormatColoredLineNumber, e.cfg.Output.PrintLinterName,
-			e.log.Child("text_printer"))
+		return printers.NewJSON(&e.reportData, e.runner.SuppressedIssues, e.printedMetadata(), e.cfg.Output.PrintIssuedLine, w), nil
+	case config.OutFormatColoredLineNumber, config.OutFormatLineNumber, config.OutFormatTextGrouped:
+		return printers.NewText(e.cfg.Output.PrintIssuedLine,
+			format != config.OutFormatLineNumber, e.cfg.Output.PrintLinterName,
+			e.cfg.Output.ShowCaret, format == config.OutFormatTextGrouped, e.log.Child("text_printer"), w), nil
 	case config.OutFormatTab:
-		p = printers.NewTab(e.cfg.Output.PrintLinterName, e.log.Child("tab_printer"))
+		return printers.NewTab(e.cfg.Output.PrintLinterName, e.cfg.Output.TabColumns, e.log.Child("tab_printer"), w), nil
 	case config.OutFormatCheckstyle:
-		p = printers.NewCheckstyle()
+		return printers.NewCheckstyle(e.cfg.Output.HideZeroColumn, w), nil
+	case config.OutFormatSARIF:
+		return printers.NewSARIF(e.printedMetadata(), w), nil
+	case config.OutFormatCodeClimate:
+		return printers.NewCodeClimate(func(name string) bool {
+			return e.DBManager.IsLinterInPresets(name, []string{linter.PresetBugs})
+		}, w), nil
+	case config.OutFormatJUnitXML:
+		return printers.NewJUnitXML(&e.reportData, w), nil
+	default:
+		return createPrinterWithoutOptions(format, w)
+	}
+}
+
+// createPrinterWithoutOptions creates printers of formats not depending on options
+func createPrinterWithoutOptions(format string, w io.Writer) (printers.Printer, error) {
+	switch format {
+	case config.OutFormatTap:
+		return printers.NewTap(w), nil
+	case config.OutFormatGitHubActions:
+		return printers.NewGitHubActions(w), nil
 	default:
 		return nil, fmt.Errorf("unknown output format %s", format)
 	}
+}
+
+// printedMetadata returns metadata of the run if it's printed by --print-metadata
+func (e *Executor) printedMetadata() *report.Metadata {
+	if !e.cfg.Output.PrintMetadata {
+		return nil
+	}
+
+	return e.getMetadata("")
+}
+
+// getMetadata returns metadata of the run: git fields are set only
+// if dir is in a git repo, empty dir is the current dir
+func (e *Executor) getMetadata(dir string) *report.Metadata {
+	m := &report.Metadata{
+		GoVersion:       runtime.Version(),
+		GolangciVersion: e.version,
+		Timestamp:       e.startedAt.UTC(),
+	}
+
+	commit, branch, err := gitutil.HeadInfo(context.Background(), dir)
+	if err != nil {
+		e.log.Infof("Can't get git metadata: %s", err)
+		return m
+	}
 
-	return p, nil
+	m.Git = &report.GitMetadata{
+		Commit: commit,
+		Branch: branch,
+	}
+	return m
 }
 
 func (e *Executor) executeRun(cmd *cobra.Command, args []string) {
@@ -367,31 +1390,129 @@ func (e *Executor) executeRun(cmd *cobra.Command, args []string) {
 		}
 	}()
 
-	ctx, cancel := context.WithTimeout(context.Background(), e.cfg.Run.Deadline)
+	// deadline can be extended after packages loading: use timer instead of context.WithTimeout
+	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	e.applyDeprecatedDeadline(cmd)
+
+	e.startedAt = time.Now()
+	e.deadline = e.cfg.Run.Timeout
+	e.deadlineTimer = time.AfterFunc(e.deadline, cancel)
+	defer e.deadlineTimer.Stop()
+
 	if needTrackResources {
 		go watchResources(ctx, trackResourcesEndCh, e.log)
 	}
 
-	if err := e.runAndPrint(ctx, args); err != nil {
+	analysisCtx := ctx
+	if e.cfg.Run.MaxMemory > 0 {
+		var cancelAnalysis context.CancelFunc
+		analysisCtx, cancelAnalysis = context.WithCancel(ctx)
+		defer cancelAnalysis()
+
+		go e.watchMemoryLimit(analysisCtx, cancelAnalysis)
+	}
+
+	if err := e.runAndPrint(analysisCtx, args); err != nil {
 		e.log.Errorf("Running error: %s", err)
-		if e.exitCode == exitcodes.Success {
-			if exitErr, ok := errors.Cause(err).(*exitcodes.ExitError); ok {
-				e.exitCode = exitErr.Code
-			} else {
-				e.exitCode = exitcodes.Failure
-			}
+		e.exitMessage = err.Error()
+		// exit code can be already set by found issues: errors must not look like found issues
+		if exitErr, ok := errors.Cause(err).(*exitcodes.ExitError); ok {
+			e.exitCode = exitErr.Code
+		} else {
+			e.exitCode = exitcodes.Failure
 		}
 	}
 
 	e.setupExitCode(ctx)
+
+	if e.cfg.Run.ExitReasonPath != "" {
+		if err := e.writeExitReason(); err != nil {
+			e.log.Warnf("Can't report exit reason: %s", err)
+		}
+	}
+}
+
+// applyDeprecatedDeadline uses --deadline as timeout if --timeout isn't passed
+func (e *Executor) applyDeprecatedDeadline(cmd *cobra.Command) {
+	if e.cfg.Run.Deadline == 0 {
+		return
+	}
+
+	e.log.Infof("Flag --deadline is deprecated, use --timeout instead")
+	if !cmd.Flags().Changed("timeout") {
+		e.cfg.Run.Timeout = e.cfg.Run.Deadline
+	}
+}
+
+type exitReason struct {
+	Code    int    `json:"code"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// writeExitReason writes exit reason to the file for non-success exit code
+// and removes the file left by a previous run otherwise
+func (e *Executor) writeExitReason() error {
+	path := e.cfg.Run.ExitReasonPath
+	if e.exitCode == exitcodes.Success {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	reason := exitcodes.Reason(e.exitCode)
+	if e.exitMessage == "" && e.exitCode == e.cfg.Run.ExitCodeIfIssuesFound {
+		reason = exitcodes.Reason(exitcodes.IssuesFound) // exit code can be customized
+		e.exitMessage = "issues were found"
+	}
+
+	data, err := json.Marshal(exitReason{
+		Code:    e.exitCode,
+		Reason:  reason,
+		Message: e.exitMessage,
+	})
+	if err != nil {
+		return err
+	}
+
+	return fsutils.WriteFileAtomically(path, func(w io.Writer) error {
+		_, err := fmt.Fprintln(w, string(data))
+		return err
+	})
+}
+
+// describeUnfinishedWork describes work which wasn't finished before the deadline
+func (e *Executor) describeUnfinishedWork() string {
+	if e.runner == nil {
+		return "Loading of packages wasn't finished"
+	}
+
+	unfinished := e.runner.UnfinishedLinters()
+	if len(unfinished) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("Unfinished linters: %s", strings.Join(unfinished, ", "))
 }
 
 func (e *Executor) setupExitCode(ctx context.Context) {
-	if ctx.Err() != nil {
+	if atomic.LoadInt32(&e.memoryLimitExceeded) != 0 {
+		e.exitCode = exitcodes.MemoryLimitExceeded
+		e.exitMessage = "Memory limit exceeded: try increase it by passing --max-memory option"
+		e.log.Errorf("%s", e.exitMessage)
+	} else if ctx.Err() != nil {
 		e.exitCode = exitcodes.Timeout
-		e.log.Errorf("Deadline exceeded: try increase it by passing --deadline option")
+		e.exitMessage = e.timeoutMessage()
+		e.log.Errorf("%s", e.exitMessage)
+	} else if e.runner != nil && len(e.runner.NotFixedFiles()) != 0 {
+		notFixed := e.runner.NotFixedFiles()
+		e.exitCode = exitcodes.Failure
+		e.exitMessage = fmt.Sprintf("Can't fix issues in %d files, their issues weren't reported: %s",
+			len(notFixed), strings.Join(notFixed, ", "))
+		e.log.Errorf("%s", e.exitMessage)
 	}
 
 	if e.exitCode == exitcodes.Success &&
@@ -399,6 +1520,48 @@ func (e *Executor) setupExitCode(ctx context.Context) {
 		len(e.reportData.Warnings) != 0 {
 
 		e.exitCode = exitcodes.WarningInTest
+		e.exitMessage = fmt.Sprintf("%d warnings in test run", len(e.reportData.Warnings))
+	}
+}
+
+// timeoutMessage describes the exceeded timeout and work unfinished because of it
+func (e *Executor) timeoutMessage() string {
+	msg := "Timeout exceeded: try increase it by passing --timeout option"
+	if e.cfg.Run.DeadlinePerPackage > 0 {
+		msg = fmt.Sprintf("Timeout %s exceeded: try increase it by passing --timeout or "+
+			"--deadline-per-package option", e.deadline)
+	}
+
+	if unfinished := e.describeUnfinishedWork(); unfinished != "" {
+		msg += ". " + unfinished
+	}
+	return msg
+}
+
+func (e *Executor) watchMemoryLimit(ctx context.Context, cancel context.CancelFunc) {
+	const MB = 1024 * 1024
+	limit := uint64(e.cfg.Run.MaxMemory) * MB
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+
+		if m.Sys > limit {
+			e.log.Infof("Memory usage %.1fMB exceeded limit %dMB: stopping analysis",
+				float64(m.Sys)/MB, e.cfg.Run.MaxMemory)
+			atomic.StoreInt32(&e.memoryLimitExceeded, 1)
+			cancel()
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
 }
 
@@ -0,0 +1,234 @@
+package commands
+
+import (
+	"context"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestFlagsDefaultsAreInDefaultConfig(t *testing.T) {
+	// lint.Run uses config.NewDefault(): it must be the config of the run command without flags
+	cfg := config.NewDefault()
+	initFlagSet(pflag.NewFlagSet("run", pflag.ContinueOnError), cfg, lintersdb.NewManager())
+	assert.Equal(t, config.NewDefault(), cfg)
+}
+
+func TestReadPackages(t *testing.T) {
+	pkgs, err := readPackages(strings.NewReader("github.com/org/repo\ngithub.com/org/repo/pkg/a  ./b\n\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"github.com/org/repo", "github.com/org/repo/pkg/a", "./b"}, pkgs)
+
+	pkgs, err = readPackages(strings.NewReader(""))
+	assert.NoError(t, err)
+	assert.Empty(t, pkgs)
+}
+
+func TestArgsFromStdin(t *testing.T) {
+	newExecutor := func() *Executor {
+		return &Executor{cfg: config.NewDefault(), log: logutils.NewStderrLog("")}
+	}
+
+	e := newExecutor()
+	args, err := e.argsFromStdin([]string{"./..."})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"./..."}, args)
+
+	// packages passed as arguments aren't read from stdin
+	e = newExecutor()
+	e.cfg.Run.PackagesFromStdin = true
+	args, err = e.argsFromStdin([]string{"./pkg/..."})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"./pkg/..."}, args)
+	assert.False(t, e.cfg.Run.PackagesFromStdin)
+
+	e = newExecutor()
+	e.cfg.Run.PackagesFromStdin = true
+	_, err = e.argsFromStdin([]string{"-"})
+	assert.EqualError(t, err, "can't combine options --files-from-stdin and --packages-from-stdin")
+
+	e = newExecutor()
+	e.cfg.Run.FilesFromStdin = true
+	_, err = e.argsFromStdin([]string{"./..."})
+	assert.EqualError(t, err, "files are read from stdin: don't pass packages as arguments")
+
+	e = newExecutor()
+	e.cfg.Issues.UpdateSnapshot = true
+	_, err = e.argsFromStdin([]string{"-"})
+	assert.EqualError(t, err, "option --update-snapshot can't be used with files from stdin")
+	assert.True(t, e.cfg.Run.FilesFromStdin)
+}
+
+func TestWatchMemoryLimit(t *testing.T) {
+	e := &Executor{cfg: config.NewDefault(), log: logutils.NewStderrLog("")}
+
+	// the process always uses more than 1MB: the analysis is stopped
+	e.cfg.Run.MaxMemory = 1
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	e.watchMemoryLimit(ctx, cancel)
+	assert.Error(t, ctx.Err())
+	assert.EqualValues(t, 1, atomic.LoadInt32(&e.memoryLimitExceeded))
+
+	e.setupExitCode(ctx)
+	assert.Equal(t, exitcodes.MemoryLimitExceeded, e.exitCode)
+	assert.Contains(t, e.exitMessage, "--max-memory")
+
+	// the watcher exits when the analysis is done
+	e = &Executor{cfg: config.NewDefault(), log: logutils.NewStderrLog("")}
+	e.cfg.Run.MaxMemory = 1024 * 1024
+	doneCtx, doneCancel := context.WithCancel(context.Background())
+	doneCancel()
+	e.watchMemoryLimit(doneCtx, func() { t.Error("analysis must not be stopped") })
+	assert.EqualValues(t, 0, atomic.LoadInt32(&e.memoryLimitExceeded))
+}
+
+func TestExtendDeadlineForPackages(t *testing.T) {
+	newExecutor := func(perPackage time.Duration) (*Executor, context.Context, context.CancelFunc) {
+		e := &Executor{cfg: config.NewDefault(), log: logutils.NewStderrLog("")}
+		e.cfg.Run.Timeout = 50 * time.Millisecond
+		e.cfg.Run.DeadlinePerPackage = perPackage
+
+		ctx, cancel := context.WithCancel(context.Background())
+		e.startedAt = time.Now()
+		e.deadline = e.cfg.Run.Timeout
+		e.deadlineTimer = time.AfterFunc(e.deadline, cancel)
+		return e, ctx, cancel
+	}
+
+	e, ctx, cancel := newExecutor(time.Hour)
+	defer cancel()
+	e.extendDeadlineForPackages(2)
+	assert.Equal(t, 2*time.Hour+50*time.Millisecond, e.deadline)
+	time.Sleep(100 * time.Millisecond)
+	assert.NoError(t, ctx.Err())
+	e.deadlineTimer.Stop()
+
+	// disabled option doesn't change the deadline
+	e, ctx, cancel = newExecutor(0)
+	defer cancel()
+	e.extendDeadlineForPackages(2)
+	assert.Equal(t, 50*time.Millisecond, e.deadline)
+	<-ctx.Done()
+
+	// exceeded deadline isn't extended
+	e, ctx, cancel = newExecutor(time.Hour)
+	defer cancel()
+	<-ctx.Done()
+	e.extendDeadlineForPackages(2)
+	assert.Error(t, ctx.Err())
+
+	e.setupExitCode(ctx)
+	assert.Equal(t, exitcodes.Timeout, e.exitCode)
+	assert.Contains(t, e.exitMessage, "--deadline-per-package")
+}
+
+func TestDescribeUnfinishedWork(t *testing.T) {
+	// the runner is created after loading of packages
+	e := &Executor{cfg: config.NewDefault(), log: logutils.NewStderrLog("")}
+	assert.Equal(t, "Loading of packages wasn't finished", e.describeUnfinishedWork())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	e.setupExitCode(ctx)
+	assert.Equal(t, exitcodes.Timeout, e.exitCode)
+	assert.Equal(t, "Timeout exceeded: try increase it by passing --timeout option. "+
+		"Loading of packages wasn't finished", e.exitMessage)
+}
+
+func TestGetMetadataNotInRepo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci-lint-metadata")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	e := &Executor{cfg: config.NewDefault(), log: logutils.NewStderrLog(""), version: "1.2.3"}
+	m := e.getMetadata(dir)
+	assert.Nil(t, m.Git)
+	assert.Equal(t, "1.2.3", m.GolangciVersion)
+	assert.Equal(t, runtime.Version(), m.GoVersion)
+}
+
+func TestValidateSnapshotUpdate(t *testing.T) {
+	valid := []config.Issues{
+		{},
+		{OnlyNew: true, Snapshot: "snapshot.json"},
+		{OnlyNew: true, UpdateSnapshot: true, AllowRatchetLoosen: true},
+	}
+	for i := range valid {
+		assert.NoError(t, validateSnapshotUpdate(&valid[i]))
+	}
+
+	invalid := []struct {
+		ic      config.Issues
+		errText string
+	}{
+		{config.Issues{AllowRatchetLoosen: true}, "requires --update-snapshot"},
+		{config.Issues{UpdateSnapshot: true}, "requires --only-new"},
+		{config.Issues{OnlyNew: true, UpdateSnapshot: true, WriteBaseline: "baseline.json"}, "--write-baseline"},
+		{config.Issues{OnlyNew: true, UpdateSnapshot: true, DiffFromRevision: "HEAD~"}, "--new-from-rev"},
+	}
+	for i := range invalid {
+		err := validateSnapshotUpdate(&invalid[i].ic)
+		if assert.Error(t, err, invalid[i].errText) {
+			assert.Contains(t, err.Error(), invalid[i].errText)
+		}
+	}
+}
+
+func TestPrintToDestination(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci-lint-output")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	e := &Executor{cfg: config.NewDefault(), log: logutils.NewStderrLog("")}
+	issues := []result.Issue{{FromLinter: "golint", Text: "exported func F should have comment",
+		Pos: token.Position{Filename: "a.go", Line: 1, Column: 1}}}
+
+	path := filepath.Join(dir, "issues.txt")
+	f := config.OutputFormat{Format: config.OutFormatTab, Path: path}
+	assert.NoError(t, e.printToDestination(context.Background(), f, issues))
+	out, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), "a.go:1:1")
+	assert.Contains(t, string(out), "exported func F should have comment")
+
+	f.Path = filepath.Join(dir, "no", "dir")
+	assert.Error(t, e.printToDestination(context.Background(), f, issues))
+}
+
+func TestCreatePrinterWithoutOptions(t *testing.T) {
+	for _, format := range []string{config.OutFormatTap, config.OutFormatGitHubActions} {
+		p, err := createPrinterWithoutOptions(format, ioutil.Discard)
+		assert.NoError(t, err, format)
+		assert.NotNil(t, p, format)
+	}
+
+	_, err := createPrinterWithoutOptions("xml", ioutil.Discard)
+	assert.EqualError(t, err, "unknown output format xml")
+}
+
+func TestPrintedMetadata(t *testing.T) {
+	e := &Executor{cfg: config.NewDefault(), log: logutils.NewStderrLog(""), version: "1.2.3"}
+	assert.Nil(t, e.printedMetadata())
+
+	e.cfg.Output.PrintMetadata = true
+	m := e.printedMetadata()
+	if assert.NotNil(t, m) {
+		assert.Equal(t, "1.2.3", m.GolangciVersion)
+	}
+}
@@ -0,0 +1,42 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"runtime"
+)
+
+const (
+	goosWindows = "windows"
+	goosDarwin  = "darwin"
+)
+
+// fallbackUserCacheDir returns the user cache dir like os.UserCacheDir does:
+// it's used when os.UserCacheDir isn't available (Go < 1.11).
+func fallbackUserCacheDir(getenv func(string) string) (string, error) {
+	var dir string
+	switch runtime.GOOS {
+	case goosWindows:
+		dir = getenv("LocalAppData")
+		if dir == "" {
+			return "", errors.New("%LocalAppData% is not defined")
+		}
+	case goosDarwin:
+		dir = getenv("HOME")
+		if dir == "" {
+			return "", errors.New("$HOME is not defined")
+		}
+		dir = filepath.Join(dir, "Library", "Caches")
+	default:
+		dir = getenv("XDG_CACHE_HOME")
+		if dir == "" {
+			dir = getenv("HOME")
+			if dir == "" {
+				return "", errors.New("neither $XDG_CACHE_HOME nor $HOME are defined")
+			}
+			dir = filepath.Join(dir, ".cache")
+		}
+	}
+
+	return dir, nil
+}
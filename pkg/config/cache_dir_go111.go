@@ -0,0 +1,10 @@
+//go:build go1.11
+// +build go1.11
+
+package config
+
+import "os"
+
+func userCacheDir() (string, error) {
+	return os.UserCacheDir()
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFallbackUserCacheDir(t *testing.T) {
+	if runtime.GOOS == goosWindows || runtime.GOOS == goosDarwin {
+		t.Skip("test of $XDG_CACHE_HOME and $HOME")
+	}
+
+	env := map[string]string{}
+	getenv := func(k string) string {
+		return env[k]
+	}
+
+	_, err := fallbackUserCacheDir(getenv)
+	assert.Error(t, err)
+
+	env["HOME"] = filepath.FromSlash("/home/u")
+	dir, err := fallbackUserCacheDir(getenv)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.FromSlash("/home/u/.cache"), dir)
+
+	env["XDG_CACHE_HOME"] = filepath.FromSlash("/cache")
+	dir, err = fallbackUserCacheDir(getenv)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.FromSlash("/cache"), dir)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -14,79 +15,166 @@ const (
 	OutFormatJSON              = "json"
 	OutFormatLineNumber        = "line-number"
 	OutFormatColoredLineNumber = "colored-line-number"
+	OutFormatTextGrouped       = "text-grouped"
 	OutFormatTab               = "tab"
 	OutFormatCheckstyle        = "checkstyle"
+	OutFormatTap               = "tap"
+	OutFormatSARIF             = "sarif"
+	OutFormatGitHubActions     = "github-actions"
+	OutFormatCodeClimate       = "code-climate"
+	OutFormatJUnitXML          = "junit-xml"
 )
 
 var OutFormats = []string{
 	OutFormatColoredLineNumber,
 	OutFormatLineNumber,
+	OutFormatTextGrouped,
 	OutFormatJSON,
 	OutFormatTab,
 	OutFormatCheckstyle,
+	OutFormatTap,
+	OutFormatSARIF,
+	OutFormatGitHubActions,
+	OutFormatCodeClimate,
+	OutFormatJUnitXML,
 }
 
+// Columns of tab output format by output.tab-columns option
+const (
+	TabColumnPath     = "path"
+	TabColumnLine     = "line"
+	TabColumnColumn   = "col"
+	TabColumnLinter   = "linter"
+	TabColumnSeverity = "severity"
+	TabColumnMessage  = "message"
+)
+
+var TabColumns = []string{
+	TabColumnPath,
+	TabColumnLine,
+	TabColumnColumn,
+	TabColumnLinter,
+	TabColumnSeverity,
+	TabColumnMessage,
+}
+
+// Modes of paths of issues by output.path-mode option
+const (
+	PathModeRelative = "relative"
+	PathModeAbsolute = "absolute"
+)
+
+// Modes of colors of output by output.color option
+const (
+	ColorAuto   = "auto" // colors are used only if stdout is a terminal and NO_COLOR isn't set
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+// Destinations of output formats in output.format option: any other destination is a file path
+const (
+	OutPathStdout = "stdout"
+	OutPathStderr = "stderr"
+)
+
+// OutputFormat is a format of output with its destination
+type OutputFormat struct {
+	Format string
+	Path   string
+}
+
+// Keys of deduplication of issues by issues.uniq-by option
+const (
+	UniqByLine = "line"             // one issue per line
+	UniqByText = "line-column-text" // one issue per position and text
+)
+
+// Modes of detection of generated files by issues.autogen-mode option
+const (
+	AutogenModeLax    = "lax"    // header comments contain any of markers
+	AutogenModeStrict = "strict" // header comment line matches https://golang.org/s/generatedcode
+)
+
+// ModulesDownloadModes are values of run.modules-download-mode option: they are passed to go list as -mod flag
+var ModulesDownloadModes = []string{"mod", "readonly", "vendor"}
+
+// ExcludePattern excludes issues of any of Linters with texts matching case-insensitive
+// regexp Pattern: empty Linters match issues of all linters. Default excludes have
+// stable IDs to apply them by issues.include option when default excludes are disabled.
 type ExcludePattern struct {
+	ID      string
 	Pattern string
-	Linter  string
+	Linters []string
 	Why     string
 }
 
 var DefaultExcludePatterns = []ExcludePattern{
 	{
+		ID: "EXC0001",
 		Pattern: "Error return value of .((os\\.)?std(out|err)\\..*|.*Close" +
 			"|.*Flush|os\\.Remove(All)?|.*printf?|os\\.(Un)?Setenv). is not checked",
-		Linter: "errcheck",
-		Why:    "Almost all programs ignore errors on these functions and in most cases it's ok",
+		Linters: []string{"errcheck"},
+		Why:     "Almost all programs ignore errors on these functions and in most cases it's ok",
 	},
 	{
+		ID: "EXC0002",
 		Pattern: "(comment on exported (method|function|type|const)|" +
 			"should have( a package)? comment|comment should be of the form)",
-		Linter: "golint",
-		Why:    "Annoying issue about not having a comment. The rare codebase has such comments",
+		Linters: []string{"golint"},
+		Why:     "Annoying issue about not having a comment. The rare codebase has such comments",
 	},
 	{
+		ID:      "EXC0003",
 		Pattern: "func name will be used as test\\.Test.* by other packages, and that stutters; consider calling this",
-		Linter:  "golint",
+		Linters: []string{"golint"},
 		Why:     "False positive when tests are defined in package 'test'",
 	},
 	{
+		ID:      "EXC0004",
 		Pattern: "(possible misuse of unsafe.Pointer|should have signature)",
-		Linter:  "govet",
+		Linters: []string{"govet"},
 		Why:     "Common false positives",
 	},
 	{
+		ID:      "EXC0005",
 		Pattern: "ineffective break statement. Did you mean to break out of the outer loop",
-		Linter:  "megacheck",
+		Linters: []string{"megacheck", "staticcheck"},
 		Why:     "Developers tend to write in C-style with an explicit 'break' in a 'switch', so it's ok to ignore",
 	},
 	{
+		ID:      "EXC0006",
 		Pattern: "Use of unsafe calls should be audited",
-		Linter:  "gosec",
+		Linters: []string{"gosec"},
 		Why:     "Too many false-positives on 'unsafe' usage",
 	},
 	{
+		ID:      "EXC0007",
 		Pattern: "Subprocess launch(ed with variable|ing should be audited)",
-		Linter:  "gosec",
+		Linters: []string{"gosec"},
 		Why:     "Too many false-positives for parametrized shell calls",
 	},
 	{
+		ID:      "EXC0008",
 		Pattern: "G104",
-		Linter:  "gosec",
+		Linters: []string{"gosec"},
 		Why:     "Duplicated errcheck checks",
 	},
 	{
+		ID:      "EXC0009",
 		Pattern: "(Expect directory permissions to be 0750 or less|Expect file permissions to be 0600 or less)",
-		Linter:  "gosec",
+		Linters: []string{"gosec"},
 		Why:     "Too many issues in popular repos",
 	},
 	{
+		ID:      "EXC0010",
 		Pattern: "Potential file inclusion via variable",
-		Linter:  "gosec",
+		Linters: []string{"gosec"},
 		Why:     "False positive is triggered by 'src, err := ioutil.ReadFile(filename)'",
 	},
 }
 
+// GetDefaultExcludePatternsStrings returns patterns of all default excludes without
+// their linters: it's used to pass them to tools without per-linter excludes.
 func GetDefaultExcludePatternsStrings() []string {
 	var ret []string
 	for _, p := range DefaultExcludePatterns {
@@ -103,27 +191,53 @@ type Run struct {
 	MemProfilePath      string
 	Concurrency         int
 	PrintResourcesUsage bool `mapstructure:"print-resources-usage"`
+	PrintProcessors     bool `mapstructure:"print-processors"`
+	DryRun              bool `mapstructure:"dry-run"`
 
 	Config   string
 	NoConfig bool
 
-	Args []string
+	Args              []string
+	PackagesFromStdin bool              `mapstructure:"packages-from-stdin"`
+	FilesFromStdin    bool              `mapstructure:"files-from-stdin"`
+	OnlyFiles         []string          `mapstructure:"-"` // files read from stdin: issues only in them are reported
+	Overlay           map[string][]byte `mapstructure:"-"` // unsaved contents of files by absolute paths, e.g. from lsp
+	AtRevision        string            `mapstructure:"at-rev"`
 
-	BuildTags []string `mapstructure:"build-tags"`
+	BuildTags           []string `mapstructure:"build-tags"`
+	ModulesDownloadMode string   `mapstructure:"modules-download-mode"`
+	GoVersion           string   `mapstructure:"go"`
 
 	ExitCodeIfIssuesFound int  `mapstructure:"issues-exit-code"`
+	FailOnLinterErrors    bool `mapstructure:"fail-on-linter-errors"`
+	StopOnTypecheckErrors bool `mapstructure:"stop-on-typecheck-errors"`
 	AnalyzeTests          bool `mapstructure:"tests"`
-	Deadline              time.Duration
+	Timeout               time.Duration
+	Deadline              time.Duration // deprecated: it's used as timeout if timeout isn't set
+	DeadlinePerPackage    time.Duration `mapstructure:"deadline-per-package"`
+	PackageTimeout        time.Duration `mapstructure:"package-timeout"`
+	MaxMemory             int           `mapstructure:"max-memory"`
+	NoCache               bool          `mapstructure:"no-cache"`
+	CacheAutogen          bool          `mapstructure:"cache-autogen"`
+	CacheIssues           bool          `mapstructure:"cache-issues"`
+	CacheDir              string        `mapstructure:"cache-dir"`
 	PrintVersion          bool
 
-	SkipFiles []string `mapstructure:"skip-files"`
-	SkipDirs  []string `mapstructure:"skip-dirs"`
+	SkipFiles          []string `mapstructure:"skip-files"`
+	SkipDirs           []string `mapstructure:"skip-dirs"`
+	UseDefaultSkipDirs bool     `mapstructure:"skip-dirs-use-default"`
+	RespectGitignore   bool     `mapstructure:"respect-gitignore"`
+
+	OnlyExplicitLinters bool `mapstructure:"only-explicit-linters"`
+
+	LintLock   string `mapstructure:"lint-lock"`
+	UpdateLock bool   `mapstructure:"update-lock"`
+
+	ExitReasonPath string `mapstructure:"report-exit-reason"`
 }
 
 type LintersSettings struct {
-	Govet struct {
-		CheckShadowing bool `mapstructure:"check-shadowing"`
-	}
+	Govet  GovetSettings
 	Golint struct {
 		MinConfidence float64 `mapstructure:"min-confidence"`
 	}
@@ -164,12 +278,104 @@ type LintersSettings struct {
 		CheckExported bool `mapstructure:"check-exported"`
 	}
 
-	Lll      LllSettings
-	Unparam  UnparamSettings
-	Nakedret NakedretSettings
-	Prealloc PreallocSettings
-	Errcheck ErrcheckSettings
-	Gocritic GocriticSettings
+	Lll        LllSettings
+	Unparam    UnparamSettings
+	Nakedret   NakedretSettings
+	Prealloc   PreallocSettings
+	Errcheck   ErrcheckSettings
+	Gocritic   GocriticSettings
+	Nolintlint NolintlintSettings
+	Custom     map[string]CustomLinterSettings
+
+	// Tests is filled from linters-settings.<name>.tests options by FileReader:
+	// it overrides run.tests for the linter
+	Tests map[string]bool `mapstructure:"-"`
+
+	// Timeouts is filled from linters-settings.<name>.timeout options by FileReader:
+	// the linter is canceled after its timeout, run.timeout is still applied
+	Timeouts map[string]time.Duration `mapstructure:"-"`
+}
+
+type GovetSettings struct {
+	CheckShadowing bool `mapstructure:"check-shadowing"`
+	Enable         []string
+	Disable        []string
+}
+
+// GovetAnalyzers are names of analyzers of govet: shadow is experimental,
+// it's enabled only explicitly or by check-shadowing option
+var GovetAnalyzers = []string{
+	"asmdecl",
+	"assign",
+	"atomic",
+	"bool",
+	"buildtags",
+	"cgocall",
+	"composites",
+	"copylocks",
+	"httpresponse",
+	"lostcancel",
+	"methods",
+	"nilfunc",
+	"printf",
+	"rangeloops",
+	"shadow",
+	"shift",
+	"structtags",
+	"tests",
+	"unreachable",
+	"unsafeptr",
+	"unusedresult",
+}
+
+func (s GovetSettings) Validate() error {
+	known := map[string]bool{}
+	for _, name := range GovetAnalyzers {
+		known[name] = true
+	}
+
+	for _, name := range append(append([]string{}, s.Enable...), s.Disable...) {
+		if !known[name] {
+			return fmt.Errorf("no such govet analyzer %q: only next analyzers exist: (%s)",
+				name, strings.Join(GovetAnalyzers, "|"))
+		}
+	}
+
+	for _, name := range s.Enable {
+		if stringsContain(s.Disable, name) {
+			return fmt.Errorf("govet analyzer %q can't be disabled and enabled at one moment", name)
+		}
+	}
+
+	return nil
+}
+
+// IsAnalyzerEnabled reports whether the govet analyzer must be run: if enable list
+// is set, only analyzers from it are run, analyzers from disable list are never run
+func (s GovetSettings) IsAnalyzerEnabled(name string) bool {
+	if stringsContain(s.Disable, name) {
+		return false
+	}
+
+	if len(s.Enable) != 0 {
+		return stringsContain(s.Enable, name)
+	}
+
+	if name == "shadow" {
+		return s.CheckShadowing
+	}
+
+	return true
+}
+
+func stringsContain(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
 }
 
 type ErrcheckSettings struct {
@@ -193,6 +399,18 @@ type NakedretSettings struct {
 	MaxFuncLines int `mapstructure:"max-func-lines"`
 }
 
+// CustomLinterSettings describes a custom linter loaded from a Go plugin:
+// the plugin must export function New of type func() (linter.Linter, error)
+type CustomLinterSettings struct {
+	Path        string
+	Description string
+	OriginalURL string `mapstructure:"original-url"`
+}
+
+type NolintlintSettings struct {
+	MinExplanationLength int `mapstructure:"min-explanation-length"`
+}
+
 type PreallocSettings struct {
 	Simple     bool
 	RangeLoops bool `mapstructure:"range-loops"`
@@ -278,28 +496,44 @@ var defaultGocriticEnabledChecks = []string{
 	"defaultCaseOrder",
 }
 
-var defaultLintersSettings = LintersSettings{
-	Lll: LllSettings{
-		LineLength: 120,
-		TabWidth:   1,
-	},
-	Unparam: UnparamSettings{
-		Algo: "cha",
-	},
-	Nakedret: NakedretSettings{
-		MaxFuncLines: 30,
-	},
-	Prealloc: PreallocSettings{
-		Simple:     true,
-		RangeLoops: true,
-		ForLoops:   false,
-	},
-	Errcheck: ErrcheckSettings{
-		Ignore: IgnoreFlag{},
-	},
-	Gocritic: GocriticSettings{
-		SettingsPerCheck: map[string]GocriticCheckSettings{},
-	},
+// defaultLintersSettings returns default settings of linters: every call
+// returns new maps to not share them between configs
+func defaultLintersSettings() LintersSettings {
+	settings := LintersSettings{
+		Lll: LllSettings{
+			LineLength: 120,
+			TabWidth:   1,
+		},
+		Unparam: UnparamSettings{
+			Algo: "cha",
+		},
+		Nakedret: NakedretSettings{
+			MaxFuncLines: 30,
+		},
+		Prealloc: PreallocSettings{
+			Simple:     true,
+			RangeLoops: true,
+			ForLoops:   false,
+		},
+		Errcheck: ErrcheckSettings{
+			Ignore: IgnoreFlag{},
+		},
+		Gocritic: GocriticSettings{
+			SettingsPerCheck: map[string]GocriticCheckSettings{},
+		},
+		Nolintlint: NolintlintSettings{
+			MinExplanationLength: 1,
+		},
+	}
+
+	settings.Golint.MinConfidence = 0.8
+	settings.Gofmt.Simplify = true
+	settings.Gocyclo.MinComplexity = 30
+	settings.Dupl.Threshold = 150
+	settings.Goconst.MinStringLen = 3
+	settings.Goconst.MinOccurrencesCount = 3
+
+	return settings
 }
 
 type Linters struct {
@@ -310,41 +544,246 @@ type Linters struct {
 	Fast       bool
 
 	Presets []string
+
+	FailIfNoLinters bool `mapstructure:"fail-if-no-linters"`
 }
 
 type Issues struct {
-	ExcludePatterns    []string `mapstructure:"exclude"`
-	UseDefaultExcludes bool     `mapstructure:"exclude-use-default"`
+	ExcludePatterns        []string      `mapstructure:"exclude"`
+	UseDefaultExcludes     bool          `mapstructure:"exclude-use-default"`
+	IncludeDefaultExcludes []string      `mapstructure:"include"`
+	GeneratedDirs          []string      `mapstructure:"generated-dirs"`
+	GeneratedFiles         []string      `mapstructure:"generated-files"`
+	NeverGenerated         []string      `mapstructure:"never-generated"`
+	GeneratedTag           string        `mapstructure:"generated-tag"`
+	AutogenMarkers         []string      `mapstructure:"autogen-markers"`
+	AutogenMode            string        `mapstructure:"autogen-mode"`
+	AutogenScanFullFile    bool          `mapstructure:"autogen-scan-full-file"`
+	ExcludeStructTags      []string      `mapstructure:"exclude-struct-tags"`
+	ExcludeRules           []ExcludeRule `mapstructure:"exclude-rules"`
+	IncludeRules           []IncludeRule `mapstructure:"include-rules"`
+
+	// aliases of output.strip-prefix and output.path-prefix: they're used if those aren't set
+	PathPrefixStrip string `mapstructure:"path-prefix-strip"`
+	PathPrefixAdd   string `mapstructure:"path-prefix-add"`
+
+	ExcludeAt             []string `mapstructure:"exclude-at"`
+	ReportUnusedExcludeAt bool     `mapstructure:"report-unused-exclude-at"`
+
+	MaxIssuesPerLinter int    `mapstructure:"max-issues-per-linter"`
+	MaxSameIssues      int    `mapstructure:"max-same-issues"`
+	UniqBy             string `mapstructure:"uniq-by"`
+
+	DiffFromRevision  string   `mapstructure:"new-from-rev"`
+	DiffPatchFilePath string   `mapstructure:"new-from-patch"`
+	Diff              bool     `mapstructure:"new"`
+	FailOnNewOnly     bool     `mapstructure:"fail-on-new-only"`
+	FailOnPresets     []string `mapstructure:"fail-on-presets"`
+	ErrorLinters      []string `mapstructure:"error-linters"`
+	WarningLinters    []string `mapstructure:"warning-linters"`
+
+	Baseline      string `mapstructure:"baseline"`
+	WriteBaseline string `mapstructure:"write-baseline"`
+
+	OnlyNew            bool   `mapstructure:"only-new"`
+	Snapshot           string `mapstructure:"snapshot"`
+	UpdateSnapshot     bool   `mapstructure:"update-snapshot"`
+	AllowRatchetLoosen bool   `mapstructure:"allow-ratchet-loosen"`
+
+	NeedFix bool `mapstructure:"fix"`
+}
+
+type ExcludeRule struct {
+	Linters []string
+	Path    string // regexp of issue file path
+	Text    string // regexp of issue text
+	Source  string // regexp of issue source line
+}
+
+// IncludeRule enables linters only for files with path matching regexp Path
+type IncludeRule struct {
+	Linters []string
+	Path    string // regexp of issue file path
+}
 
-	MaxIssuesPerLinter int `mapstructure:"max-issues-per-linter"`
-	MaxSameIssues      int `mapstructure:"max-same-issues"`
+type SeverityRule struct {
+	Severity string
+	Linters  []string
+	Path     string // regexp of issue file path
+}
 
-	DiffFromRevision  string `mapstructure:"new-from-rev"`
-	DiffPatchFilePath string `mapstructure:"new-from-patch"`
-	Diff              bool   `mapstructure:"new"`
+type Severity struct {
+	Default string `mapstructure:"default-severity"`
+	Rules   []SeverityRule
 }
 
-type Config struct { //nolint:maligned
+type Config struct { //nolint:maligned // fields follow sections of the config file
 	Run Run
 
 	Output struct {
 		Format              string
 		PrintIssuedLine     bool `mapstructure:"print-issued-lines"`
-		PrintLinterName     bool `mapstructure:"print-linter-name"`
-		PrintWelcomeMessage bool `mapstructure:"print-welcome"`
+		SourceContextLines  int  `mapstructure:"source-context-lines"`
+		ShowCaret           bool `mapstructure:"show-caret"`
+		HideZeroColumn      bool `mapstructure:"hide-zero-column"`
+		InferColumn         bool `mapstructure:"infer-column"`
+		PrintSummary        bool `mapstructure:"print-summary"`
+		PrintLinterTime     bool `mapstructure:"print-linter-time"`
+		Color               string
+		StripPrefix         string   `mapstructure:"strip-prefix"`
+		PathPrefix          string   `mapstructure:"path-prefix"`
+		ShowSuppressed      bool     `mapstructure:"show-suppressed"`
+		OutFile             string   `mapstructure:"out-file"`
+		PrintMetadata       bool     `mapstructure:"print-metadata"`
+		PrintLinterName     bool     `mapstructure:"print-linter-name"`
+		TabColumns          []string `mapstructure:"tab-columns"`
+		PrintWelcomeMessage bool     `mapstructure:"print-welcome"`
+		PathMode            string   `mapstructure:"path-mode"`
+		SortResults         bool     `mapstructure:"sort-results"`
+
+		Categories map[string]string // linter name to category
 	}
 
 	LintersSettings LintersSettings `mapstructure:"linters-settings"`
 	Linters         Linters
 	Issues          Issues
+	Severity        Severity
 
 	InternalTest bool // Option is used only for testing golangci-lint code, don't use it
 }
 
+// ShouldLoadTests reports whether test files must be loaded: they are needed
+// if run.tests is enabled or some linter enables tests by its settings.
+func (c Config) ShouldLoadTests() bool {
+	if c.Run.AnalyzeTests {
+		return true
+	}
+
+	for _, analyzeTests := range c.LintersSettings.Tests {
+		if analyzeTests {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetExcludePatterns returns patterns of issues.exclude option matching issues
+// of all linters and default exclude patterns: all of them if they aren't disabled,
+// otherwise only ones with ids from issues.include option
+func (c Config) GetExcludePatterns() ([]ExcludePattern, error) {
+	var patterns []ExcludePattern
+	for _, p := range c.Issues.ExcludePatterns {
+		patterns = append(patterns, ExcludePattern{Pattern: p})
+	}
+
+	included := map[string]bool{}
+	for _, id := range c.Issues.IncludeDefaultExcludes {
+		included[strings.ToUpper(id)] = true
+	}
+	for _, ep := range DefaultExcludePatterns {
+		if c.Issues.UseDefaultExcludes || included[ep.ID] {
+			patterns = append(patterns, ep)
+		}
+		delete(included, ep.ID)
+	}
+
+	for _, id := range c.Issues.IncludeDefaultExcludes {
+		if included[strings.ToUpper(id)] {
+			return nil, fmt.Errorf("unknown default exclude id %q in issues.include: "+
+				"ids are listed in help of exclude-use-default option", id)
+		}
+	}
+
+	return patterns, nil
+}
+
+// GetCacheDir returns dir of on-disk caches: run.cache-dir option
+// or golangci-lint dir in user cache dir by default.
+func (c Config) GetCacheDir() (string, error) {
+	if c.Run.CacheDir != "" {
+		return c.Run.CacheDir, nil
+	}
+
+	userCacheDir, err := userCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("can't get user cache dir: %s", err)
+	}
+
+	return filepath.Join(userCacheDir, "golangci-lint"), nil
+}
+
+// GetOutputFormats parses output.format option: comma-separated formats
+// with optional destinations like "colored-line-number,json:report.json".
+// Columns of output.tab-columns option are validated too.
+// Destination is stdout by default. If output.out-file is set, output
+// in the only format is written to it and issues are printed to stdout
+// in colored-line-number format.
+func (c Config) GetOutputFormats() ([]OutputFormat, error) {
+	var formats []OutputFormat
+	for _, item := range strings.Split(c.Output.Format, ",") {
+		f := OutputFormat{Format: item, Path: OutPathStdout}
+		if i := strings.Index(item, ":"); i != -1 {
+			f.Format, f.Path = item[:i], item[i+1:]
+		}
+
+		if !stringsContain(OutFormats, f.Format) {
+			return nil, fmt.Errorf("unknown output format %q: allowed are %s", f.Format, strings.Join(OutFormats, ", "))
+		}
+		if f.Path == "" {
+			return nil, fmt.Errorf("empty destination of output format %s", f.Format)
+		}
+		formats = append(formats, f)
+	}
+
+	for _, col := range c.Output.TabColumns {
+		if !stringsContain(TabColumns, col) {
+			return nil, fmt.Errorf("unknown tab column %q: allowed are %s", col, strings.Join(TabColumns, ", "))
+		}
+	}
+
+	if c.Output.OutFile != "" {
+		if len(formats) != 1 || strings.Contains(c.Output.Format, ":") {
+			return nil, errors.New("output file can't be set for multiple output formats or formats with destinations")
+		}
+		formats = []OutputFormat{
+			{Format: formats[0].Format, Path: c.Output.OutFile},
+			{Format: OutFormatColoredLineNumber, Path: OutPathStdout},
+		}
+	}
+
+	return formats, nil
+}
+
+// NewDefault returns the config with default values of options of the run command:
+// it's the config of the run command without config files and flags.
 func NewDefault() *Config {
-	return &Config{
-		LintersSettings: defaultLintersSettings,
+	cfg := &Config{
+		LintersSettings: defaultLintersSettings(),
 	}
+
+	cfg.Run.ExitCodeIfIssuesFound = 1
+	cfg.Run.AnalyzeTests = true
+	cfg.Run.Timeout = time.Minute
+	cfg.Run.UseDefaultSkipDirs = true
+
+	cfg.Output.Format = OutFormatColoredLineNumber
+	cfg.Output.PrintIssuedLine = true
+	cfg.Output.ShowCaret = true
+	cfg.Output.Color = ColorAuto
+	cfg.Output.PrintLinterName = true
+	cfg.Output.PathMode = PathModeRelative
+	cfg.Output.SortResults = true
+
+	cfg.Issues.UseDefaultExcludes = true
+	cfg.Issues.GeneratedTag = "generated"
+	cfg.Issues.AutogenMode = AutogenModeLax
+	cfg.Issues.MaxIssuesPerLinter = 50
+	cfg.Issues.MaxSameIssues = 3
+	cfg.Issues.UniqBy = UniqByLine
+	cfg.Issues.Snapshot = ".golangci.issues"
+
+	return cfg
 }
 
 // IgnoreFlags was taken from errcheck in order to keep the API identical.
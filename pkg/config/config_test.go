@@ -0,0 +1,180 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func TestGovetSettings(t *testing.T) {
+	s := GovetSettings{}
+	assert.NoError(t, s.Validate())
+	assert.True(t, s.IsAnalyzerEnabled("printf"))
+	assert.False(t, s.IsAnalyzerEnabled("shadow"))
+
+	s = GovetSettings{CheckShadowing: true, Disable: []string{"printf"}}
+	assert.NoError(t, s.Validate())
+	assert.False(t, s.IsAnalyzerEnabled("printf"))
+	assert.True(t, s.IsAnalyzerEnabled("shadow"))
+	assert.True(t, s.IsAnalyzerEnabled("copylocks"))
+
+	s = GovetSettings{Enable: []string{"printf", "shadow"}}
+	assert.NoError(t, s.Validate())
+	assert.True(t, s.IsAnalyzerEnabled("printf"))
+	assert.True(t, s.IsAnalyzerEnabled("shadow"))
+	assert.False(t, s.IsAnalyzerEnabled("copylocks"))
+
+	assert.Error(t, GovetSettings{Enable: []string{"nilness"}}.Validate())
+	assert.Error(t, GovetSettings{Enable: []string{"printf"}, Disable: []string{"printf"}}.Validate())
+}
+
+func TestGetOutputFormats(t *testing.T) {
+	cases := []struct {
+		format, outFile string
+		tabColumns      []string
+		formats         []OutputFormat
+		err             bool
+	}{
+		{
+			format:  "tab",
+			formats: []OutputFormat{{Format: "tab", Path: "stdout"}},
+		},
+		{
+			format: "colored-line-number,json:report.json,checkstyle:stderr",
+			formats: []OutputFormat{
+				{Format: "colored-line-number", Path: "stdout"},
+				{Format: "json", Path: "report.json"},
+				{Format: "checkstyle", Path: "stderr"},
+			},
+		},
+		{
+			format:  `json:C:\report.json`,
+			formats: []OutputFormat{{Format: "json", Path: `C:\report.json`}},
+		},
+		{
+			format:  "checkstyle",
+			outFile: "report.xml",
+			formats: []OutputFormat{
+				{Format: "checkstyle", Path: "report.xml"},
+				{Format: "colored-line-number", Path: "stdout"},
+			},
+		},
+		{format: "text:stdout", err: true},
+		{format: "json:", err: true},
+		{format: "json,tab", outFile: "report.json", err: true},
+		{
+			format:     "tab",
+			tabColumns: []string{"linter", "path", "message"},
+			formats:    []OutputFormat{{Format: "tab", Path: "stdout"}},
+		},
+		{format: "tab", tabColumns: []string{"path", "text"}, err: true},
+	}
+
+	for _, c := range cases {
+		cfg := Config{}
+		cfg.Output.Format = c.format
+		cfg.Output.OutFile = c.outFile
+		cfg.Output.TabColumns = c.tabColumns
+		formats, err := cfg.GetOutputFormats()
+		if c.err {
+			assert.Error(t, err, c.format)
+			continue
+		}
+		assert.NoError(t, err, c.format)
+		assert.Equal(t, c.formats, formats, c.format)
+	}
+}
+
+func TestGetExcludePatterns(t *testing.T) {
+	var c Config
+	c.Issues.ExcludePatterns = []string{"abc"}
+	c.Issues.IncludeDefaultExcludes = []string{"EXC0001", "exc0003"}
+
+	patterns, err := c.GetExcludePatterns()
+	assert.NoError(t, err)
+	assert.Equal(t, []ExcludePattern{{Pattern: "abc"}, DefaultExcludePatterns[0], DefaultExcludePatterns[2]}, patterns)
+
+	c.Issues.UseDefaultExcludes = true
+	patterns, err = c.GetExcludePatterns()
+	assert.NoError(t, err)
+	assert.Len(t, patterns, len(DefaultExcludePatterns)+1)
+
+	c.Issues.IncludeDefaultExcludes = []string{"EXC9999"}
+	_, err = c.GetExcludePatterns()
+	assert.EqualError(t, err, `unknown default exclude id "EXC9999" in issues.include: `+
+		"ids are listed in help of exclude-use-default option")
+}
+
+func TestDefaultExcludeIDsAreUnique(t *testing.T) {
+	ids := map[string]bool{}
+	for _, ep := range DefaultExcludePatterns {
+		assert.False(t, ids[ep.ID], "duplicate id %s", ep.ID)
+		assert.NotEmpty(t, ep.Linters, "no linters of default exclude %s", ep.ID)
+		ids[ep.ID] = true
+	}
+}
+
+func TestOverrideLintersByCommandLine(t *testing.T) {
+	var cfg, commandLineCfg Config
+	cfg.Linters = Linters{
+		Enable:     []string{"golint", "govet"},
+		Disable:    []string{"errcheck"},
+		DisableAll: true,
+		Presets:    []string{"bugs"},
+	}
+	commandLineCfg.Linters = Linters{
+		Enable:    []string{"ErrCheck"},
+		Disable:   []string{"golint"},
+		EnableAll: true,
+	}
+
+	r := NewFileReader(&cfg, &commandLineCfg, nil, logutils.NewStderrLog(""))
+	r.overrideLintersByCommandLine()
+
+	assert.Equal(t, Linters{Enable: []string{"govet"}}, cfg.Linters)
+}
+
+func TestApplyOptionAliases(t *testing.T) {
+	var cfg Config
+	cfg.Run.Deadline = time.Minute
+	cfg.Issues.PathPrefixStrip = "/app"
+	cfg.Issues.PathPrefixAdd = "services/api"
+
+	r := NewFileReader(&cfg, nil, nil, logutils.NewStderrLog(""))
+	r.applyOptionAliases()
+
+	assert.Equal(t, time.Minute, cfg.Run.Timeout)
+	assert.Zero(t, cfg.Run.Deadline)
+	assert.Equal(t, "/app", cfg.Output.StripPrefix)
+	assert.Equal(t, "services/api", cfg.Output.PathPrefix)
+}
+
+func TestReadLinterTimeout(t *testing.T) {
+	var cfg Config
+	r := NewFileReader(&cfg, nil, map[string]string{"gas": "gosec", "gosec": "gosec"}, logutils.NewStderrLog(""))
+
+	assert.NoError(t, r.readLinterTimeout("gas", map[string]interface{}{"timeout": "10s"}))
+	assert.NoError(t, r.readLinterTimeout("example", map[string]interface{}{"timeout": "1m"}))
+	assert.NoError(t, r.readLinterTimeout("golint", map[string]interface{}{}))
+	assert.Equal(t, map[string]time.Duration{"gosec": 10 * time.Second, "example": time.Minute},
+		cfg.LintersSettings.Timeouts)
+
+	assert.Error(t, r.readLinterTimeout("gosec", map[string]interface{}{"timeout": "20s"}))
+	assert.Error(t, r.readLinterTimeout("golint", map[string]interface{}{"timeout": "-1s"}))
+}
+
+func TestReadLinterTests(t *testing.T) {
+	var cfg Config
+	r := NewFileReader(&cfg, nil, map[string]string{"gas": "gosec", "gosec": "gosec"}, logutils.NewStderrLog(""))
+
+	assert.NoError(t, r.readLinterTests("gas", map[string]interface{}{"tests": false}))
+	assert.NoError(t, r.readLinterTests("example", map[string]interface{}{"tests": true}))
+	assert.NoError(t, r.readLinterTests("golint", map[string]interface{}{}))
+	assert.Equal(t, map[string]bool{"gosec": false, "example": true}, cfg.LintersSettings.Tests)
+
+	assert.Error(t, r.readLinterTests("gosec", map[string]interface{}{"tests": true}))
+	assert.Error(t, r.readLinterTests("golint", map[string]interface{}{"tests": "no"}))
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"reflect"
+	"regexp"
+)
+
+var regexpPtrType = reflect.TypeOf(&regexp.Regexp{})
+
+// Copy returns a deep copy of the config: runs can modify their copies
+// without affecting other runs sharing the config.
+func (c *Config) Copy() *Config {
+	ret := reflect.New(reflect.TypeOf(*c))
+	copyValue(ret.Elem(), reflect.ValueOf(*c))
+	return ret.Interface().(*Config)
+}
+
+func copyValue(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() || src.Type() == regexpPtrType { // regexps are immutable
+			dst.Set(src)
+			return
+		}
+		dst.Set(reflect.New(src.Type().Elem()))
+		copyValue(dst.Elem(), src.Elem())
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := reflect.New(src.Elem().Type()).Elem()
+		copyValue(elem, src.Elem())
+		dst.Set(elem)
+	case reflect.Struct:
+		copyStruct(dst, src)
+	case reflect.Slice:
+		copySlice(dst, src)
+	case reflect.Map:
+		copyMap(dst, src)
+	default:
+		dst.Set(src)
+	}
+}
+
+func copyStruct(dst, src reflect.Value) {
+	dst.Set(src) // unexported fields are shallow copied: they're only caches
+	for i := 0; i < src.NumField(); i++ {
+		if src.Type().Field(i).PkgPath == "" {
+			copyValue(dst.Field(i), src.Field(i))
+		}
+	}
+}
+
+func copySlice(dst, src reflect.Value) {
+	if src.IsNil() {
+		return
+	}
+
+	dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Len()))
+	for i := 0; i < src.Len(); i++ {
+		copyValue(dst.Index(i), src.Index(i))
+	}
+}
+
+func copyMap(dst, src reflect.Value) {
+	if src.IsNil() {
+		return
+	}
+
+	dst.Set(reflect.MakeMap(src.Type()))
+	for _, k := range src.MapKeys() {
+		v := reflect.New(src.Type().Elem()).Elem()
+		copyValue(v, src.MapIndex(k))
+		dst.SetMapIndex(k, v)
+	}
+}
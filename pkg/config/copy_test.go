@@ -0,0 +1,37 @@
+package config
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func TestCopy(t *testing.T) {
+	cfg := NewDefault()
+	cfg.Run.Args = []string{"./..."}
+	cfg.Run.Overlay = map[string][]byte{"/src/a.go": []byte("package a")}
+	cfg.Issues.ExcludeRules = []ExcludeRule{{Linters: []string{"golint"}, Text: "comment"}}
+	cfg.LintersSettings.Errcheck.Ignore["fmt"] = regexp.MustCompile(".*")
+	cfg.LintersSettings.Gocritic.SettingsPerCheck["rangeValCopy"] = GocriticCheckSettings{"sizeThreshold": 32}
+	cfg.LintersSettings.Gocritic.EnabledChecks = []string{"rangeValCopy"}
+	cfg.LintersSettings.Gocritic.InferEnabledChecks(logutils.NewStderrLog(""))
+
+	c := cfg.Copy()
+	assert.Equal(t, cfg, c)
+	assert.True(t, c.LintersSettings.Gocritic.IsCheckEnabled("rangeValCopy"))
+
+	c.Run.Args[0] = "./pkg/..."
+	c.Run.Overlay["/src/a.go"][0] = 'P'
+	c.Issues.ExcludeRules[0].Linters[0] = "govet"
+	delete(c.LintersSettings.Errcheck.Ignore, "fmt")
+	c.LintersSettings.Gocritic.SettingsPerCheck["rangeValCopy"]["sizeThreshold"] = 64
+
+	assert.Equal(t, []string{"./..."}, cfg.Run.Args)
+	assert.Equal(t, "package a", string(cfg.Run.Overlay["/src/a.go"]))
+	assert.Equal(t, []string{"golint"}, cfg.Issues.ExcludeRules[0].Linters)
+	assert.Contains(t, cfg.LintersSettings.Errcheck.Ignore, "fmt")
+	assert.Equal(t, 32, cfg.LintersSettings.Gocritic.SettingsPerCheck["rangeValCopy"]["sizeThreshold"])
+}
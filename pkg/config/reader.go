@@ -6,10 +6,13 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 
 	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/goutil"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 
 	"github.com/mitchellh/go-homedir"
@@ -19,13 +22,17 @@ type FileReader struct {
 	log            logutils.Log
 	cfg            *Config
 	commandLineCfg *Config
+	linterNames    map[string]string
 }
 
-func NewFileReader(toCfg, commandLineCfg *Config, log logutils.Log) *FileReader {
+// NewFileReader creates a reader of the config file into toCfg: linterNames
+// are all names of supported linters mapped to their canonical names.
+func NewFileReader(toCfg, commandLineCfg *Config, linterNames map[string]string, log logutils.Log) *FileReader {
 	return &FileReader{
 		log:            log,
 		cfg:            toCfg,
 		commandLineCfg: commandLineCfg,
+		linterNames:    linterNames,
 	}
 }
 
@@ -44,6 +51,13 @@ func (r *FileReader) Read() error {
 	}
 
 	if configFile != "" {
+		// don't fall back to defaults: the config was requested explicitly
+		if _, err = os.Stat(configFile); err != nil {
+			if os.IsNotExist(err) {
+				return fmt.Errorf("config file %s from --config option doesn't exist", configFile)
+			}
+			return fmt.Errorf("can't access config file %s from --config option: %s", configFile, err)
+		}
 		viper.SetConfigFile(configFile)
 	} else {
 		r.setupConfigFileSearch()
@@ -72,8 +86,19 @@ func (r *FileReader) parseConfig() error {
 	}
 	r.log.Infof("Used config file %s", usedConfigFile)
 
-	if err := viper.Unmarshal(r.cfg); err != nil {
-		return fmt.Errorf("can't unmarshal config by viper: %s", err)
+	if err := r.readExtendedConfigs(); err != nil {
+		return err
+	}
+
+	if err := r.unmarshalConfig(); err != nil {
+		return err
+	}
+
+	r.applyOptionAliases()
+	r.overrideLintersByCommandLine()
+
+	if err := r.readLintersTests(); err != nil {
+		return fmt.Errorf("can't read linters settings: %s", err)
 	}
 
 	if err := r.validateConfig(); err != nil {
@@ -88,6 +113,288 @@ func (r *FileReader) parseConfig() error {
 	return nil
 }
 
+// unmarshalConfig sets the config by read settings: unknown keys are rejected
+func (r *FileReader) unmarshalConfig() error {
+	if err := validateKeys(viper.AllSettings(), r.linterNames); err != nil {
+		return fmt.Errorf("can't validate config: %s", err)
+	}
+
+	if err := viper.Unmarshal(r.cfg); err != nil {
+		return fmt.Errorf("can't unmarshal config by viper: %s", err)
+	}
+
+	return nil
+}
+
+// applyOptionAliases sets options by their deprecated names or aliases
+// if the options aren't set by the main names
+func (r *FileReader) applyOptionAliases() {
+	if r.cfg.Run.Deadline != 0 {
+		r.log.Infof("Option run.deadline is deprecated, use run.timeout instead")
+		if !viper.IsSet("run.timeout") {
+			r.cfg.Run.Timeout = r.cfg.Run.Deadline
+		}
+		r.cfg.Run.Deadline = 0 // don't apply it again with --deadline
+	}
+
+	if r.cfg.Issues.PathPrefixStrip != "" && !viper.IsSet("output.strip-prefix") {
+		r.cfg.Output.StripPrefix = r.cfg.Issues.PathPrefixStrip
+	}
+	if r.cfg.Issues.PathPrefixAdd != "" && !viper.IsSet("output.path-prefix") {
+		r.cfg.Output.PathPrefix = r.cfg.Issues.PathPrefixAdd
+	}
+}
+
+// readExtendedConfigs reads base configs from the "extends" option: a path or a list
+// of paths relative to the including config. Settings of base configs are set
+// as viper defaults, so maps are deep-merged and other values of the including
+// config override them.
+func (r *FileReader) readExtendedConfigs() error {
+	usedConfigFile, err := filepath.Abs(viper.ConfigFileUsed())
+	if err != nil {
+		return fmt.Errorf("can't get abs path of config file: %s", err)
+	}
+
+	baseSettings, err := readBaseConfigs(usedConfigFile, viper.Get("extends"), []string{usedConfigFile})
+	if err != nil {
+		return fmt.Errorf("can't read base configs of %s: %s", usedConfigFile, err)
+	}
+
+	setDefaults("", baseSettings)
+	return nil
+}
+
+func readBaseConfigs(configFile string, extends interface{}, stack []string) (map[string]interface{}, error) {
+	paths, err := extendsPaths(extends)
+	if err != nil {
+		return nil, err
+	}
+	if paths == nil {
+		return nil, nil
+	}
+
+	settings := map[string]interface{}{}
+	for _, basePath := range paths {
+		var path string
+		if path, err = baseConfigPath(configFile, basePath, stack); err != nil {
+			return nil, err
+		}
+
+		v := viper.New()
+		v.SetConfigFile(path)
+		if err = v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("can't read base config %s: %s", path, err)
+		}
+
+		var baseSettings map[string]interface{}
+		if baseSettings, err = readBaseConfigs(path, v.Get("extends"), append(stack, path)); err != nil {
+			return nil, err
+		}
+
+		mergeSettings(settings, baseSettings)
+		mergeSettings(settings, v.AllSettings())
+	}
+
+	delete(settings, "extends")
+	return settings, nil
+}
+
+// extendsPaths returns paths of the "extends" option: it's a path or a list of paths
+func extendsPaths(extends interface{}) ([]string, error) {
+	switch v := extends.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []interface{}:
+		paths := []string{}
+		for _, p := range v {
+			path, ok := p.(string)
+			if !ok {
+				return nil, fmt.Errorf("option extends must be a path or a list of paths, got %v", extends)
+			}
+			paths = append(paths, path)
+		}
+		return paths, nil
+	default:
+		return nil, fmt.Errorf("option extends must be a path or a list of paths, got %v", extends)
+	}
+}
+
+// baseConfigPath returns the absolute path of the base config: relative paths are relative
+// to the including config. Configs in the stack of including configs can't be extended.
+func baseConfigPath(configFile, basePath string, stack []string) (string, error) {
+	path, err := homedir.Expand(basePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand base config path %q: %s", basePath, err)
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(configFile), path)
+	}
+
+	for _, p := range stack {
+		if p == path {
+			return "", fmt.Errorf("cycle of extended configs: %s", strings.Join(append(stack, path), " -> "))
+		}
+	}
+
+	return path, nil
+}
+
+// mergeSettings deeply merges maps of src into dst: other values of src replace values of dst
+func mergeSettings(dst, src map[string]interface{}) {
+	for k, srcV := range src {
+		srcMap, srcIsMap := srcV.(map[string]interface{})
+		dstMap, dstIsMap := dst[k].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			mergeSettings(dstMap, srcMap)
+			continue
+		}
+
+		if srcIsMap { // don't share maps between configs
+			dstMap = map[string]interface{}{}
+			mergeSettings(dstMap, srcMap)
+			srcV = dstMap
+		}
+		dst[k] = srcV
+	}
+}
+
+func setDefaults(prefix string, settings map[string]interface{}) {
+	for k, v := range settings {
+		if m, ok := v.(map[string]interface{}); ok {
+			setDefaults(prefix+k+".", m)
+			continue
+		}
+
+		viper.SetDefault(prefix+k, v)
+	}
+}
+
+// readLintersTests reads linters-settings.<name>.tests and linters-settings.<name>.timeout
+// options: settings of every linter have own struct and can't have these common options.
+func (r *FileReader) readLintersTests() error {
+	// all settings are used to include linters settings from base configs
+	lintersSettings, _ := viper.AllSettings()["linters-settings"].(map[string]interface{})
+	for name, settings := range lintersSettings {
+		settingsMap, ok := settings.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if err := r.readLinterTimeout(name, settingsMap); err != nil {
+			return err
+		}
+
+		if err := r.readLinterTests(name, settingsMap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *FileReader) readLinterTests(name string, settingsMap map[string]interface{}) error {
+	tests, ok := settingsMap["tests"]
+	if !ok {
+		return nil
+	}
+
+	analyzeTests, ok := tests.(bool)
+	if !ok {
+		return fmt.Errorf("option linters-settings.%s.tests must be bool, got %v", name, tests)
+	}
+
+	// issues are filtered by canonical names of linters: e.g. gas is an alias of gosec
+	canonicalName := r.canonicalLinterName(name)
+	if _, ok := r.cfg.LintersSettings.Tests[canonicalName]; ok {
+		return fmt.Errorf("option linters-settings.%s.tests is set more than once by aliases of linter %s",
+			name, canonicalName)
+	}
+
+	if r.cfg.LintersSettings.Tests == nil {
+		r.cfg.LintersSettings.Tests = map[string]bool{}
+	}
+	r.cfg.LintersSettings.Tests[canonicalName] = analyzeTests
+	return nil
+}
+
+func (r *FileReader) readLinterTimeout(name string, settingsMap map[string]interface{}) error {
+	v, ok := settingsMap["timeout"]
+	if !ok {
+		return nil
+	}
+
+	timeout, err := cast.ToDurationE(v)
+	if err != nil || timeout <= 0 {
+		return fmt.Errorf("option linters-settings.%s.timeout must be positive duration, e.g. 30s, got %v", name, v)
+	}
+
+	// the runner looks timeouts up by canonical names: e.g. gas is an alias of gosec
+	canonicalName := r.canonicalLinterName(name)
+	if _, ok := r.cfg.LintersSettings.Timeouts[canonicalName]; ok {
+		return fmt.Errorf("option linters-settings.%s.timeout is set more than once by aliases of linter %s",
+			name, canonicalName)
+	}
+
+	if r.cfg.LintersSettings.Timeouts == nil {
+		r.cfg.LintersSettings.Timeouts = map[string]time.Duration{}
+	}
+	r.cfg.LintersSettings.Timeouts[canonicalName] = timeout
+	return nil
+}
+
+// canonicalLinterName returns the canonical name of the linter by any of its names:
+// names of custom and unknown linters are returned as is
+func (r *FileReader) canonicalLinterName(name string) string {
+	if canonicalName, ok := r.linterNames[name]; ok {
+		return canonicalName
+	}
+
+	return name
+}
+
+// overrideLintersByCommandLine drops options of linters from config conflicting
+// with command-line ones: command-line lists are appended to config lists when flags
+// are parsed, so linters enabled in config can be disabled by --disable and vice versa.
+func (r *FileReader) overrideLintersByCommandLine() {
+	if r.commandLineCfg == nil {
+		return
+	}
+
+	cl := &r.commandLineCfg.Linters
+	lc := &r.cfg.Linters
+	lc.Enable = withoutNames(lc.Enable, cl.Disable)
+	lc.Disable = withoutNames(lc.Disable, cl.Enable)
+
+	if cl.EnableAll {
+		lc.DisableAll = false
+		lc.Presets = nil // presets are incompatible with --enable-all
+	}
+	if cl.DisableAll {
+		lc.EnableAll = false
+	}
+}
+
+// withoutNames returns names which aren't in the excluded ones, names are case-insensitive
+func withoutNames(names, excluded []string) []string {
+	var ret []string
+	for _, name := range names {
+		isExcluded := false
+		for _, e := range excluded {
+			if strings.EqualFold(name, e) {
+				isExcluded = true
+				break
+			}
+		}
+		if !isExcluded {
+			ret = append(ret, name)
+		}
+	}
+
+	return ret
+}
+
 func (r *FileReader) validateConfig() error {
 	c := r.cfg
 	if len(c.Run.Args) != 0 {
@@ -106,6 +413,10 @@ func (r *FileReader) validateConfig() error {
 		return errors.New("can't set run.verbose option with config: only on command-line")
 	}
 
+	if _, err := goutil.ReleaseTags(c.Run.GoVersion); err != nil {
+		return fmt.Errorf("invalid run.go option: %s", err)
+	}
+
 	return nil
 }
 
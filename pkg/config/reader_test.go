@@ -0,0 +1,62 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtendsPaths(t *testing.T) {
+	paths, err := extendsPaths(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, paths)
+
+	paths, err = extendsPaths("base.yml")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"base.yml"}, paths)
+
+	paths, err = extendsPaths([]interface{}{"a.yml", "b.yml"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.yml", "b.yml"}, paths)
+
+	_, err = extendsPaths([]interface{}{"a.yml", 1})
+	assert.EqualError(t, err, "option extends must be a path or a list of paths, got [a.yml 1]")
+	_, err = extendsPaths(map[string]interface{}{})
+	assert.Error(t, err)
+}
+
+func TestReadBaseConfigs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci-lint-extends")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeConfig := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, ioutil.WriteFile(path, []byte(content), os.ModePerm))
+		return path
+	}
+
+	mainConfig := filepath.Join(dir, "main.yml")
+	writeConfig("base.yml", "run:\n  tests: false\n  skip-dirs: [gen]\n")
+	writeConfig("team.yml", "extends: base.yml\nrun:\n  tests: true\n")
+	writeConfig("loop.yml", "extends: loop.yml\n")
+
+	settings, err := readBaseConfigs(mainConfig, "team.yml", []string{mainConfig})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"run": map[string]interface{}{"tests": true, "skip-dirs": []interface{}{"gen"}},
+	}, settings)
+
+	_, err = readBaseConfigs(mainConfig, "loop.yml", []string{mainConfig})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "cycle of extended configs")
+	}
+
+	_, err = readBaseConfigs(mainConfig, "missing.yml", []string{mainConfig})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "can't read base config")
+	}
+}
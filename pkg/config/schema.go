@@ -0,0 +1,313 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// extensionKeyPrefix is a prefix of top-level keys which aren't config options:
+// e.g. they keep blocks shared by YAML anchors like in docker-compose files.
+const extensionKeyPrefix = "x-"
+
+// commonLinterSettings are options of linters-settings.<name> supported for all linters:
+// they are read by FileReader, linters without own settings can have only them.
+type commonLinterSettings struct {
+	Tests   bool
+	Timeout time.Duration
+}
+
+// keysValidator validates keys of config settings, linterNames are all known names
+// of linters: linters-settings.<name> is valid for them even without own settings.
+type keysValidator struct {
+	linterNames map[string]string
+}
+
+// validateKeys checks that all keys of config settings are known: known keys
+// are mapstructure names of fields of Config and nested structs, names of linters
+// and custom linters under linters-settings; top-level keys with extensionKeyPrefix
+// are ignored. Settings must have lowercased keys as returned by viper: YAML anchors
+// and merge keys are already resolved in them.
+func validateKeys(settings map[string]interface{}, linterNames map[string]string) error {
+	options := map[string]interface{}{}
+	for k, v := range settings {
+		if !strings.HasPrefix(k, extensionKeyPrefix) {
+			options[k] = v
+		}
+	}
+
+	v := keysValidator{linterNames: map[string]string{}}
+	for name, canonicalName := range linterNames {
+		v.linterNames[name] = canonicalName
+	}
+	for _, name := range customLinterNames(options) {
+		v.linterNames[name] = name
+	}
+
+	return v.validateStructKeys(options, reflect.TypeOf(Config{}), "", map[string]reflect.Type{"extends": nil})
+}
+
+// customLinterNames returns names of custom linters from linters-settings.custom
+func customLinterNames(settings map[string]interface{}) []string {
+	lintersSettings, _ := toStringMap(settings["linters-settings"])
+	custom, _ := toStringMap(lintersSettings["custom"])
+
+	var ret []string
+	for name := range custom {
+		ret = append(ret, strings.ToLower(name))
+	}
+
+	return ret
+}
+
+// keyNames returns config key names of struct fields: keys of fields without
+// mapstructure tag are lowercased field names because keys are case-insensitive
+func keyNames(t reflect.Type) map[string]reflect.Type {
+	ret := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if name, ok := keyName(f); ok {
+			ret[name] = f.Type
+		}
+	}
+
+	return ret
+}
+
+// keyName returns config key name of the struct field or false
+// if the field can't be set in config files
+func keyName(f reflect.StructField) (string, bool) {
+	if f.PkgPath != "" { // unexported
+		return "", false
+	}
+
+	name := strings.Split(f.Tag.Get("mapstructure"), ",")[0]
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		name = f.Name
+	}
+
+	return strings.ToLower(name), true
+}
+
+// Settings returns values of the config as settings with the same keys
+// as in config files: durations are formatted like in them.
+func (c *Config) Settings() map[string]interface{} {
+	return structSettings(reflect.ValueOf(c).Elem())
+}
+
+func structSettings(v reflect.Value) map[string]interface{} {
+	ret := map[string]interface{}{}
+	for i := 0; i < v.NumField(); i++ {
+		if name, ok := keyName(v.Type().Field(i)); ok {
+			ret[name] = valueSettings(v.Field(i))
+		}
+	}
+
+	return ret
+}
+
+func valueSettings(v reflect.Value) interface{} {
+	if d, ok := v.Interface().(time.Duration); ok {
+		return d.String()
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return valueSettings(v.Elem())
+	case reflect.Struct:
+		return structSettings(v)
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		ret := make([]interface{}, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			ret = append(ret, valueSettings(v.Index(i)))
+		}
+		return ret
+	case reflect.Map:
+		ret := map[string]interface{}{}
+		for _, k := range v.MapKeys() {
+			ret[fmt.Sprint(k.Interface())] = valueSettings(v.MapIndex(k))
+		}
+		return ret
+	}
+
+	return v.Interface()
+}
+
+func (v keysValidator) validateStructKeys(settings map[string]interface{}, t reflect.Type, path string,
+	extraKeys map[string]reflect.Type) error {
+
+	known := keyNames(t)
+	for k, kt := range extraKeys {
+		if _, ok := known[k]; !ok {
+			known[k] = kt
+		}
+	}
+
+	sortedKeys := make([]string, 0, len(settings))
+	for k := range settings {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	for _, k := range sortedKeys {
+		fieldType, ok := known[strings.ToLower(k)]
+		if !ok {
+			return unknownKeyError(k, path, known)
+		}
+
+		if fieldType == nil {
+			continue
+		}
+
+		var fieldExtraKeys map[string]reflect.Type
+		switch {
+		case fieldType == reflect.TypeOf(LintersSettings{}):
+			fieldExtraKeys = v.lintersSettingsKeys()
+		case t == reflect.TypeOf(LintersSettings{}):
+			fieldExtraKeys = keyNames(reflect.TypeOf(commonLinterSettings{}))
+		}
+
+		if err := v.validateValueKeys(settings[k], fieldType, joinKeyPath(path, k), fieldExtraKeys); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lintersSettingsKeys returns keys of linters under linters-settings
+// in addition to fields of LintersSettings
+func (v keysValidator) lintersSettingsKeys() map[string]reflect.Type {
+	ret := map[string]reflect.Type{}
+	for name := range v.linterNames {
+		ret[name] = reflect.TypeOf(commonLinterSettings{})
+	}
+
+	return ret
+}
+
+func (v keysValidator) validateValueKeys(value interface{}, t reflect.Type, path string,
+	extraKeys map[string]reflect.Type) error {
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		m, ok := toStringMap(value)
+		if !ok {
+			return nil // type errors are reported by unmarshaling
+		}
+		return v.validateStructKeys(m, t, path, extraKeys)
+	case reflect.Slice:
+		elems, ok := value.([]interface{})
+		if !ok {
+			return nil
+		}
+		for i, elem := range elems {
+			if err := v.validateValueKeys(elem, t.Elem(), fmt.Sprintf("%s[%d]", path, i), nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// toStringMap converts maps decoded from config files: yaml decodes them to map[interface{}]interface{}
+func toStringMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case map[string]interface{}:
+		return m, true
+	case map[interface{}]interface{}:
+		ret := map[string]interface{}{}
+		for k, v := range m {
+			ret[fmt.Sprint(k)] = v
+		}
+		return ret, true
+	}
+
+	return nil, false
+}
+
+func joinKeyPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func unknownKeyError(key, path string, known map[string]reflect.Type) error {
+	msg := fmt.Sprintf("unknown top-level key `%s`", key)
+	if path != "" {
+		msg = fmt.Sprintf("unknown key `%s` under `%s`", key, path)
+	}
+
+	if suggestion := closestKey(strings.ToLower(key), known); suggestion != "" {
+		msg += fmt.Sprintf(", did you mean `%s`?", suggestion)
+	}
+
+	return fmt.Errorf("%s", msg)
+}
+
+// closestKey returns the known key with the minimal edit distance to the key
+// or empty string if all known keys are too different
+func closestKey(key string, known map[string]reflect.Type) string {
+	var best string
+	bestDist := len(key)/2 + 1 // don't suggest completely different keys
+	for k := range known {
+		d := editDistance(key, k)
+		if d < bestDist || (d == bestDist && best != "" && k < best) {
+			best, bestDist = k, d
+		}
+	}
+
+	return best
+}
+
+// editDistance returns Levenshtein distance between strings a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
+
+func minInt(first int, rest ...int) int {
+	ret := first
+	for _, v := range rest {
+		if v < ret {
+			ret = v
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// testLinterNames are names of linters as returned by lintersdb.Manager.GetAllLinterNames:
+// lintersdb can't be imported because it depends on config
+var testLinterNames = map[string]string{
+	"golint":      "golint",
+	"staticcheck": "staticcheck",
+	"structcheck": "structcheck",
+	"gosec":       "gosec",
+	"gas":         "gosec",
+}
+
+func TestValidateKeys(t *testing.T) {
+	cases := []struct {
+		settings map[string]interface{}
+		err      string
+	}{
+		{
+			settings: map[string]interface{}{
+				"run":     map[string]interface{}{"skip-dirs": []interface{}{"a"}, "deadline": "1m"},
+				"extends": "base.yml",
+				"linters-settings": map[string]interface{}{
+					"golint": map[string]interface{}{"min-confidence": 0.8, "tests": false},
+				},
+				"internaltest": true,
+				"x-shared":     map[string]interface{}{"foo": 1},
+			},
+		},
+		{
+			settings: map[string]interface{}{
+				"linters-settings": map[string]interface{}{
+					"staticcheck": map[string]interface{}{"tests": false},
+					"gas":         map[string]interface{}{"timeout": "1m"},
+					"golint":      map[string]interface{}{"timeout": "1m"},
+					"custom": map[string]interface{}{
+						"example": map[string]interface{}{"path": "example.so"},
+					},
+					"example": map[string]interface{}{"tests": false},
+				},
+			},
+		},
+		{
+			settings: map[string]interface{}{
+				"linters-settings": map[string]interface{}{
+					"staticcheck": map[string]interface{}{"checks": []interface{}{"all"}},
+				},
+			},
+			err: "unknown key `checks` under `linters-settings.staticcheck`",
+		},
+		{
+			settings: map[string]interface{}{
+				"linters-settings": map[string]interface{}{"staticchek": map[string]interface{}{}},
+			},
+			err: "unknown key `staticchek` under `linters-settings`, did you mean `staticcheck`?",
+		},
+		{
+			settings: map[string]interface{}{"run": map[string]interface{}{"skipdirs": []interface{}{"a"}}},
+			err:      "unknown key `skipdirs` under `run`, did you mean `skip-dirs`?",
+		},
+		{
+			settings: map[string]interface{}{"lintrs": map[string]interface{}{}},
+			err:      "unknown top-level key `lintrs`, did you mean `linters`?",
+		},
+		{
+			settings: map[string]interface{}{"issues": map[string]interface{}{"foo": 1}},
+			err:      "unknown key `foo` under `issues`",
+		},
+		{
+			settings: map[string]interface{}{
+				"severity": map[string]interface{}{
+					"rules": []interface{}{map[interface{}]interface{}{"severity": "info", "linterz": "a"}},
+				},
+			},
+			err: "unknown key `linterz` under `severity.rules[0]`, did you mean `linters`?",
+		},
+	}
+
+	for _, c := range cases {
+		err := validateKeys(c.settings, testLinterNames)
+		if c.err == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, c.err)
+		}
+	}
+}
+
+func TestExampleConfigKeysAreKnown(t *testing.T) {
+	v := viper.New()
+	v.SetConfigFile(filepath.Join("..", "..", ".golangci.example.yml"))
+	assert.NoError(t, v.ReadInConfig())
+	assert.NoError(t, validateKeys(v.AllSettings(), testLinterNames))
+}
+
+func TestSettings(t *testing.T) {
+	var cfg Config
+	cfg.Run.Timeout = time.Minute
+	cfg.Run.SkipDirs = []string{"gen"}
+	cfg.Run.OnlyFiles = []string{"a.go"}
+	cfg.Issues.ExcludeRules = []ExcludeRule{{Linters: []string{"golint"}}}
+
+	settings := cfg.Settings()
+	assert.NoError(t, validateKeys(settings, testLinterNames))
+
+	run := settings["run"].(map[string]interface{})
+	assert.Equal(t, "1m0s", run["timeout"])
+	assert.Equal(t, []interface{}{"gen"}, run["skip-dirs"])
+	assert.NotContains(t, run, "onlyfiles")
+
+	rules := settings["issues"].(map[string]interface{})["exclude-rules"].([]interface{})
+	assert.Equal(t, []interface{}{"golint"}, rules[0].(map[string]interface{})["linters"])
+}
@@ -1,15 +1,52 @@
 package exitcodes
 
+// Exit codes of golangci-lint: only IssuesFound can be changed by the issues-exit-code option
+// to let found issues be non-fatal, all other codes mean that analysis wasn't done properly.
 const (
-	Success              = 0
-	IssuesFound          = 1
-	WarningInTest        = 2
-	Failure              = 3
-	Timeout              = 4
-	NoGoFiles            = 5
+	// Success is returned if no issues were found or all found issues don't fail the run
+	Success = 0
+	// IssuesFound is returned only if analysis was done and failing issues were printed
+	IssuesFound = 1
+	// WarningInTest is returned on warnings in tests of golangci-lint or if FAIL_ON_WARNINGS=1
+	WarningInTest = 2
+	// Failure is returned on config and usage errors and errors of running and printing
+	Failure = 3
+	// Timeout is returned if deadline was exceeded: found issues can be incomplete
+	Timeout = 4
+	// NoGoFiles is returned if there are no go files to analyze
+	NoGoFiles = 5
+	// NoConfigFileDetected is returned by the config path command
 	NoConfigFileDetected = 6
+	// MemoryLimitExceeded is returned if analysis was canceled by the max-memory option
+	MemoryLimitExceeded = 7
 )
 
+var reasons = map[int]string{
+	Success:              "success",
+	IssuesFound:          "issues-found",
+	WarningInTest:        "warning-in-test",
+	Failure:              "failure",
+	Timeout:              "timeout",
+	NoGoFiles:            "no-go-files",
+	NoConfigFileDetected: "no-config-file-detected",
+	MemoryLimitExceeded:  "memory-limit-exceeded",
+}
+
+// IsReserved returns true if the code has another meaning than found issues
+// and can't be used as the issues exit code
+func IsReserved(code int) bool {
+	return code != Success && code != IssuesFound && reasons[code] != ""
+}
+
+// Reason returns stable machine-readable reason of the exit code.
+func Reason(code int) string {
+	if reason, ok := reasons[code]; ok {
+		return reason
+	}
+
+	return "unknown"
+}
+
 type ExitError struct {
 	Message string
 	Code    int
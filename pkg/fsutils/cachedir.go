@@ -0,0 +1,64 @@
+package fsutils
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	cacheDirTagName      = "CACHEDIR.TAG"
+	cacheDirTagSignature = "Signature: 8a477f597d28d172789f06886806bc55"
+	cacheDirTag          = cacheDirTagSignature + "\n" +
+		"# This file is a cache directory tag created by golangci-lint.\n" +
+		"# For information about cache directory tags, see http://www.brynosaurus.com/cachedir/\n"
+)
+
+// InitCacheDir creates the cache dir and marks it by the cache directory tag.
+// An existing dir is used only if it's empty or was already marked: it's
+// removed entirely by RemoveCacheDir.
+func InitCacheDir(dir string) error {
+	if IsCacheDir(dir) {
+		return nil
+	}
+
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("can't create cache dir: %s", err)
+	}
+
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("can't read cache dir: %s", err)
+	}
+	if len(entries) != 0 {
+		return fmt.Errorf("cache dir %s isn't empty and wasn't created by golangci-lint", dir)
+	}
+
+	if err = ioutil.WriteFile(filepath.Join(dir, cacheDirTagName), []byte(cacheDirTag), os.ModePerm); err != nil {
+		return fmt.Errorf("can't write cache dir tag: %s", err)
+	}
+
+	return nil
+}
+
+// IsCacheDir returns true if the dir was created by InitCacheDir.
+func IsCacheDir(dir string) bool {
+	data, err := ioutil.ReadFile(filepath.Join(dir, cacheDirTagName))
+	return err == nil && strings.HasPrefix(string(data), cacheDirTagSignature)
+}
+
+// RemoveCacheDir removes the cache dir only if it was created by InitCacheDir:
+// cache dir is configured by user and can point to any dir.
+func RemoveCacheDir(dir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	if !IsCacheDir(dir) {
+		return fmt.Errorf("dir %s wasn't created by golangci-lint: no %s in it", dir, cacheDirTagName)
+	}
+
+	return os.RemoveAll(dir)
+}
@@ -0,0 +1,35 @@
+package fsutils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheDir(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "golangci-lint-cachedir")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	dir := filepath.Join(tmpDir, "cache")
+	assert.NoError(t, RemoveCacheDir(dir)) // not existing dir
+
+	assert.NoError(t, InitCacheDir(dir))
+	assert.True(t, IsCacheDir(dir))
+	writeTestFile(t, filepath.Join(dir, "issues", "entry.json"), "[]")
+	assert.NoError(t, InitCacheDir(dir)) // already marked dir
+
+	assert.NoError(t, RemoveCacheDir(dir))
+	assert.False(t, IsDir(dir))
+
+	// not empty dir of user isn't marked and removed
+	userDir := filepath.Join(tmpDir, "user")
+	writeTestFile(t, filepath.Join(userDir, "main.go"), "package main")
+	assert.Error(t, InitCacheDir(userDir))
+	assert.False(t, IsCacheDir(userDir))
+	assert.Error(t, RemoveCacheDir(userDir))
+	assert.True(t, IsDir(userDir))
+}
@@ -2,6 +2,8 @@ package fsutils
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sync"
@@ -98,3 +100,38 @@ func ShortestRelPath(path string, wd string) (string, error) {
 
 	return relPath, nil
 }
+
+// WriteFileAtomically writes the file by the given func to a temporary file
+// in the same dir and renames it to path only if writing succeeded:
+// readers never see a partially written file and a previous file is kept on error.
+func WriteFileAtomically(path string, write func(w io.Writer) error) (err error) {
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("can't create temp file for %s: %s", path, err)
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	// temp files are created with 0600 permissions
+	if err = f.Chmod(0644); err != nil {
+		return fmt.Errorf("can't chmod temp file for %s: %s", path, err)
+	}
+	if err = write(f); err != nil {
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		return fmt.Errorf("can't sync temp file for %s: %s", path, err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("can't close temp file for %s: %s", path, err)
+	}
+	if err = os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("can't rename temp file to %s: %s", path, err)
+	}
+
+	return nil
+}
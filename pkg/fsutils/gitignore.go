@@ -0,0 +1,165 @@
+package fsutils
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// GitIgnore matches paths against .gitignore files of a git repository:
+// the root one and nested ones in parent dirs of paths. It isn't safe
+// for concurrent use.
+type GitIgnore struct {
+	root     string
+	patterns map[string][]gitIgnorePattern // by slash-separated dir relative to root
+}
+
+type gitIgnorePattern struct {
+	glob    string // for MatchGlob
+	negate  bool
+	dirOnly bool
+}
+
+// NewGitIgnore returns a matcher for the git repository containing dir
+// or nil if dir isn't in a git repository.
+func NewGitIgnore(dir string) (*GitIgnore, error) {
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		// .git is a file in worktrees and submodules
+		if _, err = os.Stat(filepath.Join(root, ".git")); err == nil {
+			return &GitIgnore{
+				root:     root,
+				patterns: map[string][]gitIgnorePattern{},
+			}, nil
+		}
+
+		parent := filepath.Dir(root)
+		if parent == root {
+			return nil, nil
+		}
+		root = parent
+	}
+}
+
+// IsIgnored reports whether the path is ignored: a path is ignored
+// if it or any of its parent dirs inside the repository is ignored.
+func (g *GitIgnore) IsIgnored(filePath string, isDir bool) bool {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return false
+	}
+
+	relPath, err := filepath.Rel(g.root, absPath)
+	if err != nil || relPath == "." || strings.HasPrefix(relPath, "..") {
+		return false
+	}
+
+	segs := strings.Split(filepath.ToSlash(relPath), "/")
+	for i := 1; i <= len(segs); i++ {
+		if g.matchSegments(segs[:i], i < len(segs) || isDir) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchSegments applies patterns of .gitignore files of all parent dirs:
+// patterns of deeper files and later patterns in a file take precedence.
+func (g *GitIgnore) matchSegments(segs []string, isDir bool) bool {
+	ignored := false
+	for d := 0; d < len(segs); d++ {
+		relPath := strings.Join(segs[d:], "/")
+		for _, p := range g.dirPatterns(strings.Join(segs[:d], "/")) {
+			if p.dirOnly && !isDir {
+				continue
+			}
+			if MatchGlob(p.glob, relPath) {
+				ignored = !p.negate
+			}
+		}
+	}
+
+	return ignored
+}
+
+func (g *GitIgnore) dirPatterns(dir string) []gitIgnorePattern {
+	if patterns, ok := g.patterns[dir]; ok {
+		return patterns
+	}
+
+	// unreadable .gitignore files are skipped like missing ones
+	patterns, _ := readGitIgnore(filepath.Join(g.root, filepath.FromSlash(dir), ".gitignore"))
+	g.patterns[dir] = patterns
+	return patterns
+}
+
+func readGitIgnore(filePath string) ([]gitIgnorePattern, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns []gitIgnorePattern
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if p, ok := parseGitIgnoreLine(scanner.Text()); ok {
+			patterns = append(patterns, p)
+		}
+	}
+
+	return patterns, scanner.Err()
+}
+
+// trimGitIgnoreLine removes the line ending and trailing spaces unless they are escaped
+func trimGitIgnoreLine(line string) string {
+	line = strings.TrimSuffix(line, "\r")
+	if strings.HasSuffix(line, `\ `) {
+		return line
+	}
+
+	return strings.TrimRight(line, " ")
+}
+
+// parseGitIgnoreLine parses a line by rules of 'git help gitignore'
+func parseGitIgnoreLine(line string) (gitIgnorePattern, bool) {
+	line = trimGitIgnoreLine(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return gitIgnorePattern{}, false
+	}
+
+	var p gitIgnorePattern
+	if strings.HasPrefix(line, "!") {
+		p.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\!`) || strings.HasPrefix(line, `\#`) {
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		p.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+
+	// patterns with a slash at the beginning or middle are relative to the dir
+	// of .gitignore, other patterns match a name at any level below it
+	if strings.Contains(line, "/") {
+		line = strings.TrimPrefix(line, "/")
+	} else {
+		line = path.Join("**", line)
+	}
+
+	p.glob = strings.Replace(line, "[!", "[^", -1)
+	if line == "" || ValidateGlob(p.glob) != nil {
+		return gitIgnorePattern{}, false
+	}
+
+	return p, true
+}
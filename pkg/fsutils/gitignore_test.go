@@ -0,0 +1,80 @@
+package fsutils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFile(t *testing.T, filePath, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(filePath), os.ModePerm))
+	require.NoError(t, ioutil.WriteFile(filePath, []byte(content), os.ModePerm))
+}
+
+func TestGitIgnore(t *testing.T) {
+	root, err := ioutil.TempDir("", "gitignore")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), os.ModePerm))
+	writeTestFile(t, filepath.Join(root, ".gitignore"), `
+# build outputs
+build/
+/gen
+*.pb.go
+!keep.pb.go
+docs/**/*.tmp.go
+\#hash.go
+`)
+	writeTestFile(t, filepath.Join(root, "pkg", ".gitignore"), "local.go\n!/build\n")
+
+	gi, err := NewGitIgnore(filepath.Join(root, "pkg"))
+	require.NoError(t, err)
+	require.NotNil(t, gi)
+
+	cases := []struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{"build", true, true},
+		{"build/a.go", false, true},
+		{"sub/build", true, true},
+		{"sub/build/a.go", false, true},
+		{"build", false, false}, // dir-only pattern
+		{"gen", true, true},
+		{"gen/a.go", false, true},
+		{"sub/gen", true, false}, // anchored pattern
+		{"a.pb.go", false, true},
+		{"sub/a.pb.go", false, true},
+		{"sub/keep.pb.go", false, false},
+		{"docs/a.tmp.go", false, true},
+		{"docs/x/y/a.tmp.go", false, true},
+		{"docs/a.go", false, false},
+		{"#hash.go", false, true},
+		{"local.go", false, false},
+		{"pkg/local.go", false, true},
+		{"pkg/sub/local.go", false, true},
+		{"pkg/build", true, false}, // re-included by the nested .gitignore
+		{"pkg/a.go", false, false},
+		{".", true, false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.ignored, gi.IsIgnored(filepath.Join(root, filepath.FromSlash(c.path)), c.isDir), "path %q", c.path)
+	}
+}
+
+func TestGitIgnoreNotInRepo(t *testing.T) {
+	root, err := ioutil.TempDir("", "gitignore")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	gi, err := NewGitIgnore(root)
+	require.NoError(t, err)
+	assert.Nil(t, gi)
+}
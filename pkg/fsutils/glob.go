@@ -0,0 +1,50 @@
+package fsutils
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ValidateGlob checks syntax of glob pattern for MatchGlob.
+func ValidateGlob(pattern string) error {
+	for _, seg := range strings.Split(pattern, "/") {
+		if _, err := path.Match(seg, ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MatchGlob reports whether slash-separated path matches the pattern:
+// segment "**" matches zero or more path segments, other segments
+// are matched by path.Match, e.g. "**/*.gen.go" or "internal/mock/**".
+func MatchGlob(pattern, filePath string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(filepath.ToSlash(filePath), "/"))
+}
+
+func matchGlobSegments(pattern, segs []string) bool {
+	if len(pattern) == 0 {
+		return len(segs) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segs); i++ {
+			if matchGlobSegments(pattern[1:], segs[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(segs) == 0 {
+		return false
+	}
+
+	if matched, _ := path.Match(pattern[0], segs[0]); !matched {
+		return false
+	}
+
+	return matchGlobSegments(pattern[1:], segs[1:])
+}
@@ -0,0 +1,35 @@
+package fsutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchGlob(t *testing.T) {
+	cases := []struct {
+		pattern, path string
+		matched       bool
+	}{
+		{"**/*.gen.go", "a.gen.go", true},
+		{"**/*.gen.go", "pkg/sub/a.gen.go", true},
+		{"**/*.gen.go", "pkg/a.go", false},
+		{"internal/mock/**", "internal/mock/a.go", true},
+		{"internal/mock/**", "internal/mock/sub/a.go", true},
+		{"internal/mock/**", "internal/mocks/a.go", false},
+		{"internal/mock/**", "pkg/internal/mock/a.go", false},
+		{"pkg/**/gen/*.go", "pkg/gen/a.go", true},
+		{"pkg/**/gen/*.go", "pkg/a/b/gen/a.go", true},
+		{"pkg/**/gen/*.go", "pkg/a/b/gen/sub/a.go", false},
+		{"pkg/*.go", "pkg/sub/a.go", false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.matched, MatchGlob(c.pattern, c.path), "pattern %q, path %q", c.pattern, c.path)
+	}
+}
+
+func TestValidateGlob(t *testing.T) {
+	assert.NoError(t, ValidateGlob("**/*.gen.go"))
+	assert.Error(t, ValidateGlob("pkg/[a.go"))
+}
@@ -0,0 +1,41 @@
+package gitutil
+
+import (
+	"context"
+	"strings"
+)
+
+// diffArgs make output of git diff independent of user's git config:
+// renamed files are detected to report only changed lines of them.
+var diffArgs = []string{"diff", "--relative", "--no-color", "--no-ext-diff",
+	"--src-prefix=a/", "--dst-prefix=b/", "--find-renames"}
+
+// Patch returns unified diff of dir against revision rev and untracked files:
+// all lines of them are new. If rev is empty, uncommitted changes are returned or,
+// if there are no such changes, changes of the last commit.
+// Paths are relative to dir.
+func Patch(ctx context.Context, dir, rev string) (patch string, newFiles []string, err error) {
+	untracked, err := runGit(ctx, dir, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return "", nil, err
+	}
+	for _, f := range strings.Split(untracked, "\n") {
+		if f != "" {
+			newFiles = append(newFiles, f)
+		}
+	}
+
+	if rev != "" {
+		patch, err = runGit(ctx, dir, append(diffArgs, rev, "--")...)
+		return patch, newFiles, err
+	}
+
+	// staged and unstaged changes
+	patch, err = runGit(ctx, dir, append(diffArgs, "HEAD", "--")...)
+	if err != nil || patch != "" || len(newFiles) != 0 {
+		return patch, newFiles, err
+	}
+
+	patch, err = runGit(ctx, dir, append(diffArgs, "HEAD~", "--")...)
+	return patch, nil, err
+}
@@ -0,0 +1,25 @@
+package gitutil
+
+import (
+	"context"
+)
+
+// HeadInfo returns commit hash and branch name of HEAD of the repo containing dir:
+// branch is empty for detached HEAD.
+func HeadInfo(ctx context.Context, dir string) (commit, branch string, err error) {
+	commit, err = runGit(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", "", err
+	}
+
+	branch, err = runGit(ctx, dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", "", err
+	}
+
+	if branch == "HEAD" {
+		branch = ""
+	}
+
+	return commit, branch, nil
+}
@@ -0,0 +1,45 @@
+package gitutil
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeadInfo(t *testing.T) {
+	repoDir := initTestRepo(t)
+	defer os.RemoveAll(repoDir)
+
+	ctx := context.Background()
+	headCommit, err := runGit(ctx, repoDir, "rev-parse", "HEAD")
+	require.NoError(t, err)
+	headBranch, err := runGit(ctx, repoDir, "symbolic-ref", "--short", "HEAD")
+	require.NoError(t, err)
+
+	commit, branch, err := HeadInfo(ctx, repoDir)
+	assert.NoError(t, err)
+	assert.Equal(t, headCommit, commit)
+	assert.Equal(t, headBranch, branch)
+
+	// detached HEAD has no branch
+	_, err = runGit(ctx, repoDir, "checkout", "-q", "HEAD~1")
+	require.NoError(t, err)
+	commit, branch, err = HeadInfo(ctx, repoDir)
+	assert.NoError(t, err)
+	assert.NotEqual(t, headCommit, commit)
+	assert.Len(t, commit, 40)
+	assert.Empty(t, branch)
+}
+
+func TestHeadInfoNotInRepo(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci-lint-gitutil")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, _, err = HeadInfo(context.Background(), dir)
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package gitutil
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+// Worktree is a temporary git worktree with files checked out at some revision.
+type Worktree struct {
+	Dir    string // root dir of the worktree
+	Prefix string // path of the current dir relative to the repo root
+
+	origDir string
+	log     logutils.Log
+}
+
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to run 'git %s': %s",
+			strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// NewWorktree checks out revision rev of the repo containing the current dir
+// into a new temporary worktree. It must be removed by Remove.
+func NewWorktree(ctx context.Context, rev string, log logutils.Log) (*Worktree, error) {
+	origDir, err := os.Getwd()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get working dir")
+	}
+
+	prefix, err := runGit(ctx, origDir, "rev-parse", "--show-prefix")
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := ioutil.TempDir("", "golangci-lint-rev-")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create temp dir for worktree")
+	}
+
+	// temp dir can be a symlink (e.g. on macOS): paths of issues must be evaluated
+	if evaledDir, evalErr := filepath.EvalSymlinks(tmpDir); evalErr == nil {
+		tmpDir = evaledDir
+	}
+
+	if _, err = runGit(ctx, origDir, "worktree", "add", "--detach", tmpDir, rev); err != nil {
+		os.RemoveAll(tmpDir)
+		return nil, err
+	}
+
+	log.Infof("Checked out revision %s into worktree %s", rev, tmpDir)
+	return &Worktree{
+		Dir:     tmpDir,
+		Prefix:  filepath.FromSlash(prefix),
+		origDir: origDir,
+		log:     log,
+	}, nil
+}
+
+// WorkDir returns dir in the worktree corresponding to the current dir.
+func (w Worktree) WorkDir() string {
+	return filepath.Join(w.Dir, w.Prefix)
+}
+
+// Remove removes worktree files and git metadata of the worktree.
+func (w Worktree) Remove() {
+	// don't use analysis context: it can be already canceled by deadline
+	if _, err := runGit(context.Background(), w.origDir, "worktree", "remove", "--force", w.Dir); err != nil {
+		w.log.Warnf("Failed to remove worktree: %s", err)
+	}
+
+	if err := os.RemoveAll(w.Dir); err != nil {
+		w.log.Warnf("Failed to remove worktree dir %s: %s", w.Dir, err)
+	}
+}
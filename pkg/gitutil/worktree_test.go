@@ -0,0 +1,81 @@
+package gitutil
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+// initTestRepo creates a git repo with two commits of pkg/a.go
+func initTestRepo(t *testing.T) string {
+	repoDir, err := ioutil.TempDir("", "golangci-lint-gitutil")
+	require.NoError(t, err)
+	if evaledDir, evalErr := filepath.EvalSymlinks(repoDir); evalErr == nil {
+		repoDir = evaledDir
+	}
+
+	git := func(args ...string) {
+		args = append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)
+		_, gitErr := runGit(context.Background(), repoDir, args...)
+		require.NoError(t, gitErr)
+	}
+
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "pkg"), os.ModePerm))
+	git("init", "-q")
+	for _, content := range []string{"package pkg // v1\n", "package pkg // v2\n"} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(repoDir, "pkg", "a.go"), []byte(content), os.ModePerm))
+		git("add", "-A")
+		git("commit", "-q", "-m", content)
+	}
+
+	return repoDir
+}
+
+func TestWorktree(t *testing.T) {
+	repoDir := initTestRepo(t)
+	defer os.RemoveAll(repoDir)
+
+	origWd, err := os.Getwd()
+	require.NoError(t, err)
+	defer os.Chdir(origWd) //nolint:errcheck // nothing to do if wd can't be restored
+	require.NoError(t, os.Chdir(filepath.Join(repoDir, "pkg")))
+
+	wt, err := NewWorktree(context.Background(), "HEAD~1", logutils.NewStderrLog(""))
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(wt.Dir, "pkg"), wt.WorkDir())
+
+	// files of the revision are checked out, files of the current tree aren't changed
+	content, err := ioutil.ReadFile(filepath.Join(wt.WorkDir(), "a.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package pkg // v1\n", string(content))
+	content, err = ioutil.ReadFile("a.go")
+	require.NoError(t, err)
+	assert.Equal(t, "package pkg // v2\n", string(content))
+
+	wt.Remove()
+	_, err = os.Stat(wt.Dir)
+	assert.True(t, os.IsNotExist(err))
+	worktrees, err := runGit(context.Background(), repoDir, "worktree", "list")
+	require.NoError(t, err)
+	assert.NotContains(t, worktrees, wt.Dir)
+}
+
+func TestWorktreeUnknownRevision(t *testing.T) {
+	repoDir := initTestRepo(t)
+	defer os.RemoveAll(repoDir)
+
+	origWd, err := os.Getwd()
+	require.NoError(t, err)
+	defer os.Chdir(origWd) //nolint:errcheck // nothing to do if wd can't be restored
+	require.NoError(t, os.Chdir(repoDir))
+
+	_, err = NewWorktree(context.Background(), "no-such-rev", logutils.NewStderrLog(""))
+	assert.Error(t, err)
+}
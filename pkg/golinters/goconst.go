@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	goconstAPI "github.com/golangci/goconst"
+	"golang.org/x/tools/go/packages"
 
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/result"
@@ -21,27 +22,30 @@ func (Goconst) Desc() string {
 }
 
 func (lint Goconst) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
-	var goconstIssues []goconstAPI.Issue
 	cfg := goconstAPI.Config{
 		MatchWithConstants: true,
 		MinStringLength:    lintCtx.Settings().Goconst.MinStringLen,
 		MinOccurrences:     lintCtx.Settings().Goconst.MinOccurrencesCount,
 	}
-	for _, pkg := range lintCtx.Packages {
+	return analyzePackages(ctx, lintCtx, lint.Name(), func(pkg *packages.Package) ([]result.Issue, error) {
 		files, fset, err := getASTFilesForGoPkg(lintCtx, pkg)
 		if err != nil {
 			return nil, err
 		}
 
-		issues, err := goconstAPI.Run(files, fset, &cfg)
+		pkgCfg := cfg // analysis of a skipped package can be still running
+		goconstIssues, err := goconstAPI.Run(files, fset, &pkgCfg)
 		if err != nil {
 			return nil, err
 		}
 
-		goconstIssues = append(goconstIssues, issues...)
-	}
+		return lint.convertIssues(lintCtx, goconstIssues), nil
+	})
+}
+
+func (lint Goconst) convertIssues(lintCtx *linter.Context, goconstIssues []goconstAPI.Issue) []result.Issue {
 	if len(goconstIssues) == 0 {
-		return nil, nil
+		return nil
 	}
 
 	res := make([]result.Issue, 0, len(goconstIssues))
@@ -60,5 +64,5 @@ func (lint Goconst) Run(ctx context.Context, lintCtx *linter.Context) ([]result.
 		})
 	}
 
-	return res, nil
+	return res
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"go/token"
+	"io/ioutil"
 
 	"github.com/golangci/tools/imports" // TODO: replace with x/tools when use it in golangci/gofmt/gofmt
 
@@ -59,7 +60,57 @@ func getFirstDeletedAndAddedLineNumberInHunk(h *diff.Hunk) (int, int, error) {
 	return 0, firstAddedLineNumber, fmt.Errorf("didn't find deletion line in hunk %s", string(h.Body))
 }
 
-func (g Gofmt) extractIssuesFromPatch(patch string, log logutils.Log) ([]result.Issue, error) {
+// getHunkReplacement returns replacement of original lines of the hunk by new lines
+// or nil if the hunk can't be applied as a replacement
+func getHunkReplacement(h *diff.Hunk, lineOffsets []int, contentLen int) *result.Replacement {
+	newText, ok := getHunkNewText(h)
+	if !ok {
+		return nil
+	}
+
+	startLine := int(h.OrigStartLine)
+	if h.OrigLines == 0 { // lines are inserted after the start line
+		startLine++
+	}
+	endLine := startLine + int(h.OrigLines) // exclusive
+	if startLine < 1 || startLine > len(lineOffsets) || endLine > len(lineOffsets)+1 {
+		return nil
+	}
+
+	endOffset := contentLen
+	if endLine <= len(lineOffsets) {
+		endOffset = lineOffsets[endLine-1]
+	}
+
+	return &result.Replacement{
+		Offset:  lineOffsets[startLine-1],
+		Length:  endOffset - lineOffsets[startLine-1],
+		NewText: newText,
+	}
+}
+
+// getHunkNewText returns new lines of the hunk: ok is false if the hunk
+// has lines other than context, added and deleted ones
+func getHunkNewText(h *diff.Hunk) (newText string, ok bool) {
+	var buf bytes.Buffer
+	for _, line := range bytes.Split(h.Body, []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+		switch line[0] {
+		case ' ', '+':
+			buf.Write(line[1:])
+			buf.WriteByte('\n')
+		case '-':
+		default: // e.g. "\ No newline at end of file"
+			return "", false
+		}
+	}
+
+	return buf.String(), true
+}
+
+func (g Gofmt) extractIssuesFromPatch(patch string, fileContent []byte, log logutils.Log) ([]result.Issue, error) {
 	diffs, err := diff.ParseMultiFileDiff([]byte(patch))
 	if err != nil {
 		return nil, fmt.Errorf("can't parse patch: %s", err)
@@ -69,6 +120,7 @@ func (g Gofmt) extractIssuesFromPatch(patch string, log logutils.Log) ([]result.
 		return nil, fmt.Errorf("got no diffs from patch parser: %v", diffs)
 	}
 
+	lineOffsets := getLineOffsets(fileContent)
 	issues := []result.Issue{}
 	for _, d := range diffs {
 		if len(d.Hunks) == 0 {
@@ -96,7 +148,8 @@ func (g Gofmt) extractIssuesFromPatch(patch string, log logutils.Log) ([]result.
 					Filename: d.NewName,
 					Line:     deletedLine,
 				},
-				Text: text,
+				Text:        text,
+				Replacement: getHunkReplacement(hunk, lineOffsets, len(fileContent)),
 			}
 			issues = append(issues, i)
 		}
@@ -124,7 +177,12 @@ func (g Gofmt) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue
 			continue
 		}
 
-		is, err := g.extractIssuesFromPatch(string(diff), lintCtx.Log)
+		fileContent, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("can't read file %s: %s", f, err)
+		}
+
+		is, err := g.extractIssuesFromPatch(string(diff), fileContent, lintCtx.Log)
 		if err != nil {
 			return nil, fmt.Errorf("can't extract issues from gofmt diff output %q: %s", string(diff), err)
 		}
@@ -0,0 +1,41 @@
+package golinters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sourcegraph.com/sourcegraph/go-diff/diff"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestGetHunkReplacement(t *testing.T) {
+	content := []byte("package p\n\nvar  x = 1\nvar y = 2\n")
+	lineOffsets := []int{0, 10, 11, 23}
+
+	replacement := getHunkReplacement(&diff.Hunk{
+		OrigStartLine: 3,
+		OrigLines:     1,
+		Body:          []byte("-var  x = 1\n+var x = 1\n"),
+	}, lineOffsets, len(content))
+	assert.Equal(t, &result.Replacement{Offset: 11, Length: 12, NewText: "var x = 1\n"}, replacement)
+
+	// lines are inserted after the start line
+	replacement = getHunkReplacement(&diff.Hunk{
+		OrigStartLine: 2,
+		OrigLines:     0,
+		Body:          []byte("+var z = 3\n"),
+	}, lineOffsets, len(content))
+	assert.Equal(t, &result.Replacement{Offset: 11, Length: 0, NewText: "var z = 3\n"}, replacement)
+
+	assert.Nil(t, getHunkReplacement(&diff.Hunk{
+		OrigStartLine: 4,
+		OrigLines:     1,
+		Body:          []byte("-var y = 2\n+var y = 3\n\\ No newline at end of file\n"),
+	}, lineOffsets, len(content)))
+	assert.Nil(t, getHunkReplacement(&diff.Hunk{
+		OrigStartLine: 5,
+		OrigLines:     1,
+		Body:          []byte("-var y = 2\n"),
+	}, lineOffsets, len(content)))
+}
@@ -7,6 +7,7 @@ import (
 	"go/token"
 
 	lintAPI "github.com/golangci/lint-1"
+	"golang.org/x/tools/go/packages"
 
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/result"
@@ -23,26 +24,19 @@ func (Golint) Desc() string {
 }
 
 func (g Golint) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
-	var issues []result.Issue
-	var lintErr error
-	for _, pkg := range lintCtx.Packages {
+	return analyzePackages(ctx, lintCtx, g.Name(), func(pkg *packages.Package) ([]result.Issue, error) {
 		files, fset, err := getASTFilesForGoPkg(lintCtx, pkg)
 		if err != nil {
 			return nil, err
 		}
 
-		i, err := g.lintPkg(lintCtx.Settings().Golint.MinConfidence, files, fset)
+		issues, err := g.lintPkg(lintCtx.Settings().Golint.MinConfidence, files, fset)
 		if err != nil {
-			lintErr = err
-			continue
+			lintCtx.Log.Warnf("Golint: %s", err)
+			return nil, nil
 		}
-		issues = append(issues, i...)
-	}
-	if lintErr != nil {
-		lintCtx.Log.Warnf("Golint: %s", lintErr)
-	}
-
-	return issues, nil
+		return issues, nil
+	})
 }
 
 func (g Golint) lintPkg(minConfidence float64, files []*ast.File, fset *token.FileSet) ([]result.Issue, error) {
@@ -2,11 +2,16 @@ package golinters
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"go/ast"
 	"go/token"
+	"strconv"
+	"sync"
 
 	govetAPI "github.com/golangci/govet"
 
+	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/result"
@@ -46,7 +51,31 @@ func (g Govet) Run(_ context.Context, lintCtx *linter.Context) ([]result.Issue,
 	return res, nil
 }
 
+// govetMu serializes runs of govet: its analyzers are configured by global flags
+// and concurrent runs of lint.Run can have different settings
+var govetMu sync.Mutex
+
+// configureAnalyzers enables and disables analyzers of govet by its flags:
+// every analyzer is registered as a flag of the standard flag set
+func configureAnalyzers(settings *config.GovetSettings) error {
+	for _, name := range config.GovetAnalyzers {
+		if err := flag.Set(name, strconv.FormatBool(settings.IsAnalyzerEnabled(name))); err != nil {
+			return fmt.Errorf("can't configure govet analyzer %s: %s", name, err)
+		}
+	}
+
+	return nil
+}
+
 func (g Govet) runImpl(lintCtx *linter.Context) ([]govetAPI.Issue, error) {
+	govetMu.Lock()
+	defer govetMu.Unlock()
+
+	settings := &lintCtx.Settings().Govet
+	if err := configureAnalyzers(settings); err != nil {
+		return nil, err
+	}
+
 	// TODO: check .S asm files: govet can do it if pass dirs
 	var govetIssues []govetAPI.Issue
 	for _, pkg := range lintCtx.Program.InitialPackages() {
@@ -55,7 +84,7 @@ func (g Govet) runImpl(lintCtx *linter.Context) ([]govetAPI.Issue, error) {
 		}
 
 		issues, err := govetAPI.Analyze(pkg.Files, lintCtx.Program.Fset, pkg,
-			lintCtx.Settings().Govet.CheckShadowing, getPath)
+			settings.IsAnalyzerEnabled("shadow"), getPath)
 		if err != nil {
 			return nil, err
 		}
@@ -152,5 +152,13 @@ func runMegacheck(program *loader.Program, ssaProg *ssa.Program, conf *loader.Co
 	}
 
 	fs := lintutil.FlagSet(megacheckName)
+	// the target Go version is taken from release tags of the build context of the run:
+	// by default megacheck takes it from the global build context
+	if conf.Build != nil && len(conf.Build.ReleaseTags) != 0 {
+		tags := conf.Build.ReleaseTags
+		if err := fs.Set("go", strings.TrimPrefix(tags[len(tags)-1], "go")); err != nil {
+			panic(fmt.Sprintf("can't set megacheck go version: %s", err))
+		}
+	}
 	return lintutil.ProcessFlagSet(checkers, fs, program, ssaProg, conf)
 }
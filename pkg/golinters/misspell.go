@@ -51,6 +51,7 @@ func (lint Misspell) Run(ctx context.Context, lintCtx *linter.Context) ([]result
 		}
 
 		_, diffs := r.ReplaceGo(string(fileContent))
+		lineOffsets := getLineOffsets(fileContent)
 		for _, diff := range diffs {
 			text := fmt.Sprintf("`%s` is a misspelling of `%s`", diff.Original, diff.Corrected)
 			pos := token.Position{
@@ -62,6 +63,11 @@ func (lint Misspell) Run(ctx context.Context, lintCtx *linter.Context) ([]result
 				Pos:        pos,
 				Text:       text,
 				FromLinter: lint.Name(),
+				Replacement: &result.Replacement{
+					Offset:  lineOffsets[diff.Line-1] + diff.Column,
+					Length:  len(diff.Original),
+					NewText: diff.Corrected,
+				},
 			})
 		}
 	}
@@ -0,0 +1,85 @@
+package golinters
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// NolintlintName is a name of the linter: bare //nolint directives don't suppress its issues
+const NolintlintName = "nolintlint"
+
+type Nolintlint struct{}
+
+func (Nolintlint) Name() string {
+	return NolintlintName
+}
+
+func (Nolintlint) Desc() string {
+	return "Reports nolint directives without explanation"
+}
+
+func (lint Nolintlint) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
+	minLength := lintCtx.Settings().Nolintlint.MinExplanationLength
+
+	var res []result.Issue
+	for _, f := range lintCtx.ASTCache.GetAllValidFiles() {
+		res = append(res, lint.checkFile(f.F, f.Fset, minLength)...)
+	}
+
+	return res, nil
+}
+
+func (lint Nolintlint) checkFile(f *ast.File, fset *token.FileSet, minLength int) []result.Issue {
+	var res []result.Issue
+	for _, g := range f.Comments {
+		for _, c := range g.List {
+			if !strings.HasPrefix(c.Text, "//") {
+				continue
+			}
+
+			text := strings.TrimPrefix(c.Text, "//")
+			if !isNolintDirective(text) {
+				continue
+			}
+
+			// explanation is a comment after the directive: //nolint:golint // explanation
+			directive, explanation := text, ""
+			if idx := strings.Index(text, "//"); idx != -1 {
+				directive, explanation = text[:idx], strings.TrimSpace(text[idx+2:])
+			}
+			if len(explanation) >= minLength {
+				continue
+			}
+
+			directive = "//" + strings.TrimSpace(directive)
+			res = append(res, result.Issue{
+				Pos: fset.Position(c.Pos()),
+				Text: fmt.Sprintf("directive %s should have explanation of at least %d chars, e.g. %s",
+					formatCode(directive, nil), minLength, formatCode(directive+" // reason", nil)),
+				FromLinter: lint.Name(),
+			})
+		}
+	}
+
+	return res
+}
+
+// isNolintDirective returns true if comment text without leading "//" is a nolint
+// directive: "nolint" must be followed by ":", a space, "//" or the end of the comment,
+// so prose like "// nolintlint reports..." isn't a directive.
+func isNolintDirective(text string) bool {
+	const directive = "nolint"
+	text = strings.TrimLeft(text, " ")
+	if !strings.HasPrefix(text, directive) {
+		return false
+	}
+
+	rest := text[len(directive):]
+	return rest == "" || strings.HasPrefix(rest, ":") || strings.HasPrefix(rest, " ") || strings.HasPrefix(rest, "//")
+}
@@ -0,0 +1,55 @@
+package golinters
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsNolintDirective(t *testing.T) {
+	cases := map[string]bool{
+		"nolint":                        true,
+		" nolint":                       true,
+		"nolint:errcheck":               true,
+		"nolint // reason":              true,
+		"nolint//reason":                true,
+		"nolint:errcheck,golint":        true,
+		"nolintlint reports directives": false,
+		"nolintfoo":                     false,
+		" lint is disabled":             false,
+		"":                              false,
+	}
+
+	for text, isDirective := range cases {
+		assert.Equal(t, isDirective, isNolintDirective(text), text)
+	}
+}
+
+func TestNolintlintCheckFile(t *testing.T) {
+	const src = `package p
+
+// nolintlint reports directives without explanation
+func f() {} //nolint:golint // it's fine
+
+func g() {} //nolint:golint
+
+func h() {} // nolint
+`
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	assert.NoError(t, err)
+
+	issues := Nolintlint{}.checkFile(f, fset, 1)
+	if assert.Len(t, issues, 2) {
+		assert.Equal(t, 6, issues[0].Line())
+		assert.Equal(t, "directive `//nolint:golint` should have explanation of at least 1 chars, e.g. `//nolint:golint // reason`",
+			issues[0].Text)
+		assert.Equal(t, 8, issues[1].Line())
+		assert.Equal(t, NolintlintName, issues[1].FromLinter)
+	}
+
+	assert.Len(t, Nolintlint{}.checkFile(f, fset, 10), 3)
+}
@@ -1,4 +1,4 @@
-package golinters // nolint:dupl
+package golinters // nolint:dupl // varcheck is run the same way
 
 import (
 	"context"
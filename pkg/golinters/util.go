@@ -1,17 +1,20 @@
 package golinters
 
 import (
+	"context"
 	"fmt"
 	"go/ast"
 	"go/token"
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	gopackages "golang.org/x/tools/go/packages"
 
 	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/result"
 )
 
 func formatCode(code string, _ *config.Config) string {
@@ -89,6 +92,17 @@ func markIdentifiers(s string) string {
 	return s
 }
 
+// getLineOffsets returns byte offsets of starts of lines of the file content
+func getLineOffsets(content []byte) []int {
+	offsets := []int{0}
+	for i, c := range content {
+		if c == '\n' {
+			offsets = append(offsets, i+1)
+		}
+	}
+	return offsets
+}
+
 func getAllFileNames(ctx *linter.Context) []string {
 	var ret []string
 	uniqFiles := map[string]bool{} // files are duplicated for test packages
@@ -123,3 +137,79 @@ func getASTFilesForGoPkg(ctx *linter.Context, pkg *gopackages.Package) ([]*ast.F
 
 	return files, fset, nil
 }
+
+type pkgAnalysisResult struct {
+	issues []result.Issue
+	err    error
+}
+
+// analyzePackages analyzes every package separately. If run.package-timeout is set,
+// analysis of a package exceeding it is abandoned: the package is skipped with a warning.
+// Analysis can't be interrupted, so the abandoned analysis continues in parallel with
+// analysis of next packages and it's waited for before returning: goroutines don't outlive
+// the linter. Analysis is stopped if ctx is done, e.g. on exceeding of the total deadline:
+// then abandoned analyzes aren't waited for. Linters calling it must be configured
+// by WithPackageTimeout.
+func analyzePackages(ctx context.Context, lintCtx *linter.Context, linterName string,
+	analyze func(pkg *gopackages.Package) ([]result.Issue, error)) ([]result.Issue, error) {
+
+	var issues []result.Issue
+	var abandoned []<-chan pkgAnalysisResult
+	for _, pkg := range lintCtx.Packages {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		pkgIssues, abandonedResCh, err := analyzePackage(ctx, lintCtx, linterName, pkg, analyze)
+		if err != nil {
+			return nil, err
+		}
+		if abandonedResCh != nil {
+			abandoned = append(abandoned, abandonedResCh)
+		}
+		issues = append(issues, pkgIssues...)
+	}
+
+	// results of abandoned analyzes are dropped
+	for _, resCh := range abandoned {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-resCh:
+		}
+	}
+
+	return issues, nil
+}
+
+// analyzePackage analyzes the package within run.package-timeout: the channel
+// of the result of the analysis is returned if the analysis was abandoned
+func analyzePackage(ctx context.Context, lintCtx *linter.Context, linterName string, pkg *gopackages.Package,
+	analyze func(pkg *gopackages.Package) ([]result.Issue, error)) ([]result.Issue, <-chan pkgAnalysisResult, error) {
+
+	timeout := lintCtx.Cfg.Run.PackageTimeout
+	if timeout <= 0 {
+		issues, err := analyze(pkg)
+		return issues, nil, err
+	}
+
+	// analysis can't be interrupted: run it in a goroutine to stop waiting for it
+	resCh := make(chan pkgAnalysisResult, 1)
+	go func() {
+		issues, err := analyze(pkg)
+		resCh <- pkgAnalysisResult{issues: issues, err: err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case <-timer.C:
+		lintCtx.Log.Warnf("%s: analysis of package %s exceeded package timeout %s, the package is skipped",
+			linterName, pkg.PkgPath, timeout)
+		return nil, resCh, nil
+	case res := <-resCh:
+		return res.issues, nil, res.err
+	}
+}
@@ -0,0 +1,111 @@
+package golinters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestAnalyzePackagesWithPackageTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log := logutils.NewMockLog(ctrl)
+	log.EXPECT().Warnf(gomock.Any(), "golint", "slow", 10*time.Millisecond)
+
+	cfg := config.NewDefault()
+	cfg.Run.PackageTimeout = 10 * time.Millisecond
+	lintCtx := &linter.Context{
+		Packages: []*packages.Package{{PkgPath: "a"}, {PkgPath: "slow"}, {PkgPath: "b"}},
+		Cfg:      cfg,
+		Log:      log,
+	}
+
+	release := make(chan struct{})
+	slowFinished := make(chan struct{})
+	analyzed := make(chan string, len(lintCtx.Packages))
+	go func() {
+		// the slow package is released only after the next package was analyzed
+		for pkg := range analyzed {
+			if pkg == "b" {
+				close(release)
+				return
+			}
+		}
+	}()
+	issues, err := analyzePackages(context.Background(), lintCtx, "golint",
+		func(pkg *packages.Package) ([]result.Issue, error) {
+			if pkg.PkgPath == "slow" {
+				<-release
+				close(slowFinished)
+			}
+			analyzed <- pkg.PkgPath
+			return []result.Issue{{Text: pkg.PkgPath}}, nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, []result.Issue{{Text: "a"}, {Text: "b"}}, issues)
+
+	// the abandoned analysis was waited for
+	select {
+	case <-slowFinished:
+	default:
+		t.Error("analysis of the skipped package is still running")
+	}
+}
+
+func TestAnalyzePackagesDoesNotWaitAbandonedOnDeadline(t *testing.T) {
+	cfg := config.NewDefault()
+	cfg.Run.PackageTimeout = time.Millisecond
+	lintCtx := &linter.Context{
+		Packages: []*packages.Package{{PkgPath: "slow"}},
+		Cfg:      cfg,
+		Log:      logutils.NewStderrLog(""),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done := make(chan struct{})
+	defer close(done)
+	_, err := analyzePackages(ctx, lintCtx, "golint", func(pkg *packages.Package) ([]result.Issue, error) {
+		<-done
+		return nil, nil
+	})
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestAnalyzePackagesStopsOnDeadline(t *testing.T) {
+	cfg := config.NewDefault()
+	lintCtx := &linter.Context{
+		Packages: []*packages.Package{{PkgPath: "a"}, {PkgPath: "b"}},
+		Cfg:      cfg,
+		Log:      logutils.NewStderrLog(""),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var analyzed []string
+	_, err := analyzePackages(ctx, lintCtx, "golint", func(pkg *packages.Package) ([]result.Issue, error) {
+		analyzed = append(analyzed, pkg.PkgPath)
+		cancel() // deadline is exceeded during analysis of the first package
+		return nil, nil
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, []string{"a"}, analyzed)
+
+	// with package timeout
+	cfg.Run.PackageTimeout = time.Minute
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = analyzePackages(ctx, lintCtx, "golint", func(pkg *packages.Package) ([]result.Issue, error) {
+		return nil, nil
+	})
+	assert.Equal(t, context.Canceled, err)
+}
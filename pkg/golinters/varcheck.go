@@ -1,4 +1,4 @@
-package golinters // nolint:dupl
+package golinters // nolint:dupl // structcheck is run the same way
 
 import (
 	"context"
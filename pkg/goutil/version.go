@@ -0,0 +1,37 @@
+package goutil
+
+import (
+	"fmt"
+	"go/build"
+	"regexp"
+	"strconv"
+)
+
+var goVersionRe = regexp.MustCompile(`^1\.([1-9][0-9]*)$`)
+
+// defaultReleaseTags are release tags of the running toolchain
+var defaultReleaseTags = append([]string{}, build.Default.ReleaseTags...)
+
+// ReleaseTags returns build release tags go1.1, ..., go1.N of the Go version
+// in format 1.N or release tags of the running toolchain if version is empty.
+func ReleaseTags(version string) ([]string, error) {
+	if version == "" {
+		return append([]string{}, defaultReleaseTags...), nil
+	}
+
+	m := goVersionRe.FindStringSubmatch(version)
+	if m == nil {
+		return nil, fmt.Errorf("invalid Go version %q: it must be in format 1.N, e.g. 1.21", version)
+	}
+
+	minor, err := strconv.Atoi(m[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid Go version %q: %s", version, err)
+	}
+
+	tags := make([]string, 0, minor)
+	for i := 1; i <= minor; i++ {
+		tags = append(tags, fmt.Sprintf("go1.%d", i))
+	}
+	return tags, nil
+}
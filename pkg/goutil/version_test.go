@@ -0,0 +1,23 @@
+package goutil
+
+import (
+	"go/build"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseTags(t *testing.T) {
+	tags, err := ReleaseTags("")
+	assert.NoError(t, err)
+	assert.Equal(t, build.Default.ReleaseTags, tags)
+
+	tags, err = ReleaseTags("1.3")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"go1.1", "go1.2", "go1.3"}, tags)
+
+	for _, v := range []string{"go1.21", "1.21.3", "2.0", "1.", "1.0", "1.x"} {
+		_, err = ReleaseTags(v)
+		assert.EqualError(t, err, `invalid Go version "`+v+`": it must be in format 1.N, e.g. 1.21`)
+	}
+}
@@ -0,0 +1,371 @@
+package issuecache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+var debugf = logutils.Debug("issues_cache")
+
+// DefaultMaxSize is the size of issues entries after which least recently used ones are removed.
+const DefaultMaxSize = 256 << 20
+
+// crossPackageLinters report issues depending on all analyzed packages, not only on a package
+// and its dependencies: their entries are keyed also by the set of analyzed packages.
+var crossPackageLinters = map[string]bool{
+	"dupl":      true,
+	"megacheck": true,
+	"unparam":   true,
+	"unused":    true,
+}
+
+// Cache stores issues of linters on disk per package between runs. Entry of a package
+// is keyed by contents of its files, modification times of files of its dependencies,
+// golangci-lint binary, config and enabled linters: an entry is reused if the package is analyzed
+// along with other packages. Issues of a linter are reused only if entries of all analyzed
+// packages are found.
+type Cache struct {
+	dir     string
+	maxSize int64
+	log     logutils.Log
+
+	salt      []byte                       // hash of everything except packages
+	pkgsHash  string                       // hash of the set of analyzed packages
+	pkgs      []*packages.Package          // analyzed packages
+	pkgKeys   map[*packages.Package]string // cache keys of analyzed packages without linter name
+	filesPkgs map[string]*packages.Package // file path to analyzed package
+	depHashes map[*packages.Package][]byte // memoized hashes of dependencies
+}
+
+func New(dir string, maxSize int64, log logutils.Log) *Cache {
+	return &Cache{
+		dir:     dir,
+		maxSize: maxSize,
+		log:     log,
+	}
+}
+
+// Prepare computes cache keys of packages: it must be called before Get and Put.
+func (c *Cache) Prepare(cfg *config.Config, pkgs []*packages.Package, enabledLinters []string) error {
+	h := sha256.New()
+	if err := writeBinaryID(h); err != nil {
+		return err
+	}
+
+	sortedLinters := append([]string{}, enabledLinters...)
+	sort.Strings(sortedLinters)
+	fmt.Fprintf(h, "linters %v\n", sortedLinters)
+	fmt.Fprint(h, "settings ")
+	writeValue(h, reflect.ValueOf(cfg.LintersSettings))
+	fmt.Fprintln(h)
+	fmt.Fprintf(h, "tests %t, tags %v, modules download mode %q, go %q\n",
+		cfg.Run.AnalyzeTests, cfg.Run.BuildTags, cfg.Run.ModulesDownloadMode, cfg.Run.GoVersion)
+
+	pkgIDs := make([]string, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		pkgIDs = append(pkgIDs, pkg.ID)
+	}
+	sort.Strings(pkgIDs)
+	c.salt = h.Sum(nil)
+	c.pkgsHash = hashString(fmt.Sprintf("%x packages %v", c.salt, pkgIDs))
+
+	c.pkgs = pkgs
+	c.pkgKeys = map[*packages.Package]string{}
+	c.filesPkgs = map[string]*packages.Package{}
+	c.depHashes = map[*packages.Package][]byte{}
+	for _, pkg := range pkgs {
+		key, err := c.packageKey(pkg)
+		if err != nil {
+			return err
+		}
+		c.pkgKeys[pkg] = key
+
+		for _, f := range pkg.CompiledGoFiles {
+			c.filesPkgs[f] = pkg
+		}
+	}
+
+	c.trim()
+	return nil
+}
+
+func hashString(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
+
+// writeValue writes the value like fmt %v does but with sorted keys of maps:
+// fmt prints maps in random order before go1.12.
+func writeValue(h io.Writer, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			fmt.Fprint(h, "nil ")
+			return
+		}
+		writeValue(h, v.Elem())
+	case reflect.Struct:
+		fmt.Fprint(h, "{")
+		for i := 0; i < v.NumField(); i++ {
+			fmt.Fprintf(h, "%s:", v.Type().Field(i).Name)
+			writeValue(h, v.Field(i))
+		}
+		fmt.Fprint(h, "} ")
+	case reflect.Slice, reflect.Array:
+		fmt.Fprint(h, "[")
+		for i := 0; i < v.Len(); i++ {
+			writeValue(h, v.Index(i))
+		}
+		fmt.Fprint(h, "] ")
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		fmt.Fprint(h, "map[")
+		for _, k := range keys {
+			fmt.Fprintf(h, "%v:", k)
+			writeValue(h, v.MapIndex(k))
+		}
+		fmt.Fprint(h, "] ")
+	default:
+		fmt.Fprintf(h, "%v ", v)
+	}
+}
+
+// writeBinaryID writes identity of the running golangci-lint binary:
+// cache is invalidated if the binary is updated.
+func writeBinaryID(h io.Writer) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("can't get executable path: %s", err)
+	}
+
+	fi, err := os.Stat(exe)
+	if err != nil {
+		return fmt.Errorf("can't stat executable: %s", err)
+	}
+
+	fmt.Fprintf(h, "binary %s %d %d %s\n", exe, fi.Size(), fi.ModTime().UnixNano(), runtime.Version())
+	return nil
+}
+
+func (c *Cache) packageKey(pkg *packages.Package) (string, error) {
+	h := sha256.New()
+	h.Write(c.salt) //nolint:errcheck // hash.Hash.Write never returns an error
+	fmt.Fprintf(h, "package %s\n", pkg.ID)
+
+	for _, f := range pkg.CompiledGoFiles {
+		if err := writeFileContentsHash(h, f); err != nil {
+			return "", err
+		}
+	}
+
+	if err := c.writeImportsHash(h, pkg); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func writeFileContentsHash(h io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("can't open file for hashing: %s", err)
+	}
+	defer f.Close()
+
+	fmt.Fprintf(h, "file %s\n", path)
+	if _, err = io.Copy(h, f); err != nil {
+		return fmt.Errorf("can't read file %s for hashing: %s", path, err)
+	}
+
+	return nil
+}
+
+func (c *Cache) writeImportsHash(h io.Writer, pkg *packages.Package) error {
+	importPaths := make([]string, 0, len(pkg.Imports))
+	for path := range pkg.Imports {
+		importPaths = append(importPaths, path)
+	}
+	sort.Strings(importPaths)
+
+	for _, path := range importPaths {
+		depHash, err := c.dependencyHash(pkg.Imports[path])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(h, "import %s %x\n", path, depHash)
+	}
+
+	return nil
+}
+
+// dependencyHash hashes only modification times and sizes of files of dependencies:
+// reading of all files of all dependencies including standard library is too slow.
+func (c *Cache) dependencyHash(pkg *packages.Package) ([]byte, error) {
+	if depHash, ok := c.depHashes[pkg]; ok {
+		return depHash, nil
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "package %s\n", pkg.ID)
+	for _, f := range pkg.CompiledGoFiles {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return nil, fmt.Errorf("can't stat file for hashing: %s", err)
+		}
+		fmt.Fprintf(h, "file %s %d %d\n", f, fi.Size(), fi.ModTime().UnixNano())
+	}
+
+	c.depHashes[pkg] = nil // break import cycles of broken packages
+	if err := c.writeImportsHash(h, pkg); err != nil {
+		return nil, err
+	}
+
+	depHash := h.Sum(nil)
+	c.depHashes[pkg] = depHash
+	return depHash, nil
+}
+
+func (c *Cache) entryPath(linterName string, pkg *packages.Package) string {
+	key := c.pkgKeys[pkg]
+	if crossPackageLinters[linterName] {
+		key = hashString(key + c.pkgsHash)
+	}
+
+	return filepath.Join(c.issuesDir(), linterName, key[:2], key+".json")
+}
+
+func (c *Cache) issuesDir() string {
+	return filepath.Join(c.dir, "issues")
+}
+
+// Get returns cached issues of the linter: ok is false if issues of
+// at least one package aren't cached.
+func (c *Cache) Get(linterName string) (issues []result.Issue, ok bool) {
+	for _, pkg := range c.pkgs {
+		path := c.entryPath(linterName, pkg)
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			debugf("%s: no entry for package %s: %s", linterName, pkg.ID, err)
+			return nil, false
+		}
+
+		// mark entry as recently used to not remove it by trim
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+
+		var pkgIssues []result.Issue
+		if err = json.Unmarshal(data, &pkgIssues); err != nil {
+			c.log.Warnf("Invalid issues cache entry of linter %s for package %s: %s", linterName, pkg.ID, err)
+			return nil, false
+		}
+		issues = append(issues, pkgIssues...)
+	}
+
+	return issues, true
+}
+
+// Put saves issues of the linter for every analyzed package. Nothing is saved
+// if some issues can't be attributed to analyzed packages.
+func (c *Cache) Put(linterName string, issues []result.Issue) {
+	pkgIssues := map[*packages.Package][]result.Issue{}
+	for _, i := range issues {
+		pkg := c.filesPkgs[i.FilePath()]
+		if pkg == nil {
+			debugf("%s: don't cache issues: no package for file %s", linterName, i.FilePath())
+			return
+		}
+		pkgIssues[pkg] = append(pkgIssues[pkg], i)
+	}
+
+	for _, pkg := range c.pkgs {
+		entryIssues := pkgIssues[pkg]
+		if entryIssues == nil {
+			entryIssues = []result.Issue{}
+		}
+
+		path := c.entryPath(linterName, pkg)
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			c.log.Warnf("Can't create issues cache dir: %s", err)
+			return
+		}
+
+		err := fsutils.WriteFileAtomically(path, func(w io.Writer) error {
+			return json.NewEncoder(w).Encode(entryIssues)
+		})
+		if err != nil {
+			c.log.Warnf("Can't save issues cache entry: %s", err)
+			return
+		}
+	}
+}
+
+type entryInfo struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// trim removes least recently used entries while total size of entries exceeds the max size.
+func (c *Cache) trim() {
+	var entries []entryInfo
+	var totalSize int64
+	err := filepath.Walk(c.issuesDir(), func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		// temp files of entries being written by concurrent runs aren't removed
+		if fi.Mode().IsRegular() && filepath.Ext(path) == ".json" {
+			entries = append(entries, entryInfo{path: path, size: fi.Size(), modTime: fi.ModTime()})
+			totalSize += fi.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		c.log.Warnf("Can't walk issues cache dir: %s", err)
+		return
+	}
+
+	if totalSize <= c.maxSize {
+		return
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.Before(entries[j].modTime)
+	})
+	removedN := 0
+	for _, e := range entries {
+		if totalSize <= c.maxSize {
+			break
+		}
+		if err := os.Remove(e.path); err != nil {
+			c.log.Warnf("Can't remove issues cache entry: %s", err)
+			return
+		}
+		totalSize -= e.size
+		removedN++
+	}
+	debugf("Removed %d least recently used entries", removedN)
+}
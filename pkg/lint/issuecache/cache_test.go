@@ -0,0 +1,132 @@
+package issuecache
+
+import (
+	"bytes"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci-lint-issuecache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	depFile := filepath.Join(dir, "dep.go")
+	assert.NoError(t, ioutil.WriteFile(depFile, []byte("package dep\n"), os.ModePerm))
+	pkgFile := filepath.Join(dir, "a.go")
+	assert.NoError(t, ioutil.WriteFile(pkgFile, []byte("package a\n"), os.ModePerm))
+
+	dep := &packages.Package{ID: "dep", CompiledGoFiles: []string{depFile}}
+	pkg := &packages.Package{
+		ID:              "a",
+		CompiledGoFiles: []string{pkgFile},
+		Imports:         map[string]*packages.Package{"dep": dep},
+	}
+	pkgs := []*packages.Package{pkg}
+
+	cfg := config.NewDefault()
+	newPreparedCache := func(linters ...string) *Cache {
+		c := New(filepath.Join(dir, "cache"), DefaultMaxSize, logutils.NewStderrLog(""))
+		assert.NoError(t, c.Prepare(cfg, pkgs, linters))
+		return c
+	}
+
+	issues := []result.Issue{{
+		FromLinter: "golint",
+		Text:       "text",
+		Pos:        token.Position{Filename: pkgFile, Line: 1},
+	}}
+
+	c := newPreparedCache("golint")
+	_, ok := c.Get("golint")
+	assert.False(t, ok)
+
+	c.Put("golint", issues)
+	cachedIssues, ok := c.Get("golint")
+	assert.True(t, ok)
+	assert.Equal(t, issues, cachedIssues)
+
+	// entry of the package is reused when it's analyzed without another package,
+	// but not by cross-package linters
+	pkgs = []*packages.Package{pkg, dep}
+	c = newPreparedCache("golint")
+	c.Put("golint", issues)
+	c.Put("dupl", issues)
+	pkgs = []*packages.Package{pkg}
+	c = newPreparedCache("golint")
+	cachedIssues, ok = c.Get("golint")
+	assert.True(t, ok)
+	assert.Equal(t, issues, cachedIssues)
+	_, ok = c.Get("dupl")
+	assert.False(t, ok)
+
+	// another set of enabled linters
+	_, ok = newPreparedCache("golint", "govet").Get("golint")
+	assert.False(t, ok)
+
+	// issue from unknown file isn't cached
+	c.Put("govet", []result.Issue{{Pos: token.Position{Filename: "b.go"}}})
+	_, ok = c.Get("govet")
+	assert.False(t, ok)
+
+	// package file was changed
+	assert.NoError(t, ioutil.WriteFile(pkgFile, []byte("package a // changed\n"), os.ModePerm))
+	_, ok = newPreparedCache("golint").Get("golint")
+	assert.False(t, ok)
+}
+
+func TestCacheSettingsHashIsStable(t *testing.T) {
+	cfg := config.NewDefault()
+	cfg.LintersSettings.Custom = map[string]config.CustomLinterSettings{}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		cfg.LintersSettings.Custom[name] = config.CustomLinterSettings{Path: name + ".so"}
+	}
+
+	var expected string
+	for i := 0; i < 10; i++ {
+		var buf bytes.Buffer
+		writeValue(&buf, reflect.ValueOf(cfg.LintersSettings))
+		if i == 0 {
+			expected = buf.String()
+			continue
+		}
+		assert.Equal(t, expected, buf.String())
+	}
+}
+
+func TestCacheTrim(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci-lint-issuecache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	c := New(dir, 16, logutils.NewStderrLog(""))
+	var paths []string
+	for i, name := range []string{"old", "used", "new"} {
+		path := filepath.Join(c.issuesDir(), "golint", name+".json")
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		assert.NoError(t, ioutil.WriteFile(path, []byte("[ 1, 2 ]"), os.ModePerm))
+		modTime := time.Now().Add(time.Duration(i-3) * time.Hour)
+		assert.NoError(t, os.Chtimes(path, modTime, modTime))
+		paths = append(paths, path)
+	}
+
+	c.trim()
+	_, err = os.Stat(paths[0])
+	assert.True(t, os.IsNotExist(err))
+	for _, path := range paths[1:] {
+		_, err = os.Stat(path)
+		assert.NoError(t, err)
+	}
+}
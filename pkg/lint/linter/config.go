@@ -16,6 +16,8 @@ type Config struct {
 	NeedsTypeInfo bool
 	NeedsSSARepr  bool
 
+	SupportsPackageTimeout bool // run.package-timeout is honored by the linter
+
 	InPresets        []string
 	Speed            int // more value means faster execution of linter
 	AlternativeNames []string
@@ -34,6 +36,11 @@ func (lc Config) WithSSA() Config {
 	return lc
 }
 
+func (lc Config) WithPackageTimeout() Config {
+	lc.SupportsPackageTimeout = true
+	return lc
+}
+
 func (lc Config) WithPresets(presets ...string) Config {
 	lc.InPresets = presets
 	return lc
@@ -0,0 +1,125 @@
+package lintersdb
+
+import (
+	"fmt"
+	"plugin"
+	"sort"
+	"sync"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+)
+
+// customLinters are linters loaded from plugins by LoadCustomLinters:
+// they are supported by all managers created after loading because plugins
+// can't be unloaded. customLinterPaths maps names of loaded linters to paths
+// of their plugins.
+var (
+	customLintersMu   sync.Mutex
+	customLinters     []linter.Config
+	customLinterPaths = map[string]string{}
+)
+
+func getCustomLinters() []linter.Config {
+	customLintersMu.Lock()
+	defer customLintersMu.Unlock()
+
+	return append([]linter.Config{}, customLinters...)
+}
+
+// NewCustomLinterFunc is the type of function New exported by plugins of custom linters
+type NewCustomLinterFunc = func() (linter.Linter, error)
+
+// customLinter names the linter from the plugin by the key of its settings
+type customLinter struct {
+	linter.Linter
+	name string
+	desc string
+}
+
+func (l customLinter) Name() string {
+	return l.name
+}
+
+func (l customLinter) Desc() string {
+	if l.desc != "" {
+		return l.desc
+	}
+
+	return l.Linter.Desc()
+}
+
+// LoadCustomLinters loads plugins of linters-settings.custom linters and registers
+// them: it must be called before creation of managers used for the run. Linters
+// already loaded from the same plugins are reused, so it can be called by concurrent runs.
+func LoadCustomLinters(settings map[string]config.CustomLinterSettings) error {
+	if len(settings) != 0 && !pluginsSupported {
+		return fmt.Errorf("custom linters can't be loaded: golangci-lint must be built with CGO_ENABLED=1 " +
+			"on linux or darwin to load plugins, release binaries are built without cgo")
+	}
+
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	m := NewManager()
+
+	customLintersMu.Lock()
+	defer customLintersMu.Unlock()
+
+	for _, name := range names {
+		if path, ok := customLinterPaths[name]; ok {
+			if path != settings[name].Path {
+				return fmt.Errorf("custom linter %s: it's already loaded from plugin %s", name, path)
+			}
+			continue
+		}
+
+		if m.GetLinterConfig(name) != nil {
+			return fmt.Errorf("custom linter %s: linter with this name already exists", name)
+		}
+
+		l, err := loadCustomLinter(name, settings[name])
+		if err != nil {
+			return fmt.Errorf("custom linter %s: %s", name, err)
+		}
+
+		customLinterPaths[name] = settings[name].Path
+		customLinters = append(customLinters, linter.NewConfig(l).
+			WithTypeInfo().
+			WithURL(settings[name].OriginalURL))
+	}
+
+	return nil
+}
+
+func loadCustomLinter(name string, settings config.CustomLinterSettings) (linter.Linter, error) {
+	if settings.Path == "" {
+		return nil, fmt.Errorf("path of plugin isn't set")
+	}
+
+	plug, err := plugin.Open(settings.Path)
+	if err != nil {
+		return nil, fmt.Errorf("can't load plugin %s: %s", settings.Path, err)
+	}
+
+	sym, err := plug.Lookup("New")
+	if err != nil {
+		return nil, fmt.Errorf("plugin %s doesn't export function New: %s", settings.Path, err)
+	}
+
+	newLinter, ok := sym.(NewCustomLinterFunc)
+	if !ok {
+		return nil, fmt.Errorf("function New of plugin %s has type %T, expected %T",
+			settings.Path, sym, NewCustomLinterFunc(nil))
+	}
+
+	l, err := newLinter()
+	if err != nil {
+		return nil, fmt.Errorf("can't create linter by plugin %s: %s", settings.Path, err)
+	}
+
+	return customLinter{Linter: l, name: name, desc: settings.Description}, nil
+}
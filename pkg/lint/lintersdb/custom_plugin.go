@@ -0,0 +1,8 @@
+//go:build (cgo && linux) || (cgo && darwin)
+// +build cgo,linux cgo,darwin
+
+package lintersdb
+
+// pluginsSupported is true if Go plugins can be loaded by this binary:
+// package plugin requires cgo and is implemented only for linux and darwin
+const pluginsSupported = true
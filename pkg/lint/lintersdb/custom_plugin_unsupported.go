@@ -0,0 +1,7 @@
+//go:build !cgo || (!linux && !darwin)
+// +build !cgo !linux,!darwin
+
+package lintersdb
+
+// pluginsSupported is false for binaries built without cgo, e.g. for releases
+const pluginsSupported = false
@@ -0,0 +1,65 @@
+package lintersdb
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+)
+
+func TestLoadCustomLinters(t *testing.T) {
+	defer func() {
+		customLinters = nil
+		customLinterPaths = map[string]string{}
+	}()
+
+	if !pluginsSupported {
+		err := LoadCustomLinters(map[string]config.CustomLinterSettings{"example": {Path: "example.so"}})
+		assert.Contains(t, err.Error(), "must be built with CGO_ENABLED=1")
+		return
+	}
+
+	dir, err := ioutil.TempDir("", "golangci-lint-plugin")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	pluginPath := filepath.Join(dir, "example.so")
+	out, err := exec.Command("go", "build", "-buildmode=plugin", "-o", pluginPath,
+		"./testdata/plugin").CombinedOutput()
+	require.NoError(t, err, "can't build plugin: %s", out)
+
+	err = LoadCustomLinters(map[string]config.CustomLinterSettings{
+		"custom_example": {Path: pluginPath, Description: "Custom example"},
+	})
+	require.NoError(t, err)
+
+	lc := NewManager().GetLinterConfig("custom_example")
+	require.NotNil(t, lc)
+	assert.Equal(t, "custom_example", lc.Name())
+	assert.Equal(t, "Custom example", lc.Linter.Desc())
+	issues, err := lc.Linter.Run(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Len(t, issues, 1)
+
+	// linter from the same plugin is reused
+	err = LoadCustomLinters(map[string]config.CustomLinterSettings{"custom_example": {Path: pluginPath}})
+	assert.NoError(t, err)
+	assert.Len(t, customLinters, 1)
+
+	// errors of loading
+	err = LoadCustomLinters(map[string]config.CustomLinterSettings{"custom_example": {Path: "another.so"}})
+	assert.Contains(t, err.Error(), "it's already loaded from plugin "+pluginPath)
+	err = LoadCustomLinters(map[string]config.CustomLinterSettings{"govet": {Path: pluginPath}})
+	assert.Contains(t, err.Error(), "linter with this name already exists")
+	err = LoadCustomLinters(map[string]config.CustomLinterSettings{"no_path": {}})
+	assert.Contains(t, err.Error(), "path of plugin isn't set")
+	err = LoadCustomLinters(map[string]config.CustomLinterSettings{"not_existing": {Path: "not_existing.so"}})
+	assert.Contains(t, err.Error(), "can't load plugin not_existing.so")
+}
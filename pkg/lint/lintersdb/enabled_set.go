@@ -1,6 +1,7 @@
 package lintersdb
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/golangci/golangci-lint/pkg/config"
@@ -25,7 +26,7 @@ func NewEnabledSet(m *Manager, v *Validator, log logutils.Log, cfg *config.Confi
 	}
 }
 
-// nolint:gocyclo
+// nolint:gocyclo // linters are enabled and disabled in the order of priority
 func (es EnabledSet) build(lcfg *config.Linters, enabledByDefaultLinters []linter.Config) map[string]*linter.Config {
 	resultLintersSet := map[string]*linter.Config{}
 	switch {
@@ -80,6 +81,24 @@ func (es EnabledSet) build(lcfg *config.Linters, enabledByDefaultLinters []linte
 	return resultLintersSet
 }
 
+// buildExplicit builds linters set only from explicitly enabled linters:
+// default, preset, --enable-all and --fast options don't affect it.
+func (es EnabledSet) buildExplicit(lcfg *config.Linters) map[string]*linter.Config {
+	resultLintersSet := map[string]*linter.Config{}
+	for _, name := range lcfg.Enable {
+		lc := es.m.GetLinterConfig(name)
+		resultLintersSet[lc.Name()] = lc
+	}
+
+	for _, name := range lcfg.Disable {
+		lc := es.m.GetLinterConfig(name)
+		delete(resultLintersSet, lc.Name())
+	}
+
+	es.optimizeLintersSet(resultLintersSet)
+	return resultLintersSet
+}
+
 func getAllMegacheckSubLinterNames() []string {
 	unusedName := golinters.Megacheck{UnusedEnabled: true}.Name()
 	gosimpleName := golinters.Megacheck{GosimpleEnabled: true}.Name()
@@ -122,11 +141,30 @@ func (es EnabledSet) optimizeLintersSet(linters map[string]*linter.Config) {
 }
 
 func (es EnabledSet) Get() ([]linter.Config, error) {
+	// presets from config and from command-line can be repeated
+	es.cfg.Linters.Presets = uniqueStrings(es.cfg.Linters.Presets)
+
 	if err := es.v.validateEnabledDisabledLintersConfig(&es.cfg.Linters); err != nil {
 		return nil, err
 	}
 
-	resultLintersSet := es.build(&es.cfg.Linters, es.m.GetAllEnabledByDefaultLinters())
+	var resultLintersSet map[string]*linter.Config
+	if es.cfg.Run.OnlyExplicitLinters {
+		resultLintersSet = es.buildExplicit(&es.cfg.Linters)
+		if len(resultLintersSet) == 0 {
+			return nil, fmt.Errorf("only explicitly enabled linters are allowed, but no one linter was enabled")
+		}
+	} else {
+		resultLintersSet = es.build(&es.cfg.Linters, es.m.GetAllEnabledByDefaultLinters())
+	}
+
+	if len(resultLintersSet) == 0 {
+		// e.g. presets or --fast with disabled linters left nothing to run
+		if es.cfg.Linters.FailIfNoLinters {
+			return nil, fmt.Errorf("no linters enabled: check your config/presets")
+		}
+		es.log.Warnf("No linters enabled: check your config/presets")
+	}
 
 	var resultLinters []linter.Config
 	for _, lc := range resultLintersSet {
@@ -137,6 +175,19 @@ func (es EnabledSet) Get() ([]linter.Config, error) {
 	return resultLinters, nil
 }
 
+func uniqueStrings(ss []string) []string {
+	var ret []string
+	seen := map[string]bool{}
+	for _, s := range ss {
+		if !seen[s] {
+			seen[s] = true
+			ret = append(ret, s)
+		}
+	}
+
+	return ret
+}
+
 func (es EnabledSet) verbosePrintLintersStatus(lcs []linter.Config) {
 	var linterNames []string
 	for _, lc := range lcs {
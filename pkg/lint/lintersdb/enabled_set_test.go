@@ -8,6 +8,7 @@ import (
 
 	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/logutils"
 )
 
 func TestGetEnabledLintersSet(t *testing.T) {
@@ -103,3 +104,44 @@ func TestGetEnabledLintersSet(t *testing.T) {
 		})
 	}
 }
+
+func TestGetExplicitlyEnabledLintersSet(t *testing.T) {
+	m := NewManager()
+	es := NewEnabledSet(m, NewValidator(m), nil, nil)
+
+	cfg := config.Linters{
+		Enable:  []string{"gas", "govet", "golint"},
+		Disable: []string{"golint"},
+		Presets: []string{"bugs"},
+		Fast:    true,
+	}
+	els := es.buildExplicit(&cfg)
+
+	var enabledLinters []string
+	for ln := range els {
+		enabledLinters = append(enabledLinters, ln)
+	}
+	sort.Strings(enabledLinters)
+
+	assert.Equal(t, []string{"gosec", "govet"}, enabledLinters)
+}
+
+func TestGetNoLinters(t *testing.T) {
+	m := NewManager()
+	cfg := &config.Config{
+		Linters: config.Linters{
+			Presets: []string{"unused"},
+			Fast:    true,
+			Disable: []string{"structcheck", "varcheck", "ineffassign", "deadcode"},
+		},
+	}
+	es := NewEnabledSet(m, NewValidator(m), logutils.NewStderrLog("test"), cfg)
+
+	lcs, err := es.Get()
+	assert.NoError(t, err)
+	assert.Empty(t, lcs)
+
+	cfg.Linters.FailIfNoLinters = true
+	_, err = es.Get()
+	assert.EqualError(t, err, "no linters enabled: check your config/presets")
+}
@@ -2,6 +2,7 @@ package lintersdb
 
 import (
 	"os"
+	"strings"
 
 	"github.com/golangci/golangci-lint/pkg/golinters"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
@@ -46,6 +47,17 @@ func (m Manager) GetLinterConfig(name string) *linter.Config {
 	return &lc
 }
 
+// GetAllLinterNames returns all names of supported linters including
+// alternative ones mapped to their canonical names
+func (m Manager) GetAllLinterNames() map[string]string {
+	ret := map[string]string{}
+	for name, lc := range m.nameToLC {
+		ret[name] = lc.Name()
+	}
+
+	return ret
+}
+
 func enableLinterConfigs(lcs []linter.Config, isEnabled func(lc *linter.Config) bool) []linter.Config {
 	var ret []linter.Config
 	for _, lc := range lcs {
@@ -70,6 +82,7 @@ func (Manager) GetAllSupportedLinterConfigs() []linter.Config {
 			WithSpeed(10).
 			WithURL("https://github.com/kisielk/errcheck"),
 		linter.NewConfig(golinters.Golint{}).
+			WithPackageTimeout().
 			WithPresets(linter.PresetStyle).
 			WithSpeed(3).
 			WithURL("https://github.com/golang/lint"),
@@ -125,6 +138,7 @@ func (Manager) GetAllSupportedLinterConfigs() []linter.Config {
 			WithSpeed(7).
 			WithURL("https://github.com/mibk/dupl"),
 		linter.NewConfig(golinters.Goconst{}).
+			WithPackageTimeout().
 			WithPresets(linter.PresetStyle).
 			WithSpeed(9).
 			WithURL("https://github.com/jgautheron/goconst"),
@@ -196,6 +210,10 @@ func (Manager) GetAllSupportedLinterConfigs() []linter.Config {
 			WithSpeed(5).
 			WithTypeInfo().
 			WithURL("https://github.com/go-critic/go-critic"),
+		linter.NewConfig(golinters.Nolintlint{}).
+			WithPresets(linter.PresetStyle).
+			WithSpeed(10).
+			WithURL("https://github.com/golangci/golangci-lint"),
 		linter.NewConfig(golinters.Gochecknoinits{}).
 			WithPresets(linter.PresetStyle).
 			WithSpeed(10).
@@ -221,9 +239,10 @@ func (Manager) GetAllSupportedLinterConfigs() []linter.Config {
 		// don't typecheck for golangci.com: too many troubles
 		golinters.TypeCheck{}.Name(): isLocalRun,
 	}
-	return enableLinterConfigs(lcs, func(lc *linter.Config) bool {
+	lcs = enableLinterConfigs(lcs, func(lc *linter.Config) bool {
 		return enabled[lc.Name()]
 	})
+	return append(lcs, getCustomLinters()...)
 }
 
 func (m Manager) GetAllEnabledByDefaultLinters() []linter.Config {
@@ -260,3 +279,32 @@ func (m Manager) GetAllLinterConfigsForPreset(p string) []linter.Config {
 
 	return ret
 }
+
+// IsLinterInPresets reports whether the linter with the given name is in one of the presets.
+// The name can be a name of partially enabled megacheck, e.g. megacheck.{unused,gosimple}:
+// such linter is in a preset if any of its sublinters is in it.
+func (m Manager) IsLinterInPresets(name string, presets []string) bool {
+	var lcs []linter.Config
+	if lc := m.GetLinterConfig(name); lc != nil {
+		lcs = append(lcs, *lc)
+	} else if strings.HasPrefix(name, "megacheck.{") {
+		subNames := strings.TrimSuffix(strings.TrimPrefix(name, "megacheck.{"), "}")
+		for _, subName := range strings.Split(subNames, ",") {
+			if lc := m.GetLinterConfig(subName); lc != nil {
+				lcs = append(lcs, *lc)
+			}
+		}
+	}
+
+	for _, lc := range lcs {
+		for _, ip := range lc.InPresets {
+			for _, p := range presets {
+				if p == ip {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,29 @@
+package lintersdb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+)
+
+func TestIsLinterInPresets(t *testing.T) {
+	m := NewManager()
+
+	assert.True(t, m.IsLinterInPresets("govet", []string{linter.PresetBugs}))
+	assert.False(t, m.IsLinterInPresets("govet", []string{linter.PresetStyle}))
+	assert.True(t, m.IsLinterInPresets("gas", []string{linter.PresetBugs})) // alias
+	assert.True(t, m.IsLinterInPresets("megacheck.{unused,gosimple}", []string{linter.PresetUnused}))
+	assert.False(t, m.IsLinterInPresets("megacheck.{unused,gosimple}", []string{linter.PresetBugs}))
+	assert.False(t, m.IsLinterInPresets("no_such_linter", []string{linter.PresetBugs}))
+}
+
+func TestGetAllLinterNames(t *testing.T) {
+	names := NewManager().GetAllLinterNames()
+
+	assert.Equal(t, "gosec", names["gas"])
+	assert.Equal(t, "gosec", names["gosec"])
+	assert.Equal(t, "staticcheck", names["staticcheck"])
+	assert.NotContains(t, names, "no_such_linter")
+}
@@ -0,0 +1,29 @@
+// Package main is a plugin of a custom linter for tests of LoadCustomLinters
+package main
+
+import (
+	"context"
+
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+type example struct{}
+
+func (example) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
+	return []result.Issue{{Text: "example issue"}}, nil
+}
+
+func (example) Name() string {
+	return "example"
+}
+
+func (example) Desc() string {
+	return "Example linter"
+}
+
+func New() (linter.Linter, error) {
+	return example{}, nil
+}
+
+func main() {}
@@ -51,13 +51,10 @@ func (v Validator) validateAllDisableEnableOptions(cfg *config.Linters) error {
 	}
 
 	if cfg.DisableAll {
+		// --disable is allowed: e.g. it can disable a linter enabled in config with disable-all
 		if len(cfg.Enable) == 0 && len(cfg.Presets) == 0 {
 			return fmt.Errorf("all linters were disabled, but no one linter was enabled: must enable at least one")
 		}
-
-		if len(cfg.Disable) != 0 {
-			return fmt.Errorf("can't combine options --disable-all and --disable %s", cfg.Disable[0])
-		}
 	}
 
 	if cfg.EnableAll && len(cfg.Enable) != 0 && !cfg.Fast {
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"go/build"
 	"go/types"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/golangci/tools/go/ssa"
@@ -19,6 +21,7 @@ import (
 
 	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/exitcodes"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/goutil"
 	"github.com/golangci/golangci-lint/pkg/lint/astcache"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
@@ -44,17 +47,38 @@ func NewContextLoader(cfg *config.Config, log logutils.Log, goenv *goutil.Env) *
 	}
 }
 
-func (cl ContextLoader) prepareBuildContext() {
-	// Set GOROOT to have working cross-compilation: cross-compiled binaries
-	// have invalid GOROOT. XXX: can't use runtime.GOROOT().
-	goroot := cl.goenv.Get("GOROOT")
-	if goroot == "" {
-		return
+// setGorootOnce guards the global GOROOT: it's the same for all runs and is set
+// only once before the first loading, so linters of concurrent runs can read it
+var setGorootOnce sync.Once
+
+// setGoroot sets GOROOT to have working cross-compilation: cross-compiled binaries
+// have invalid GOROOT. XXX: can't use runtime.GOROOT().
+func (cl ContextLoader) setGoroot() {
+	setGorootOnce.Do(func() {
+		goroot := cl.goenv.Get("GOROOT")
+		if goroot == "" {
+			return
+		}
+
+		os.Setenv("GOROOT", goroot)
+		build.Default.GOROOT = goroot
+	})
+}
+
+// buildContext returns the build context of the run: it's passed to linters
+// instead of changing the global build context shared by concurrent runs
+func (cl ContextLoader) buildContext() (*build.Context, error) {
+	ctxt := build.Default
+	ctxt.BuildTags = cl.cfg.Run.BuildTags
+
+	// release tags select the target Go version of linters using the build context
+	releaseTags, err := goutil.ReleaseTags(cl.cfg.Run.GoVersion)
+	if err != nil {
+		return nil, err
 	}
+	ctxt.ReleaseTags = releaseTags
 
-	os.Setenv("GOROOT", goroot)
-	build.Default.GOROOT = goroot
-	build.Default.BuildTags = cl.cfg.Run.BuildTags
+	return &ctxt, nil
 }
 
 func (cl ContextLoader) makeFakeLoaderPackageInfo(pkg *packages.Package) *loader.PackageInfo {
@@ -180,9 +204,14 @@ func (cl ContextLoader) buildArgs() []string {
 		return []string{"./..."}
 	}
 
+	if cl.cfg.Run.PackagesFromStdin {
+		// packages from stdin are usually import paths from 'go list': load them as is
+		return args
+	}
+
 	var retArgs []string
 	for _, arg := range args {
-		if strings.HasPrefix(arg, ".") {
+		if strings.HasPrefix(arg, ".") || filepath.IsAbs(arg) || isImportPath(arg) {
 			retArgs = append(retArgs, arg)
 		} else {
 			// go/packages doesn't work well if we don't have prefix ./ for local packages
@@ -193,40 +222,206 @@ func (cl ContextLoader) buildArgs() []string {
 	return retArgs
 }
 
-func (cl ContextLoader) loadPackages(ctx context.Context, loadMode packages.LoadMode) ([]*packages.Package, error) {
-	defer func(startedAt time.Time) {
-		cl.log.Infof("Go packages loading at mode %s took %s", stringifyLoadMode(loadMode), time.Since(startedAt))
-	}(time.Now())
+// isImportPath returns true if the arg is an import path or pattern of a module
+// or GOPATH package, e.g. github.com/org/repo/pkg/...: the first element
+// of such paths contains a dot and there is no such local dir
+func isImportPath(arg string) bool {
+	arg = filepath.ToSlash(arg)
+	if !strings.Contains(strings.SplitN(arg, "/", 2)[0], ".") {
+		return false
+	}
 
-	cl.prepareBuildContext()
+	fi, err := os.Stat(filepath.FromSlash(strings.TrimSuffix(arg, "/...")))
+	return err != nil || !fi.IsDir()
+}
+
+// expandArgsRespectingGitignore expands local patterns with "..." to dirs of packages
+// which aren't ignored by .gitignore files: the go command would descend into ignored dirs.
+// Patterns of dirs outside of git repositories are kept as is.
+func (cl ContextLoader) expandArgsRespectingGitignore(args []string) ([]string, error) {
+	var retArgs []string
+	for _, arg := range args {
+		if !strings.HasSuffix(filepath.ToSlash(arg), "/...") || isImportPath(arg) {
+			retArgs = append(retArgs, arg)
+			continue
+		}
+
+		baseDir := filepath.FromSlash(strings.TrimSuffix(filepath.ToSlash(arg), "/..."))
+		gitIgnore, err := fsutils.NewGitIgnore(baseDir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't find git repository of %s", baseDir)
+		}
+		if gitIgnore == nil {
+			cl.log.Infof("Dir %s isn't in a git repository: .gitignore files aren't respected for it", baseDir)
+			retArgs = append(retArgs, arg)
+			continue
+		}
+
+		dirs, err := cl.findPackageDirs(baseDir, gitIgnore)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't expand pattern %s", arg)
+		}
+		retArgs = append(retArgs, dirs...)
+	}
+
+	if len(retArgs) == 0 {
+		return nil, errors.Wrapf(exitcodes.ErrNoGoFiles, "patterns %s matched no packages not ignored by .gitignore",
+			strings.Join(args, " "))
+	}
+
+	return retArgs, nil
+}
+
+// findPackageDirs finds dirs with go files like the go command does for "..." patterns:
+// dirs starting with . or _, testdata and vendor dirs, nested modules and symlinks are skipped.
+// Subtrees without ignored dirs and files are returned as "dir/..." patterns: a full list
+// of dirs of a big repository can exceed the limit of command-line length of 'go list'.
+func (cl ContextLoader) findPackageDirs(baseDir string, gitIgnore *fsutils.GitIgnore) ([]string, error) {
+	dirs, _, err := cl.findSubtreePackageDirs(filepath.Clean(baseDir), gitIgnore)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, dir := range dirs {
+		if !strings.HasPrefix(dir, ".") && !filepath.IsAbs(dir) {
+			// go/packages doesn't work well if we don't have prefix ./ for local packages
+			dirs[i] = fmt.Sprintf(".%c%s", filepath.Separator, dir)
+		}
+	}
+
+	return dirs, nil
+}
+
+// findSubtreePackageDirs returns package dirs of the subtree of dir and whether
+// the go command would find the same packages by the pattern "dir/...".
+func (cl ContextLoader) findSubtreePackageDirs(dir string, gitIgnore *fsutils.GitIgnore) ([]string, bool, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, false, err
+	}
+
+	complete := true
+	hasGoFiles := false
+	var subDirs []string
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if !entry.IsDir() {
+			if filepath.Ext(path) == ".go" {
+				ignored := gitIgnore.IsIgnored(path, false)
+				complete = complete && !ignored
+				hasGoFiles = hasGoFiles || !ignored
+			}
+			continue
+		}
+
+		subDirDirs, subDirComplete, subDirErr := cl.findSubDirPackageDirs(path, gitIgnore)
+		if subDirErr != nil {
+			return nil, false, subDirErr
+		}
+		complete = complete && subDirComplete
+		subDirs = append(subDirs, subDirDirs...)
+	}
+
+	return subtreePackageDirs(dir, hasGoFiles, subDirs, complete), complete, nil
+}
 
+// findSubDirPackageDirs is findSubtreePackageDirs for a subdir: dirs skipped by the
+// go command, nested modules and dirs ignored by .gitignore have no packages to analyze.
+func (cl ContextLoader) findSubDirPackageDirs(path string, gitIgnore *fsutils.GitIgnore) ([]string, bool, error) {
+	name := filepath.Base(path)
+	if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor" {
+		return nil, true, nil // the go command skips them too
+	}
+	if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+		return nil, false, nil
+	}
+	if gitIgnore.IsIgnored(path, true) {
+		cl.debugf("Skip dir %s ignored by .gitignore", path)
+		return nil, false, nil
+	}
+
+	return cl.findSubtreePackageDirs(path, gitIgnore)
+}
+
+// subtreePackageDirs returns the pattern "dir/..." for the complete subtree of dir,
+// otherwise it returns dir if it has go files and package dirs of its subdirs
+func subtreePackageDirs(dir string, hasGoFiles bool, subDirs []string, complete bool) []string {
+	if complete {
+		if !hasGoFiles && len(subDirs) == 0 {
+			return nil
+		}
+		return []string{dir + string(filepath.Separator) + "..."}
+	}
+
+	var dirs []string
+	if hasGoFiles {
+		dirs = append(dirs, dir)
+	}
+	return append(dirs, subDirs...)
+}
+
+func (cl ContextLoader) buildFlags() []string {
 	var buildFlags []string
 	if len(cl.cfg.Run.BuildTags) != 0 {
 		// go help build
 		buildFlags = []string{"-tags", strings.Join(cl.cfg.Run.BuildTags, " ")}
 	}
+	if cl.cfg.Run.ModulesDownloadMode != "" {
+		if cl.isModuleMode() {
+			// go help modules: -mod flag overrides -mod from GOFLAGS
+			buildFlags = append(buildFlags, "-mod="+cl.cfg.Run.ModulesDownloadMode)
+		} else {
+			// go list fails with -mod in GOPATH mode and before Go 1.11
+			cl.log.Infof("Modules download mode %s is ignored: not in module mode", cl.cfg.Run.ModulesDownloadMode)
+		}
+	}
+
+	return buildFlags
+}
+
+func (cl ContextLoader) debugPrintLoadedPackages(pkgs []*packages.Package) {
+	cl.debugf("loaded %d pkgs", len(pkgs))
+	for i, pkg := range pkgs {
+		var syntaxFiles []string
+		for _, sf := range pkg.Syntax {
+			syntaxFiles = append(syntaxFiles, pkg.Fset.Position(sf.Pos()).Filename)
+		}
+		cl.debugf("Loaded pkg #%d: ID=%s GoFiles=%s CompiledGoFiles=%s Syntax=%s",
+			i, pkg.ID, pkg.GoFiles, pkg.CompiledGoFiles, syntaxFiles)
+	}
+}
+
+func (cl ContextLoader) loadPackages(ctx context.Context, loadMode packages.LoadMode) ([]*packages.Package, error) {
+	defer func(startedAt time.Time) {
+		cl.log.Infof("Go packages loading at mode %s took %s", stringifyLoadMode(loadMode), time.Since(startedAt))
+	}(time.Now())
+
+	cl.setGoroot()
+
 	conf := &packages.Config{
 		Mode:       loadMode,
-		Tests:      cl.cfg.Run.AnalyzeTests,
+		Tests:      cl.cfg.ShouldLoadTests(),
 		Context:    ctx,
-		BuildFlags: buildFlags,
-		//TODO: use fset, parsefile, overlay
+		BuildFlags: cl.buildFlags(),
+		Overlay:    cl.cfg.Run.Overlay,
+		//TODO: use fset, parsefile
 	}
 
 	args := cl.buildArgs()
+	if cl.cfg.Run.RespectGitignore && !cl.cfg.Run.PackagesFromStdin {
+		var err error
+		if args, err = cl.expandArgsRespectingGitignore(args); err != nil {
+			return nil, err
+		}
+	}
 	cl.debugf("Built loader args are %s", args)
 	pkgs, err := packages.Load(conf, args...)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to load program with go/packages")
 	}
-	cl.debugf("loaded %d pkgs", len(pkgs))
-	for i, pkg := range pkgs {
-		var syntaxFiles []string
-		for _, sf := range pkg.Syntax {
-			syntaxFiles = append(syntaxFiles, pkg.Fset.Position(sf.Pos()).Filename)
-		}
-		cl.debugf("Loaded pkg #%d: ID=%s GoFiles=%s CompiledGoFiles=%s Syntax=%s",
-			i, pkg.ID, pkg.GoFiles, pkg.CompiledGoFiles, syntaxFiles)
+	cl.debugPrintLoadedPackages(pkgs)
+	if len(pkgs) == 0 {
+		return nil, errors.Wrapf(exitcodes.ErrNoGoFiles, "patterns %s matched no packages", strings.Join(args, " "))
 	}
 
 	for _, pkg := range pkgs {
@@ -240,6 +435,12 @@ func (cl ContextLoader) loadPackages(ctx context.Context, loadMode packages.Load
 	return cl.filterPackages(pkgs), nil
 }
 
+// ListPackages returns packages to analyze with their files without loading
+// their syntax and types
+func (cl ContextLoader) ListPackages(ctx context.Context) ([]*packages.Package, error) {
+	return cl.loadPackages(ctx, packages.LoadFiles)
+}
+
 func (cl ContextLoader) tryParseTestPackage(pkg *packages.Package) (name, testName string, isTest bool) {
 	matches := cl.pkgTestIDRe.FindStringSubmatch(pkg.ID)
 	if matches == nil {
@@ -286,8 +487,13 @@ func (cl ContextLoader) filterPackages(pkgs []*packages.Package) []*packages.Pac
 	return retPkgs
 }
 
-//nolint:gocyclo
+//nolint:gocyclo // steps of loading depend on each other
 func (cl ContextLoader) Load(ctx context.Context, linters []linter.Config) (*linter.Context, error) {
+	buildContext, err := cl.buildContext()
+	if err != nil {
+		return nil, err
+	}
+
 	loadMode := cl.findLoadMode(linters)
 	pkgs, err := cl.loadPackages(ctx, loadMode)
 	if err != nil {
@@ -321,8 +527,8 @@ func (cl ContextLoader) Load(ctx context.Context, linters []linter.Config) (*lin
 		SSAProgram:          ssaProg,
 		MegacheckSSAProgram: megacheckSSAProg,
 		LoaderConfig: &loader.Config{
-			Cwd:   "",  // used by depguard and fallbacked to os.Getcwd
-			Build: nil, // used by depguard and megacheck and fallbacked to build.Default
+			Cwd:   "",           // used by depguard and fallbacked to os.Getcwd
+			Build: buildContext, // used by depguard and megacheck
 		},
 		Cfg:      cl.cfg,
 		ASTCache: astCache,
@@ -356,3 +562,10 @@ func saveNotCompilingPackages(lintCtx *linter.Context) {
 		lintCtx.Log.Infof("Packages that do not compile: %+v", lintCtx.NotCompilingPackages)
 	}
 }
+
+// isModuleMode returns true if the go command works in module mode:
+// GOMOD is empty in GOPATH mode and os.DevNull without go.mod.
+func (cl ContextLoader) isModuleMode() bool {
+	gomod := cl.goenv.Get("GOMOD")
+	return gomod != "" && gomod != os.DevNull
+}
@@ -0,0 +1,83 @@
+package lint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func TestBuildArgs(t *testing.T) {
+	cfg := &config.Config{}
+	cl := ContextLoader{cfg: cfg}
+	assert.Equal(t, []string{"./..."}, cl.buildArgs())
+
+	cfg.Run.Args = []string{"pkg", "./cmd", "github.com/org/repo/pkg/..."}
+	assert.Equal(t, []string{"." + string(filepath.Separator) + "pkg", "./cmd", "github.com/org/repo/pkg/..."},
+		cl.buildArgs())
+
+	// packages from stdin are import paths from 'go list': they are loaded as is
+	cfg.Run.PackagesFromStdin = true
+	cfg.Run.Args = []string{"pkg", "github.com/org/repo/pkg/a"}
+	assert.Equal(t, []string{"pkg", "github.com/org/repo/pkg/a"}, cl.buildArgs())
+}
+
+func TestFindPackageDirs(t *testing.T) {
+	root, err := ioutil.TempDir("", "golangci-lint-load")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	files := map[string]string{
+		".gitignore":        "build/\n*_gen.go\n",
+		"a.go":              "package a",
+		"pkg/b.go":          "package pkg",
+		"pkg/sub/c.go":      "package sub",
+		"build/gen.go":      "package build", // ignored dir
+		"gen/x_gen.go":      "package gen",   // ignored file
+		"gen/sub/d.go":      "package sub",
+		"mod/go.mod":        "module mod", // nested module
+		"mod/e.go":          "package mod",
+		"empty/README":      "",
+		"testdata/f.go":     "package testdata",
+		"_skipped/g.go":     "package skipped",
+		"pkg/sub/README.md": "",
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git"), os.ModePerm))
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), os.ModePerm))
+	}
+
+	gitIgnore, err := fsutils.NewGitIgnore(root)
+	require.NoError(t, err)
+	cl := NewContextLoader(&config.Config{}, logutils.NewStderrLog(""), nil)
+
+	// subtrees without ignored dirs and files are passed to 'go list' as patterns
+	dirs, err := cl.findPackageDirs(root, gitIgnore)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		root,
+		filepath.Join(root, "gen", "sub", "..."),
+		filepath.Join(root, "pkg", "..."),
+	}, dirs)
+
+	// the pattern is passed as is if nothing is ignored
+	dirs, err = cl.findPackageDirs(filepath.Join(root, "pkg"), gitIgnore)
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(root, "pkg", "...")}, dirs)
+
+	dirs, err = cl.findPackageDirs(filepath.Join(root, "empty"), gitIgnore)
+	require.NoError(t, err)
+	assert.Empty(t, dirs)
+
+	_, err = cl.findPackageDirs(filepath.Join(root, "not-existing"), gitIgnore)
+	assert.Error(t, err)
+}
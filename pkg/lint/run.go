@@ -0,0 +1,154 @@
+package lint
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/pkg/errors"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/goutil"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// Run runs linters enabled by cfg on packages by paths and returns found issues:
+// packages are loaded and issues are processed like by the run command, but issues
+// aren't printed, baseline and snapshot files aren't written and errors are returned
+// instead of exiting. Linters are stopped when ctx is done. cfg isn't modified,
+// so concurrent runs can share it. Use config.NewDefault to get the config
+// of the run command without config files and flags.
+func Run(ctx context.Context, cfg *config.Config, paths []string) ([]result.Issue, error) {
+	return RunWithLog(ctx, cfg, paths, logutils.NewStderrLog(""))
+}
+
+// RunWithLog is Run logging to log: e.g. an editor integration can pass a log
+// which doesn't write to stderr.
+func RunWithLog(ctx context.Context, cfg *config.Config, paths []string, log logutils.Log) ([]result.Issue, error) {
+	a, err := NewAnalysis(ctx, cfg, paths, log, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = a.Load(ctx); err != nil {
+		return nil, err
+	}
+
+	var issues []result.Issue
+	for i := range a.Issues(ctx) {
+		issues = append(issues, i)
+	}
+
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	return issues, nil
+}
+
+// Analysis is a run of linters on packages: Run and the run command create it by
+// NewAnalysis, load packages by Load and get found issues by Issues.
+type Analysis struct {
+	Cfg     *config.Config  // copy of the config of the run
+	Linters []linter.Config // enabled linters
+
+	// set by Load
+	Context *linter.Context
+	Runner  *Runner
+
+	log           logutils.Log
+	goenv         *goutil.Env
+	contextLoader *ContextLoader
+}
+
+// NewAnalysis deeply copies cfg with paths as arguments, loads custom linters and
+// selects enabled linters: cfg isn't modified. goenv is discovered if it's nil.
+func NewAnalysis(ctx context.Context, cfg *config.Config, paths []string, log logutils.Log,
+	goenv *goutil.Env) (*Analysis, error) {
+
+	runCfg := cfg.Copy()
+	runCfg.Run.Args = paths
+	if runCfg.Run.Concurrency < 1 {
+		runCfg.Run.Concurrency = runtime.NumCPU()
+	}
+
+	if _, err := goutil.ReleaseTags(runCfg.Run.GoVersion); err != nil {
+		return nil, err
+	}
+
+	if goenv == nil {
+		goenv = goutil.NewEnv(log.Child("goenv"))
+		if err := goenv.Discover(ctx); err != nil {
+			log.Warnf("Failed to discover go env: %s", err)
+		}
+	}
+
+	if err := lintersdb.LoadCustomLinters(runCfg.LintersSettings.Custom); err != nil {
+		return nil, err
+	}
+
+	dbManager := lintersdb.NewManager()
+	enabledLinters, err := lintersdb.NewEnabledSet(dbManager, lintersdb.NewValidator(dbManager),
+		log.Child("lintersdb"), runCfg).Get()
+	if err != nil {
+		return nil, err
+	}
+
+	warnIfPackageTimeoutIsIgnored(runCfg, enabledLinters, log)
+
+	return &Analysis{
+		Cfg:           runCfg,
+		Linters:       enabledLinters,
+		log:           log,
+		goenv:         goenv,
+		contextLoader: NewContextLoader(runCfg, log.Child("loader"), goenv),
+	}, nil
+}
+
+// warnIfPackageTimeoutIsIgnored warns if run.package-timeout is set,
+// but none of enabled linters supports it
+func warnIfPackageTimeoutIsIgnored(cfg *config.Config, enabledLinters []linter.Config, log logutils.Log) {
+	if cfg.Run.PackageTimeout <= 0 {
+		return
+	}
+
+	for _, lc := range enabledLinters {
+		if lc.SupportsPackageTimeout {
+			return
+		}
+	}
+
+	log.Warnf("Package timeout %s is ignored: none of enabled linters supports it", cfg.Run.PackageTimeout)
+}
+
+// ListPackages lists packages to analyze without loading them.
+func (a *Analysis) ListPackages(ctx context.Context) ([]*packages.Package, error) {
+	return a.contextLoader.ListPackages(ctx)
+}
+
+// Load loads packages for enabled linters and creates the runner of them.
+func (a *Analysis) Load(ctx context.Context) error {
+	lintCtx, err := a.contextLoader.Load(ctx, a.Linters)
+	if err != nil {
+		return errors.Wrap(err, "context loading failed")
+	}
+	lintCtx.Log = a.log.Child("linters context")
+
+	runner, err := NewRunner(lintCtx.ASTCache, a.Cfg, a.log.Child("runner"), a.goenv)
+	if err != nil {
+		return err
+	}
+
+	a.Context = lintCtx
+	a.Runner = runner
+	return nil
+}
+
+// Issues runs enabled linters on loaded packages and returns processed issues:
+// the runner can be queried about the run after the channel was drained.
+func (a *Analysis) Issues(ctx context.Context) <-chan result.Issue {
+	return a.Runner.Run(ctx, a.Linters, a.Context)
+}
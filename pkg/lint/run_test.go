@@ -0,0 +1,38 @@
+package lint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func TestWarnIfPackageTimeoutIsIgnored(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := lintersdb.NewManager()
+	getLinters := func(names ...string) []linter.Config {
+		var ret []linter.Config
+		for _, name := range names {
+			ret = append(ret, *m.GetLinterConfig(name))
+		}
+		return ret
+	}
+
+	cfg := config.NewDefault()
+	log := logutils.NewMockLog(ctrl)
+	warnIfPackageTimeoutIsIgnored(cfg, getLinters("govet"), log) // package timeout isn't set
+
+	cfg.Run.PackageTimeout = time.Second
+	warnIfPackageTimeoutIsIgnored(cfg, getLinters("govet", "golint"), log)
+	warnIfPackageTimeoutIsIgnored(cfg, getLinters("goconst"), log)
+
+	log.EXPECT().Warnf("Package timeout %s is ignored: none of enabled linters supports it", time.Second)
+	warnIfPackageTimeoutIsIgnored(cfg, getLinters("govet", "errcheck"), log)
+}
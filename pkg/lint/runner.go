@@ -3,6 +3,8 @@ package lint
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"runtime/debug"
 	"sort"
 	"strings"
@@ -10,8 +12,10 @@ import (
 	"time"
 
 	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/goutil"
 	"github.com/golangci/golangci-lint/pkg/lint/astcache"
+	"github.com/golangci/golangci-lint/pkg/lint/issuecache"
 	"github.com/golangci/golangci-lint/pkg/lint/linter"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/packages"
@@ -23,55 +27,491 @@ import (
 type Runner struct {
 	Processors []processors.Processor
 	Log        logutils.Log
+
+	issuesCache *issuecache.Cache // nil if caching is disabled
+	progress    *lintersProgress
+	sortResults *processors.SortResults
+	fixer       *processors.Fixer
+
+	stopOnTypecheckErrors bool
+}
+
+// lintersProgress tracks linters which finished before the context was done,
+// linters which failed to run, wall-clock times of linters and analyzed files
+type lintersProgress struct {
+	mu       sync.Mutex
+	linters  []string
+	finished map[string]bool
+	failed   []string
+	times    map[string]time.Duration
+	files    map[string]bool // slash-separated paths relative to the current dir like in issues
+}
+
+func (p *lintersProgress) start(linters []linter.Config, files []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, lc := range linters {
+		p.linters = append(p.linters, lc.Name())
+	}
+
+	p.files = map[string]bool{}
+	for _, f := range files {
+		if rel, err := fsutils.ShortestRelPath(f, ""); err == nil {
+			f = rel
+		}
+		p.files[filepath.ToSlash(f)] = true
+	}
+}
+
+// analyzed reports whether the linter successfully analyzed the file
+func (p *lintersProgress) analyzed(path, linterName string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.finished[linterName] || !p.files[filepath.ToSlash(path)] {
+		return false
+	}
+
+	for _, name := range p.failed {
+		if name == linterName {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *lintersProgress) markFinished(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.finished[name] = true
+}
+
+func (p *lintersProgress) addTime(name string, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.times[name] += d
+}
+
+// LinterTime is the wall-clock time of analysis by the linter
+type LinterTime struct {
+	Name     string
+	Duration time.Duration
+}
+
+func (p *lintersProgress) linterTimes() []LinterTime {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ret := make([]LinterTime, 0, len(p.times))
+	for name, d := range p.times {
+		ret = append(ret, LinterTime{Name: name, Duration: d})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Duration != ret[j].Duration {
+			return ret[i].Duration > ret[j].Duration
+		}
+		return ret[i].Name < ret[j].Name
+	})
+	return ret
+}
+
+func (p *lintersProgress) markFailed(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.failed = append(p.failed, name)
+}
+
+func (p *lintersProgress) failedLinters() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ret := append([]string{}, p.failed...)
+	sort.Strings(ret)
+	return ret
+}
+
+func (p *lintersProgress) unfinished() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var ret []string
+	for _, name := range p.linters {
+		if !p.finished[name] {
+			ret = append(ret, name)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// getBaselinePath returns the path of the baseline file or of the snapshot file
+// in --only-new mode: a missing snapshot file means there are no old issues
+func getBaselinePath(icfg *config.Issues, log logutils.Log) string {
+	if !icfg.OnlyNew {
+		return icfg.Baseline
+	}
+
+	if _, err := os.Stat(icfg.Snapshot); os.IsNotExist(err) {
+		log.Warnf("Snapshot file %s doesn't exist: all issues are new", icfg.Snapshot)
+		return ""
+	}
+
+	return icfg.Snapshot
 }
 
 func NewRunner(astCache *astcache.Cache, cfg *config.Config, log logutils.Log, goenv *goutil.Env) (*Runner, error) {
 	icfg := cfg.Issues
-	excludePatterns := icfg.ExcludePatterns
-	if icfg.UseDefaultExcludes {
-		excludePatterns = append(excludePatterns, config.GetDefaultExcludePatternsStrings()...)
+	autogenDiskCache, issuesCache, err := newDiskCaches(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	excludingProcessors, err := newExcludingProcessors(astCache, cfg, autogenDiskCache, log, goenv)
+	if err != nil {
+		return nil, err
 	}
 
-	var excludeTotalPattern string
-	if len(excludePatterns) != 0 {
-		excludeTotalPattern = fmt.Sprintf("(%s)", strings.Join(excludePatterns, "|"))
+	uniqProcessor, err := newUniqProcessor(&icfg, log)
+	if err != nil {
+		return nil, err
 	}
 
-	skipFilesProcessor, err := processors.NewSkipFiles(cfg.Run.SkipFiles)
+	diffProcessor, err := newDiffProcessor(&icfg)
 	if err != nil {
 		return nil, err
 	}
 
-	skipDirs := append([]string{}, packages.StdExcludeDirRegexps...)
-	skipDirs = append(skipDirs, cfg.Run.SkipDirs...)
-	skipDirsProcessor, err := processors.NewSkipDirs(skipDirs, log.Child("skip dirs"), cfg.Run.Args)
+	baselineProcessor, err := newBaselineProcessor(&icfg, log)
 	if err != nil {
 		return nil, err
 	}
 
+	pathAbsolutizer, err := newPathAbsolutizer(cfg.Output.PathMode)
+	if err != nil {
+		return nil, err
+	}
+
+	categoriesProcessor, err := processors.NewCategories(cfg.Output.Categories)
+	if err != nil {
+		return nil, err
+	}
+
+	severityProcessor, err := newSeverityProcessor(&cfg.Severity)
+	if err != nil {
+		return nil, err
+	}
+
+	fixer := processors.NewFixer(icfg.NeedFix, log.Child("fixer"))
+
 	return &Runner{
-		Processors: []processors.Processor{
-			processors.NewPathPrettifier(), // must be before diff, nolint and exclude autogenerated processor at least
-			processors.NewCgo(goenv),
-			skipFilesProcessor,
-			skipDirsProcessor,
-
-			processors.NewAutogeneratedExclude(astCache),
-			processors.NewExclude(excludeTotalPattern),
-			processors.NewNolint(astCache, log.Child("nolint")),
-
-			processors.NewUniqByLine(),
-			processors.NewDiff(icfg.Diff, icfg.DiffFromRevision, icfg.DiffPatchFilePath),
+		Processors: append(excludingProcessors,
+			processors.NewFingerprint(cfg.Run.Overlay), // must be before baseline processor
+			uniqProcessor, // must be before limiting processors to not count duplicates
+			diffProcessor,
+			baselineProcessor, // must be before limiting processors to record all issues
 			processors.NewMaxPerFileFromLinter(),
 			processors.NewMaxSameIssues(icfg.MaxSameIssues, log.Child("max_same_issues")),
 			processors.NewMaxFromLinter(icfg.MaxIssuesPerLinter, log.Child("max_from_linter")),
-			processors.NewSourceCode(log.Child("source_code")),
+			categoriesProcessor,
+			processors.NewTestFileMarker(),
+			severityProcessor,
+			fixer, // must be after filtering processors
+			processors.NewInferColumn(cfg.Output.InferColumn, astCache, log.Child("infer_column")),
+			processors.NewSourceCode(cfg.Output.SourceContextLines, cfg.Run.Overlay, log.Child("source_code")),
 			processors.NewPathShortener(),
-		},
-		Log: log,
+			pathAbsolutizer,
+			processors.NewPathPrefixStripper(cfg.Output.StripPrefix), // must be after source code processor
+			processors.NewPathPrefixAdder(cfg.Output.PathPrefix),
+		),
+		Log:         log,
+		issuesCache: issuesCache,
+		progress:    &lintersProgress{finished: map[string]bool{}, times: map[string]time.Duration{}},
+		sortResults: processors.NewSortResults(cfg.Output.SortResults),
+		fixer:       fixer,
+
+		stopOnTypecheckErrors: cfg.Run.StopOnTypecheckErrors,
+	}, nil
+}
+
+// newDiskCaches creates caches of autogenerated files and of issues enabled by options:
+// they are nil if caching is disabled
+func newDiskCaches(cfg *config.Config, log logutils.Log) (*processors.AutogenDiskCache, *issuecache.Cache, error) {
+	if cfg.Run.NoCache || (!cfg.Run.CacheAutogen && !cfg.Run.CacheIssues) {
+		return nil, nil, nil
+	}
+
+	cacheDir, err := cfg.GetCacheDir()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = fsutils.InitCacheDir(cacheDir); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		autogenDiskCache *processors.AutogenDiskCache
+		issuesCache      *issuecache.Cache
+	)
+	if cfg.Run.CacheAutogen {
+		autogenDiskCache = processors.NewAutogenDiskCache(
+			filepath.Join(cacheDir, "autogen.json"), log.Child("autogen_cache"))
+	}
+	if cfg.Run.CacheIssues {
+		issuesCache = issuecache.New(cacheDir, issuecache.DefaultMaxSize, log.Child("issues_cache"))
+	}
+
+	return autogenDiskCache, issuesCache, nil
+}
+
+// newExcludingProcessors creates processors which run first: they prettify paths and
+// exclude issues of skipped and autogenerated files, by exclude options and by nolint
+// directives
+func newExcludingProcessors(astCache *astcache.Cache, cfg *config.Config, autogenDiskCache *processors.AutogenDiskCache,
+	log logutils.Log, goenv *goutil.Env) ([]processors.Processor, error) {
+
+	skipFilesProcessor, err := processors.NewSkipFiles(cfg.Run.SkipFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	skipDirsProcessor, err := newSkipDirsProcessor(&cfg.Run, log)
+	if err != nil {
+		return nil, err
+	}
+
+	autogeneratedExcludeProcessor, err := newAutogeneratedExcludeProcessor(astCache, &cfg.Issues, autogenDiskCache, log)
+	if err != nil {
+		return nil, err
+	}
+
+	excludePatterns, err := GetExcludePatterns(cfg)
+	if err != nil {
+		return nil, err
+	}
+	excludeProcessor, err := processors.NewExclude(excludePatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	includeRulesProcessor, err := processors.NewIncludeRules(GetIncludeRules(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	excludeRulesProcessor, err := processors.NewExcludeRules(GetExcludeRules(cfg), cfg.Run.Overlay, log.Child("exclude_rules"))
+	if err != nil {
+		return nil, err
+	}
+
+	icfg := &cfg.Issues
+	nolintProcessor, err := processors.NewNolint(astCache, log.Child("nolint"), cfg.Output.ShowSuppressed,
+		icfg.ExcludeAt, icfg.ReportUnusedExcludeAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return []processors.Processor{
+		processors.NewPathPrettifier(), // must be before diff, nolint and exclude autogenerated processor at least
+		processors.NewCgo(goenv),
+		skipFilesProcessor,
+		skipDirsProcessor,
+		processors.NewOnlyFiles(cfg.Run.OnlyFiles),
+		processors.NewLinterTests(cfg.Run.AnalyzeTests, cfg.LintersSettings.Tests),
+
+		autogeneratedExcludeProcessor,
+		excludeProcessor,
+		includeRulesProcessor,
+		excludeRulesProcessor,
+		nolintProcessor,
+		processors.NewStructTagExclude(icfg.ExcludeStructTags, astCache),
 	}, nil
 }
 
+func newSkipDirsProcessor(rcfg *config.Run, log logutils.Log) (*processors.SkipDirs, error) {
+	var skipDirs []string
+	if rcfg.UseDefaultSkipDirs {
+		skipDirs = append(skipDirs, packages.StdExcludeDirRegexps...)
+	}
+	skipDirs = append(skipDirs, rcfg.SkipDirs...)
+
+	return processors.NewSkipDirs(skipDirs, log.Child("skip dirs"), rcfg.Args)
+}
+
+func newAutogeneratedExcludeProcessor(astCache *astcache.Cache, icfg *config.Issues,
+	diskCache *processors.AutogenDiskCache, log logutils.Log) (*processors.AutogeneratedExclude, error) {
+
+	var strictAutogen bool
+	switch icfg.AutogenMode {
+	case "", config.AutogenModeLax:
+	case config.AutogenModeStrict:
+		strictAutogen = true
+	default:
+		return nil, fmt.Errorf("invalid issues.autogen-mode value %q: allowed are %s, %s",
+			icfg.AutogenMode, config.AutogenModeLax, config.AutogenModeStrict)
+	}
+
+	return processors.NewAutogeneratedExclude(astCache, processors.AutogeneratedExcludeSettings{
+		GeneratedDirs:  icfg.GeneratedDirs,
+		GeneratedFiles: icfg.GeneratedFiles,
+		NeverGenerated: icfg.NeverGenerated,
+		GeneratedTag:   icfg.GeneratedTag,
+		ExtraMarkers:   icfg.AutogenMarkers,
+		Strict:         strictAutogen,
+		ScanFullFile:   icfg.AutogenScanFullFile,
+		DiskCache:      diskCache,
+	}, log.Child("autogenerated_exclude"))
+}
+
+func newUniqProcessor(icfg *config.Issues, log logutils.Log) (processors.Processor, error) {
+	switch icfg.UniqBy {
+	case "", config.UniqByLine:
+		return processors.NewUniqByLine(), nil
+	case config.UniqByText:
+		return processors.NewUniqByText(log.Child("uniq_by_text")), nil
+	}
+
+	return nil, fmt.Errorf("invalid issues.uniq-by value %q: allowed are %s, %s",
+		icfg.UniqBy, config.UniqByLine, config.UniqByText)
+}
+
+func newDiffProcessor(icfg *config.Issues) (*processors.Diff, error) {
+	return processors.NewDiff(icfg.Diff, icfg.DiffFromRevision, icfg.DiffPatchFilePath, icfg.FailOnNewOnly)
+}
+
+func newBaselineProcessor(icfg *config.Issues, log logutils.Log) (*processors.Baseline, error) {
+	recordBaseline := icfg.WriteBaseline != "" || icfg.UpdateSnapshot
+	return processors.NewBaseline(getBaselinePath(icfg, log), recordBaseline, icfg.FailOnNewOnly, log.Child("baseline"))
+}
+
+func newPathAbsolutizer(pathMode string) (*processors.PathAbsolutizer, error) {
+	switch pathMode {
+	case "", config.PathModeRelative, config.PathModeAbsolute:
+	default:
+		return nil, fmt.Errorf("invalid output.path-mode value %q: allowed are %s, %s",
+			pathMode, config.PathModeRelative, config.PathModeAbsolute)
+	}
+
+	return processors.NewPathAbsolutizer(pathMode == config.PathModeAbsolute), nil
+}
+
+func newSeverityProcessor(scfg *config.Severity) (*processors.Severity, error) {
+	var rules []processors.SeverityRule
+	for _, r := range scfg.Rules {
+		rules = append(rules, processors.SeverityRule{
+			Severity: r.Severity,
+			Linters:  r.Linters,
+			Path:     r.Path,
+		})
+	}
+
+	return processors.NewSeverity(scfg.Default, rules)
+}
+
+// GetExcludePatterns returns patterns of issues.exclude option and enabled default excludes
+func GetExcludePatterns(cfg *config.Config) ([]processors.ExcludePattern, error) {
+	patterns, err := cfg.GetExcludePatterns()
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []processors.ExcludePattern
+	for _, p := range patterns {
+		ret = append(ret, processors.ExcludePattern{
+			Pattern: p.Pattern,
+			Linters: p.Linters,
+		})
+	}
+
+	return ret, nil
+}
+
+// GetExcludeRules converts issues.exclude-rules option to rules of the processor
+func GetExcludeRules(cfg *config.Config) []processors.ExcludeRule {
+	var rules []processors.ExcludeRule
+	for _, r := range cfg.Issues.ExcludeRules {
+		rules = append(rules, processors.ExcludeRule{
+			Linters: r.Linters,
+			Path:    r.Path,
+			Text:    r.Text,
+			Source:  r.Source,
+		})
+	}
+
+	return rules
+}
+
+func GetIncludeRules(cfg *config.Config) []processors.IncludeRule {
+	var rules []processors.IncludeRule
+	for _, r := range cfg.Issues.IncludeRules {
+		rules = append(rules, processors.IncludeRule{
+			Linters: r.Linters,
+			Path:    r.Path,
+		})
+	}
+
+	return rules
+}
+
+// SuppressedIssues returns issues hidden by nolint directives: they are
+// collected only if output option show-suppressed is enabled.
+func (r Runner) SuppressedIssues() []result.SuppressedIssue {
+	for _, p := range r.Processors {
+		if nolint, ok := p.(*processors.Nolint); ok {
+			return nolint.SuppressedIssues()
+		}
+	}
+
+	return nil
+}
+
+// Baseline returns baseline processor: it records issues for the baseline
+// file only if option issues.write-baseline is set.
+func (r Runner) Baseline() *processors.Baseline {
+	for _, p := range r.Processors {
+		if baseline, ok := p.(*processors.Baseline); ok {
+			return baseline
+		}
+	}
+
+	return nil
+}
+
+// UnfinishedLinters returns sorted names of linters which weren't
+// finished or weren't started before the context of Run was done.
+func (r Runner) UnfinishedLinters() []string {
+	return r.progress.unfinished()
+}
+
+// FailedLinters returns sorted names of linters which failed to run
+// before the context of Run was done: their issues aren't reported.
+func (r Runner) FailedLinters() []string {
+	return r.progress.failedLinters()
+}
+
+// NotFixedFiles returns sorted files which weren't fixed by --fix because of errors:
+// their fixed issues weren't reported. It must be called after issues of Run were drained.
+func (r Runner) NotFixedFiles() []string {
+	return r.fixer.FailedFiles()
+}
+
+// Analyzed reports whether the linter finished without errors and the file
+// was among files of analyzed packages: all issues of the linter in the file
+// were found then. The path is relative to the current dir like paths of issues.
+func (r Runner) Analyzed(path, linterName string) bool {
+	return r.progress.analyzed(path, linterName)
+}
+
+// LinterTimes returns wall-clock times of run linters sorted from the slowest linter:
+// the times don't include the loading of packages shared by all linters.
+func (r Runner) LinterTimes() []LinterTime {
+	return r.progress.linterTimes()
+}
+
 type lintRes struct {
 	linter linter.Config
 	err    error
@@ -102,6 +542,68 @@ func (r Runner) runLinterSafe(ctx context.Context, lintCtx *linter.Context,
 	return issues, nil
 }
 
+// runLinterCached takes issues of the linter from the cache if packages
+// and config weren't changed, otherwise it runs the linter and caches issues
+func (r Runner) runLinterCached(ctx context.Context, lintCtx *linter.Context,
+	lc linter.Config) ([]result.Issue, error) {
+
+	if r.issuesCache == nil {
+		return r.runLinterSafe(ctx, lintCtx, lc)
+	}
+
+	if issues, ok := r.issuesCache.Get(lc.Name()); ok {
+		r.Log.Infof("Took %d issues of linter %s from cache", len(issues), lc.Name())
+		return issues, nil
+	}
+
+	issues, err := r.runLinterSafe(ctx, lintCtx, lc)
+	if err == nil && ctx.Err() == nil { // don't cache partial results
+		r.issuesCache.Put(lc.Name(), issues)
+	}
+
+	return issues, err
+}
+
+// runLinterWithTimeout runs the linter with its own timeout from linters-settings.<name>.timeout:
+// the linter is abandoned after the timeout even if it doesn't check the context.
+// Most linters don't check it: the abandoned linter keeps running in its goroutine
+// until it finishes, so it's run in parallel with the next linters of the worker
+// and more than run.concurrency linters can use CPU at one moment then.
+func (r Runner) runLinterWithTimeout(ctx context.Context, lintCtx *linter.Context,
+	lc linter.Config) ([]result.Issue, error) {
+
+	timeout := lintCtx.Cfg.LintersSettings.Timeouts[lc.Name()]
+	if timeout <= 0 {
+		return r.runLinterCached(ctx, lintCtx, lc)
+	}
+
+	linterCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type linterResult struct {
+		issues []result.Issue
+		err    error
+	}
+	resCh := make(chan linterResult, 1)
+	go func() {
+		issues, err := r.runLinterCached(linterCtx, lintCtx, lc)
+		resCh <- linterResult{issues: issues, err: err}
+	}()
+
+	select {
+	case res := <-resCh:
+		if linterCtx.Err() != nil && ctx.Err() == nil && res.err == nil {
+			r.Log.Warnf("Timeout %s of linter %s exceeded: its issues can be incomplete", timeout, lc.Name())
+		}
+		return res.issues, res.err
+	case <-linterCtx.Done():
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("timeout %s of the linter exceeded", timeout)
+	}
+}
+
 func (r Runner) runWorker(ctx context.Context, lintCtx *linter.Context,
 	tasksCh <-chan linter.Config, lintResultsCh chan<- lintRes, name string) {
 
@@ -123,9 +625,17 @@ func (r Runner) runWorker(ctx context.Context, lintCtx *linter.Context,
 			}
 			var issues []result.Issue
 			var err error
+			startedAt := time.Now()
 			sw.TrackStage(lc.Name(), func() {
-				issues, err = r.runLinterSafe(ctx, lintCtx, lc)
+				issues, err = r.runLinterWithTimeout(ctx, lintCtx, lc)
 			})
+			r.progress.addTime(lc.Name(), time.Since(startedAt))
+			if ctx.Err() == nil {
+				r.progress.markFinished(lc.Name())
+				if err != nil {
+					r.progress.markFailed(lc.Name())
+				}
+			}
 			lintResultsCh <- lintRes{
 				linter: lc,
 				err:    err,
@@ -136,6 +646,10 @@ func (r Runner) runWorker(ctx context.Context, lintCtx *linter.Context,
 }
 
 func (r Runner) logWorkersStat(workersFinishTimes []time.Time) {
+	if len(workersFinishTimes) == 1 {
+		return // the only worker is never idle
+	}
+
 	lastFinishTime := workersFinishTimes[0]
 	for _, t := range workersFinishTimes {
 		if t.After(lastFinishTime) {
@@ -168,7 +682,8 @@ func getSortedLintersConfigs(linters []linter.Config) []linter.Config {
 
 func (r *Runner) runWorkers(ctx context.Context, lintCtx *linter.Context, linters []linter.Config) <-chan lintRes {
 	tasksCh := make(chan linter.Config, len(linters))
-	lintResultsCh := make(chan lintRes, len(linters))
+	// bounded buffer: workers wait for processing of results instead of accumulating them
+	lintResultsCh := make(chan lintRes, lintCtx.Cfg.Run.Concurrency)
 	var wg sync.WaitGroup
 
 	workersFinishTimes := make([]time.Time, lintCtx.Cfg.Run.Concurrency)
@@ -199,6 +714,9 @@ func (r *Runner) runWorkers(ctx context.Context, lintCtx *linter.Context, linter
 	return lintResultsCh
 }
 
+// processingBatchSize is a max count of issues processed and sent to output at once
+const processingBatchSize = 1024
+
 func (r Runner) processLintResults(inCh <-chan lintRes) <-chan lintRes {
 	outCh := make(chan lintRes, 64)
 
@@ -209,13 +727,23 @@ func (r Runner) processLintResults(inCh <-chan lintRes) <-chan lintRes {
 
 		for res := range inCh {
 			if res.err != nil {
-				r.Log.Warnf("Can't run linter %s: %s", res.linter.Name(), res.err)
+				r.Log.Warnf("Linter %s failed to run, its issues aren't reported: %s", res.linter.Name(), res.err)
 				continue
 			}
 
-			if len(res.issues) != 0 {
-				res.issues = r.processIssues(res.issues, sw)
-				outCh <- res
+			// process issues by batches to not keep copies of all issues
+			// of the linter on every processing stage
+			for len(res.issues) != 0 {
+				n := processingBatchSize
+				if n > len(res.issues) {
+					n = len(res.issues)
+				}
+
+				batch := res.issues[:n:n]
+				res.issues = res.issues[n:]
+				if processed := r.processIssues(batch, sw); len(processed) != 0 {
+					outCh <- lintRes{linter: res.linter, issues: processed}
+				}
 			}
 		}
 
@@ -254,6 +782,23 @@ func collectIssues(resCh <-chan lintRes) <-chan result.Issue {
 }
 
 func (r Runner) Run(ctx context.Context, linters []linter.Config, lintCtx *linter.Context) <-chan result.Issue {
+	var files []string
+	for _, pkg := range lintCtx.Packages {
+		files = append(files, pkg.GoFiles...)
+	}
+	r.progress.start(linters, files)
+
+	if r.issuesCache != nil {
+		var linterNames []string
+		for _, lc := range linters {
+			linterNames = append(linterNames, lc.Name())
+		}
+		if err := r.issuesCache.Prepare(lintCtx.Cfg, lintCtx.Packages, linterNames); err != nil {
+			r.Log.Warnf("Can't use issues cache: %s", err)
+			r.issuesCache = nil
+		}
+	}
+
 	lintResultsCh := r.runWorkers(ctx, lintCtx, linters)
 	processedLintResultsCh := r.processLintResults(lintResultsCh)
 	if ctx.Err() != nil {
@@ -267,7 +812,43 @@ func (r Runner) Run(ctx context.Context, linters []linter.Config, lintCtx *linte
 			finishedLintersN, len(linters))
 	}
 
-	return collectIssues(processedLintResultsCh)
+	issues := collectIssues(processedLintResultsCh)
+	if r.stopOnTypecheckErrors {
+		issues = r.keepOnlyTypecheckErrors(issues)
+	}
+
+	// sorting must be after all processors: it needs all issues
+	return r.sortResults.Process(issues)
+}
+
+const typecheckLinterName = "typecheck"
+
+// keepOnlyTypecheckErrors drops issues of other linters if typecheck issues
+// were found: they are found after all processors, so typecheck issues hidden
+// by nolint or excludes don't stop reporting of other issues
+func (r Runner) keepOnlyTypecheckErrors(issues <-chan result.Issue) <-chan result.Issue {
+	var typecheckIssues, otherIssues []result.Issue
+	for i := range issues {
+		if i.FromLinter == typecheckLinterName {
+			typecheckIssues = append(typecheckIssues, i)
+		} else {
+			otherIssues = append(otherIssues, i)
+		}
+	}
+
+	retIssues := otherIssues
+	if len(typecheckIssues) != 0 {
+		r.Log.Infof("Typecheck found %d errors: %d issues of other linters aren't reported",
+			len(typecheckIssues), len(otherIssues))
+		retIssues = typecheckIssues
+	}
+
+	retCh := make(chan result.Issue, len(retIssues))
+	for _, i := range retIssues {
+		retCh <- i
+	}
+	close(retCh)
+	return retCh
 }
 
 func (r *Runner) processIssues(issues []result.Issue, sw *timeutils.Stopwatch) []result.Issue {
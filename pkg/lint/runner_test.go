@@ -0,0 +1,44 @@
+package lint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/lint/linter"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+type fakeLinter string
+
+func (l fakeLinter) Run(ctx context.Context, lintCtx *linter.Context) ([]result.Issue, error) {
+	return nil, nil
+}
+
+func (l fakeLinter) Name() string {
+	return string(l)
+}
+
+func (l fakeLinter) Desc() string {
+	return "fake linter"
+}
+
+func TestUnfinishedLinters(t *testing.T) {
+	r := Runner{progress: &lintersProgress{finished: map[string]bool{}}}
+	assert.Empty(t, r.UnfinishedLinters())
+
+	r.progress.start([]linter.Config{
+		*linter.NewConfig(fakeLinter("golint")),
+		*linter.NewConfig(fakeLinter("errcheck")),
+		*linter.NewConfig(fakeLinter("govet")),
+	}, nil)
+	assert.Equal(t, []string{"errcheck", "golint", "govet"}, r.UnfinishedLinters())
+
+	r.progress.markFinished("golint")
+	assert.Equal(t, []string{"errcheck", "govet"}, r.UnfinishedLinters())
+
+	r.progress.markFinished("errcheck")
+	r.progress.markFinished("govet")
+	assert.Empty(t, r.UnfinishedLinters())
+}
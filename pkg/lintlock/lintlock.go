@@ -0,0 +1,120 @@
+package lintlock
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Lock contains versions of golangci-lint and of all modules linked into it:
+// wrapped linters are among them.
+type Lock struct {
+	GolangciLint string            `yaml:"golangci-lint"`
+	Modules      map[string]string `yaml:"modules"`
+}
+
+// Current returns lock for the running binary of golangci-lint with the given version:
+// versions of modules are generated from vendor/modules.txt by make lintlock.
+func Current(version string) *Lock {
+	l := &Lock{
+		GolangciLint: version,
+		Modules:      map[string]string{},
+	}
+	for path, v := range vendoredModules {
+		l.Modules[path] = v
+	}
+
+	return l
+}
+
+// ParseModulesTxt parses versions of modules from vendor/modules.txt made by go mod vendor:
+// lines of modules are in format "# path version [=> replacement [version]]", versions
+// of replacements are used if they are set.
+func ParseModulesTxt(r io.Reader) (map[string]string, error) {
+	ret := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "# ") {
+			continue
+		}
+
+		fields := strings.Fields(strings.TrimPrefix(line, "# "))
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("invalid module line %q", line)
+		}
+
+		version := fields[1]
+		if len(fields) == 5 && fields[2] == "=>" {
+			version = fields[4]
+		}
+		ret[fields[0]] = version
+	}
+
+	return ret, scanner.Err()
+}
+
+// Read reads lock from the file: nil lock is returned if the file doesn't exist.
+func Read(path string) (*Lock, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "can't read lock file %s", path)
+	}
+
+	var l Lock
+	if err := yaml.Unmarshal(data, &l); err != nil {
+		return nil, errors.Wrapf(err, "can't parse lock file %s", path)
+	}
+
+	return &l, nil
+}
+
+// Write writes lock to the file in YAML format.
+func (l Lock) Write(path string) error {
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return errors.Wrap(err, "can't marshal lock")
+	}
+
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return errors.Wrapf(err, "can't write lock file %s", path)
+	}
+
+	return nil
+}
+
+// Diff returns sorted human-readable differences between locked and actual versions.
+func Diff(locked, actual *Lock) []string {
+	var ret []string
+	if locked.GolangciLint != actual.GolangciLint {
+		ret = append(ret, fmt.Sprintf("golangci-lint: locked %s, got %s", locked.GolangciLint, actual.GolangciLint))
+	}
+
+	var modDiff []string
+	for path, lockedVersion := range locked.Modules {
+		version, ok := actual.Modules[path]
+		if !ok {
+			modDiff = append(modDiff, fmt.Sprintf("%s: locked %s, got none", path, lockedVersion))
+		} else if version != lockedVersion {
+			modDiff = append(modDiff, fmt.Sprintf("%s: locked %s, got %s", path, lockedVersion, version))
+		}
+	}
+	for path, version := range actual.Modules {
+		if _, ok := locked.Modules[path]; !ok {
+			modDiff = append(modDiff, fmt.Sprintf("%s: locked none, got %s", path, version))
+		}
+	}
+	sort.Strings(modDiff)
+
+	return append(ret, modDiff...)
+}
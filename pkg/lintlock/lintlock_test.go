@@ -0,0 +1,91 @@
+package lintlock
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	locked := &Lock{
+		GolangciLint: "v1.12.0",
+		Modules: map[string]string{
+			"github.com/golangci/errcheck": "v1.0.0",
+			"github.com/golangci/gosec":    "v1.0.0",
+			"github.com/golangci/lint-1":   "v1.0.0",
+		},
+	}
+	actual := &Lock{
+		GolangciLint: "v1.12.1",
+		Modules: map[string]string{
+			"github.com/golangci/errcheck": "v1.0.0",
+			"github.com/golangci/gosec":    "v1.1.0",
+			"github.com/golangci/unparam":  "v1.0.0",
+		},
+	}
+
+	assert.Equal(t, []string{
+		"golangci-lint: locked v1.12.0, got v1.12.1",
+		"github.com/golangci/gosec: locked v1.0.0, got v1.1.0",
+		"github.com/golangci/lint-1: locked v1.0.0, got none",
+		"github.com/golangci/unparam: locked none, got v1.0.0",
+	}, Diff(locked, actual))
+	assert.Empty(t, Diff(locked, locked))
+}
+
+func TestWriteAndRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lintlock")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "lint.lock")
+	l, err := Read(path)
+	assert.NoError(t, err)
+	assert.Nil(t, l)
+
+	expected := Lock{
+		GolangciLint: "v1.12.0",
+		Modules:      map[string]string{"github.com/golangci/errcheck": "v1.0.0"},
+	}
+	assert.NoError(t, expected.Write(path))
+
+	l, err = Read(path)
+	assert.NoError(t, err)
+	assert.Equal(t, &expected, l)
+}
+
+func TestParseModulesTxt(t *testing.T) {
+	modules, err := ParseModulesTxt(strings.NewReader(`# github.com/golangci/errcheck v1.0.0
+github.com/golangci/errcheck/golangci
+# github.com/golangci/gosec v1.0.0 => github.com/fork/gosec v1.1.0
+github.com/golangci/gosec
+# github.com/golangci/lint-1 v1.0.0 => ../lint
+## explicit
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"github.com/golangci/errcheck": "v1.0.0",
+		"github.com/golangci/gosec":    "v1.1.0",
+		"github.com/golangci/lint-1":   "v1.0.0",
+	}, modules)
+
+	_, err = ParseModulesTxt(strings.NewReader("# github.com/golangci/errcheck\n"))
+	assert.EqualError(t, err, `invalid module line "# github.com/golangci/errcheck"`)
+}
+
+func TestCurrentIsGenerated(t *testing.T) {
+	f, err := os.Open(filepath.Join("..", "..", "vendor", "modules.txt"))
+	assert.NoError(t, err)
+	defer f.Close()
+
+	modules, err := ParseModulesTxt(f)
+	assert.NoError(t, err)
+
+	l := Current("v1.12.0")
+	assert.Equal(t, "v1.12.0", l.GolangciLint)
+	assert.Equal(t, modules, l.Modules, "run make lintlock")
+}
@@ -0,0 +1,76 @@
+// Code generated by scripts/gen_lintlock from vendor/modules.txt. DO NOT EDIT.
+
+package lintlock
+
+// vendoredModules are versions of modules linked into golangci-lint
+var vendoredModules = map[string]string{
+	"github.com/OpenPeeDeeP/depguard":      "v0.0.0-20180806142446-a69c782687b2",
+	"github.com/StackExchange/wmi":         "v0.0.0-20180116203802-5d049714c4a6",
+	"github.com/davecgh/go-spew":           "v1.1.0",
+	"github.com/fatih/color":               "v1.6.0",
+	"github.com/fsnotify/fsnotify":         "v1.4.7",
+	"github.com/go-critic/checkers":        "v0.0.0-20181031185637-879460b6c936",
+	"github.com/go-lintpack/lintpack":      "v0.0.0-20181105152233-7ff0297828fc",
+	"github.com/go-ole/go-ole":             "v1.2.1",
+	"github.com/go-toolsmith/astcast":      "v0.0.0-20181028201508-b7a89ed70af1",
+	"github.com/go-toolsmith/astcopy":      "v0.0.0-20180903214859-79b422d080c4",
+	"github.com/go-toolsmith/astequal":     "v0.0.0-20180903214952-dcb477bfacd6",
+	"github.com/go-toolsmith/astfmt":       "v0.0.0-20180903215011-8f8ee99c3086",
+	"github.com/go-toolsmith/astp":         "v0.0.0-20180903215135-0af7e3c24f30",
+	"github.com/go-toolsmith/typep":        "v0.0.0-20181030061450-d63dc7650676",
+	"github.com/gobwas/glob":               "v0.2.3",
+	"github.com/gogo/protobuf":             "v1.0.0",
+	"github.com/golang/mock":               "v1.1.1",
+	"github.com/golangci/check":            "v0.0.0-20180506172741-cfe4005ccda2",
+	"github.com/golangci/dupl":             "v0.0.0-20180902072040-3e9179ac440a",
+	"github.com/golangci/errcheck":         "v0.0.0-20181003203344-1765131d5be5",
+	"github.com/golangci/go-misc":          "v0.0.0-20180628070357-927a3d87b613",
+	"github.com/golangci/go-tools":         "v0.0.0-20180902103155-93eecd106a0b",
+	"github.com/golangci/goconst":          "v0.0.0-20180610141641-041c5f2b40f3",
+	"github.com/golangci/gocyclo":          "v0.0.0-20180528134321-2becd97e67ee",
+	"github.com/golangci/gofmt":            "v0.0.0-20181105071733-f021c4179c82",
+	"github.com/golangci/gosec":            "v0.0.0-20180901114220-8afd9cbb6cfb",
+	"github.com/golangci/govet":            "v0.0.0-20180818181408-44ddbe260190",
+	"github.com/golangci/ineffassign":      "v0.0.0-20180808204949-2ee8f2867dde",
+	"github.com/golangci/interfacer":       "v0.0.0-20180902080945-01958817a6ec",
+	"github.com/golangci/lint":             "v0.0.0-20180902080404-c2187e7932b5",
+	"github.com/golangci/lint-1":           "v0.0.0-20180610141402-4bf9709227d1",
+	"github.com/golangci/maligned":         "v0.0.0-20180506175553-b1d89398deca",
+	"github.com/golangci/misspell":         "v0.0.0-20180809174111-950f5d19e770",
+	"github.com/golangci/prealloc":         "v0.0.0-20180630174525-215b22d4de21",
+	"github.com/golangci/revgrep":          "v0.0.0-20180526074752-d9c87f5ffaf0",
+	"github.com/golangci/tools":            "v0.0.0-20180902102414-2cefd77fef9b",
+	"github.com/golangci/unconvert":        "v0.0.0-20180507085042-28b1c447d1f4",
+	"github.com/golangci/unparam":          "v0.0.0-20180902112548-7ad9dbcccc16",
+	"github.com/hashicorp/hcl":             "v0.0.0-20180404174102-ef8a98b0bbce",
+	"github.com/inconshreveable/mousetrap": "v1.0.0",
+	"github.com/kisielk/gotool":            "v1.0.0",
+	"github.com/magiconair/properties":     "v1.7.6",
+	"github.com/mattn/go-colorable":        "v0.0.9",
+	"github.com/mattn/go-isatty":           "v0.0.3",
+	"github.com/mitchellh/go-homedir":      "v1.0.0",
+	"github.com/mitchellh/go-ps":           "v0.0.0-20170309133038-4fdf99ab2936",
+	"github.com/mitchellh/mapstructure":    "v0.0.0-20180220230111-00c29f56e238",
+	"github.com/nbutton23/zxcvbn-go":       "v0.0.0-20171102151520-eafdab6b0663",
+	"github.com/pelletier/go-toml":         "v1.1.0",
+	"github.com/pkg/errors":                "v0.8.0",
+	"github.com/pmezard/go-difflib":        "v1.0.0",
+	"github.com/shirou/gopsutil":           "v0.0.0-20180427012116-c95755e4bcd7",
+	"github.com/shirou/w32":                "v0.0.0-20160930032740-bb4de0191aa4",
+	"github.com/sirupsen/logrus":           "v1.0.5",
+	"github.com/spf13/afero":               "v1.1.0",
+	"github.com/spf13/cast":                "v1.2.0",
+	"github.com/spf13/cobra":               "v0.0.2",
+	"github.com/spf13/jwalterweatherman":   "v0.0.0-20180109140146-7c0cea34c8ec",
+	"github.com/spf13/pflag":               "v1.0.1",
+	"github.com/spf13/viper":               "v1.0.2",
+	"github.com/stretchr/testify":          "v1.2.1",
+	"golang.org/x/crypto":                  "v0.0.0-20180505025534-4ec37c66abab",
+	"golang.org/x/net":                     "v0.0.0-20180906233101-161cd47e91fd",
+	"golang.org/x/sys":                     "v0.0.0-20180909124046-d0be0721c37e",
+	"golang.org/x/text":                    "v0.3.0",
+	"golang.org/x/tools":                   "v0.0.0-20180831211245-6c7e314b6563",
+	"gopkg.in/yaml.v2":                     "v2.2.1",
+	"sourcegraph.com/sourcegraph/go-diff":  "v0.0.0-20171119081133-3f415a150aec",
+	"sourcegraph.com/sqs/pbtypes":          "v0.0.0-20160107090929-4d1b9dc7ffc3",
+}
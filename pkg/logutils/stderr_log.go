@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/sirupsen/logrus" //nolint:depguard
+	"github.com/sirupsen/logrus" //nolint:depguard // logrus is allowed in logutils
 
 	"github.com/golangci/golangci-lint/pkg/exitcodes"
 )
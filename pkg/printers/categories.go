@@ -0,0 +1,41 @@
+package printers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// categoryCounts contains issues count per category: issues have categories
+// only if output.categories option is set.
+type categoryCounts map[string]int
+
+func (c categoryCounts) add(i *result.Issue) {
+	if i.Category != "" {
+		c[i.Category]++
+	}
+}
+
+// String returns summary like "12 style, 3 security" sorted by count.
+func (c categoryCounts) String() string {
+	categories := make([]string, 0, len(c))
+	for category := range c {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		ci, cj := categories[i], categories[j]
+		if c[ci] != c[cj] {
+			return c[ci] > c[cj]
+		}
+		return ci < cj
+	})
+
+	parts := make([]string, 0, len(categories))
+	for _, category := range categories {
+		parts = append(parts, fmt.Sprintf("%d %s", c[category], category))
+	}
+
+	return strings.Join(parts, ", ")
+}
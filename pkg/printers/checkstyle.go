@@ -4,8 +4,8 @@ import (
 	"context"
 	"encoding/xml"
 	"fmt"
+	"io"
 
-	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
@@ -21,7 +21,7 @@ type checkstyleFile struct {
 }
 
 type checkstyleError struct {
-	Column   int    `xml:"column,attr"`
+	Column   *int   `xml:"column,attr,omitempty"`
 	Line     int    `xml:"line,attr"`
 	Message  string `xml:"message,attr"`
 	Severity string `xml:"severity,attr"`
@@ -30,13 +30,27 @@ type checkstyleError struct {
 
 const defaultSeverity = "error"
 
-type Checkstyle struct{}
+func severityOrDefault(i *result.Issue) string {
+	if i.Severity != "" {
+		return i.Severity
+	}
+
+	return defaultSeverity
+}
 
-func NewCheckstyle() *Checkstyle {
-	return &Checkstyle{}
+type Checkstyle struct {
+	hideZeroColumn bool
+	w              io.Writer
 }
 
-func (Checkstyle) Print(ctx context.Context, issues <-chan result.Issue) error {
+func NewCheckstyle(hideZeroColumn bool, w io.Writer) *Checkstyle {
+	return &Checkstyle{
+		hideZeroColumn: hideZeroColumn,
+		w:              w,
+	}
+}
+
+func (p Checkstyle) Print(ctx context.Context, issues <-chan result.Issue) error {
 	out := checkstyleOutput{
 		Version: "5.0",
 	}
@@ -51,29 +65,27 @@ func (Checkstyle) Print(ctx context.Context, issues <-chan result.Issue) error {
 			}
 
 			files[issue.FilePath()] = file
+			out.Files = append(out.Files, file) // keep order of issues
 		}
 
 		newError := &checkstyleError{
-			Column:   issue.Column(),
 			Line:     issue.Line(),
 			Message:  issue.Text,
 			Source:   issue.FromLinter,
-			Severity: defaultSeverity,
+			Severity: severityOrDefault(&issue),
+		}
+		if column := issue.Column(); column != 0 || !p.hideZeroColumn {
+			newError.Column = &column
 		}
 
 		file.Errors = append(file.Errors, newError)
 	}
 
-	out.Files = make([]*checkstyleFile, 0, len(files))
-	for _, file := range files {
-		out.Files = append(out.Files, file)
-	}
-
 	data, err := xml.Marshal(&out)
 	if err != nil {
 		return err
 	}
 
-	fmt.Fprintf(logutils.StdOut, "%s%s\n", xml.Header, data)
+	fmt.Fprintf(p.w, "%s%s\n", xml.Header, data)
 	return nil
 }
@@ -0,0 +1,31 @@
+package printers
+
+import (
+	"go/token"
+	"io"
+	"testing"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newCheckstyle(hideZeroColumn bool) func(w io.Writer) Printer {
+	return func(w io.Writer) Printer {
+		return NewCheckstyle(hideZeroColumn, w)
+	}
+}
+
+func TestCheckstyle(t *testing.T) {
+	// files are printed in order of their first issues: issues are grouped by file
+	issues := append(newTestIssues(), result.Issue{
+		FromLinter: "errcheck",
+		Text:       "Error return value is not checked",
+		Severity:   result.SeverityWarning,
+		Pos:        token.Position{Filename: "b.go", Line: 5, Column: 2},
+	})
+
+	// golint issue without severity is an error
+	assertGolden(t, "checkstyle", printIssues(t, newCheckstyle(false), issues))
+
+	// unknown column of golint issue isn't printed
+	assertGolden(t, "checkstyle_hide_zero_column", printIssues(t, newCheckstyle(true), issues))
+}
@@ -0,0 +1,114 @@
+package printers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const (
+	codeClimateSeverityMajor = "major"
+	codeClimateSeverityMinor = "minor"
+	codeClimateSeverityInfo  = "info"
+)
+
+type codeClimateIssue struct {
+	Type        string              `json:"type"`
+	CheckName   string              `json:"check_name"`
+	Description string              `json:"description"`
+	Severity    string              `json:"severity"`
+	Fingerprint string              `json:"fingerprint"`
+	Location    codeClimateLocation `json:"location"`
+}
+
+type codeClimateLocation struct {
+	Path  string           `json:"path"`
+	Lines codeClimateLines `json:"lines"`
+}
+
+type codeClimateLines struct {
+	Begin int `json:"begin"`
+}
+
+// CodeClimate prints issues in Code Climate JSON format used by GitLab Code Quality.
+type CodeClimate struct {
+	isBugsLinter func(name string) bool
+	w            io.Writer
+}
+
+// NewCodeClimate creates Code Climate printer: issues with error, warning and info
+// severity are major, minor and info. Issues without severity of linters from bugs
+// preset (isBugsLinter returns true for them) are major, other issues are minor.
+func NewCodeClimate(isBugsLinter func(name string) bool, w io.Writer) *CodeClimate {
+	return &CodeClimate{
+		isBugsLinter: isBugsLinter,
+		w:            w,
+	}
+}
+
+func (p CodeClimate) Print(ctx context.Context, issues <-chan result.Issue) error {
+	allIssues := []codeClimateIssue{}
+	fingerprints := map[string]int{}
+	for i := range issues {
+		i := i
+
+		allIssues = append(allIssues, codeClimateIssue{
+			Type:        "issue",
+			CheckName:   i.FromLinter,
+			Description: i.Text,
+			Severity:    p.severity(&i),
+			Fingerprint: codeClimateFingerprint(&i, fingerprints),
+			Location: codeClimateLocation{
+				Path:  filepath.ToSlash(i.FilePath()),
+				Lines: codeClimateLines{Begin: i.Line()},
+			},
+		})
+	}
+
+	outputJSON, err := json.Marshal(allIssues)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(p.w, string(outputJSON))
+	return nil
+}
+
+func (p CodeClimate) severity(i *result.Issue) string {
+	switch i.Severity {
+	case result.SeverityError:
+		return codeClimateSeverityMajor
+	case result.SeverityWarning:
+		return codeClimateSeverityMinor
+	case result.SeverityInfo:
+		return codeClimateSeverityInfo
+	}
+
+	if p.isBugsLinter(i.FromLinter) {
+		return codeClimateSeverityMajor
+	}
+	return codeClimateSeverityMinor
+}
+
+// codeClimateFingerprint returns the fingerprint of the issue: Code Climate requires
+// unique fingerprints, so the n-th issue with the same fingerprint, e.g. the same text
+// about the same code in one file, gets sha256 of the fingerprint and n.
+// seen counts printed issues by their fingerprints.
+func codeClimateFingerprint(i *result.Issue, seen map[string]int) string {
+	fp := i.GetFingerprint()
+	n := seen[fp]
+	seen[fp]++
+	if n == 0 {
+		return fp
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%d", fp, n)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,55 @@
+package printers
+
+import (
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func isBugsLinter(name string) bool {
+	return name == "govet"
+}
+
+func newCodeClimate(w io.Writer) Printer {
+	return NewCodeClimate(isBugsLinter, w)
+}
+
+func TestCodeClimate(t *testing.T) {
+	issues := newTestIssues()
+	// the same text about the same code in one file: Code Climate requires unique fingerprints
+	issues = append(issues, issues[1])
+
+	assertGolden(t, "codeclimate", printIssues(t, newCodeClimate, issues))
+	assert.Equal(t, "[]\n", printIssues(t, newCodeClimate, nil))
+}
+
+func TestCodeClimateSeverity(t *testing.T) {
+	issues := newTestIssues()
+	issues[0].Severity = ""
+	issues[2].Severity = ""
+
+	// issues without severity are major for linters from bugs preset, otherwise minor
+	p := NewCodeClimate(isBugsLinter, ioutil.Discard)
+	assert.Equal(t, codeClimateSeverityMajor, p.severity(&issues[0]))
+	assert.Equal(t, codeClimateSeverityMinor, p.severity(&issues[2]))
+}
+
+func TestCodeClimateFingerprint(t *testing.T) {
+	i := newTestIssues()[1]
+	i.Fingerprint = ""
+	seen := map[string]int{}
+
+	// the issue wasn't processed by the runner: the fingerprint has no source lines
+	fp := result.Fingerprint(i.FilePath(), i.FromLinter, i.Text, nil)
+	assert.Equal(t, fp, codeClimateFingerprint(&i, seen))
+
+	second := codeClimateFingerprint(&i, seen)
+	third := codeClimateFingerprint(&i, seen)
+	assert.NotEqual(t, fp, second)
+	assert.NotEqual(t, second, third)
+	assert.Len(t, second, len(fp))
+}
@@ -0,0 +1,68 @@
+package printers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const typecheckLinterName = "typecheck"
+
+// GitHubActions prints issues as workflow commands of GitHub Actions:
+// they are shown as annotations of pull requests. Source lines are never printed.
+type GitHubActions struct {
+	w io.Writer
+}
+
+func NewGitHubActions(w io.Writer) *GitHubActions {
+	return &GitHubActions{
+		w: w,
+	}
+}
+
+func (p GitHubActions) Print(ctx context.Context, issues <-chan result.Issue) error {
+	for i := range issues {
+		i := i
+		fmt.Fprintln(p.w, formatGitHubActionsIssue(&i))
+	}
+
+	return nil
+}
+
+func formatGitHubActionsIssue(i *result.Issue) string {
+	level := "warning"
+	switch {
+	case i.Severity == result.SeverityInfo:
+		level = "notice"
+	case i.Severity != "":
+		level = i.Severity
+	case i.FromLinter == typecheckLinterName:
+		level = "error" // code can't be compiled
+	}
+
+	props := fmt.Sprintf("file=%s,line=%d", escapeGitHubActionsProperty(filepath.ToSlash(i.FilePath())), i.Line())
+	if i.Column() != 0 {
+		props += fmt.Sprintf(",col=%d", i.Column())
+	}
+
+	msg := fmt.Sprintf("%s (%s)", i.Text, i.FromLinter)
+	return fmt.Sprintf("::%s %s::%s", level, props, escapeGitHubActionsData(msg))
+}
+
+// escapeGitHubActionsData escapes data of workflow command,
+// see https://github.com/actions/toolkit/blob/main/packages/core/src/command.ts
+func escapeGitHubActionsData(s string) string {
+	s = strings.Replace(s, "%", "%25", -1)
+	s = strings.Replace(s, "\r", "%0D", -1)
+	return strings.Replace(s, "\n", "%0A", -1)
+}
+
+func escapeGitHubActionsProperty(s string) string {
+	s = escapeGitHubActionsData(s)
+	s = strings.Replace(s, ":", "%3A", -1)
+	return strings.Replace(s, ",", "%2C", -1)
+}
@@ -0,0 +1,25 @@
+package printers
+
+import (
+	"go/token"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newGitHubActions(w io.Writer) Printer {
+	return NewGitHubActions(w)
+}
+
+func TestGitHubActions(t *testing.T) {
+	assertGolden(t, "github_actions", printIssues(t, newGitHubActions, newTestIssues()))
+	assert.Empty(t, printIssues(t, newGitHubActions, nil))
+
+	// code which can't be compiled is an error without severity
+	typecheckIssue := result.Issue{FromLinter: "typecheck", Text: "undeclared name: x", Pos: token.Position{Filename: "a.go", Line: 1}}
+	assert.Equal(t, "::error file=a.go,line=1::undeclared name: x (typecheck)\n",
+		printIssues(t, newGitHubActions, []result.Issue{typecheckIssue}))
+}
@@ -4,36 +4,63 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 
-	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/report"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
 type JSON struct {
-	rd *report.Data
+	rd               *report.Data
+	suppressedIssues func() []result.SuppressedIssue
+	metadata         *report.Metadata
+	printIssuedLines bool
+	w                io.Writer
 }
 
-func NewJSON(rd *report.Data) *JSON {
+// NewJSON creates JSON printer: suppressedIssues is called after reading
+// of all issues to get issues hidden by nolint directives.
+// Metadata is printed only if it's not nil, source lines of issues
+// are printed only if printIssuedLines is true.
+func NewJSON(rd *report.Data, suppressedIssues func() []result.SuppressedIssue, metadata *report.Metadata,
+	printIssuedLines bool, w io.Writer) *JSON {
+
 	return &JSON{
-		rd: rd,
+		rd:               rd,
+		suppressedIssues: suppressedIssues,
+		metadata:         metadata,
+		printIssuedLines: printIssuedLines,
+		w:                w,
 	}
 }
 
 type JSONResult struct {
-	Issues []result.Issue
-	Report *report.Data
+	Metadata   *report.Metadata `json:",omitempty"`
+	Issues     []result.Issue
+	Suppressed []result.SuppressedIssue `json:",omitempty"`
+	Categories map[string]int           `json:",omitempty"` // issues count per category
+	Report     *report.Data
 }
 
 func (p JSON) Print(ctx context.Context, issues <-chan result.Issue) error {
 	allIssues := []result.Issue{}
+	counts := categoryCounts{}
 	for i := range issues {
+		i := i
+		counts.add(&i)
+		if !p.printIssuedLines {
+			i.SourceLines = nil
+			i.SourceContext = nil
+		}
 		allIssues = append(allIssues, i)
 	}
 
 	res := JSONResult{
-		Issues: allIssues,
-		Report: p.rd,
+		Metadata:   p.metadata,
+		Issues:     allIssues,
+		Suppressed: p.suppressedIssues(),
+		Categories: counts,
+		Report:     p.rd,
 	}
 
 	outputJSON, err := json.Marshal(res)
@@ -41,6 +68,6 @@ func (p JSON) Print(ctx context.Context, issues <-chan result.Issue) error {
 		return err
 	}
 
-	fmt.Fprint(logutils.StdOut, string(outputJSON))
+	fmt.Fprint(p.w, string(outputJSON))
 	return nil
 }
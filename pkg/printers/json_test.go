@@ -0,0 +1,64 @@
+package printers
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newJSON(metadata *report.Metadata) func(w io.Writer) Printer {
+	return func(w io.Writer) Printer {
+		return NewJSON(&report.Data{}, func() []result.SuppressedIssue { return nil }, metadata, true, w)
+	}
+}
+
+func parseJSONResult(t *testing.T, output string) JSONResult {
+	var res JSONResult
+	require.NoError(t, json.Unmarshal([]byte(output), &res))
+	return res
+}
+
+func TestJSONCategories(t *testing.T) {
+	res := parseJSONResult(t, printIssues(t, newJSON(nil), newCategorizedIssues()))
+	assert.Equal(t, map[string]int{"bugs": 1, "style": 2}, res.Categories)
+	assert.Equal(t, "bugs", res.Issues[0].Category)
+
+	output := printIssues(t, newJSON(nil), newTestIssues())
+	assert.NotContains(t, output, `"Categories"`)
+	assert.Nil(t, parseJSONResult(t, output).Categories)
+}
+
+func TestJSONMetadata(t *testing.T) {
+	const commit = "0123456789abcdef0123456789abcdef01234567"
+	metadata := &report.Metadata{
+		Git:             &report.GitMetadata{Commit: commit, Branch: "master"},
+		GoVersion:       "go1.11",
+		GolangciVersion: "1.2.3",
+		Timestamp:       time.Date(2019, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	output := printIssues(t, newJSON(metadata), newTestIssues())
+	assert.Contains(t, output, `"Metadata":{"Git":{"Commit":"`+commit+`","Branch":"master"},`+
+		`"GoVersion":"go1.11","GolangciVersion":"1.2.3","Timestamp":"2019-01-02T03:04:05Z"}`)
+	assert.Equal(t, metadata, parseJSONResult(t, output).Metadata)
+
+	// outside of a git repo: git metadata isn't printed
+	metadata.Git = nil
+	output = printIssues(t, newJSON(metadata), newTestIssues())
+	assert.Contains(t, output, `"Metadata":{"GoVersion":"go1.11",`)
+	assert.Equal(t, metadata, parseJSONResult(t, output).Metadata)
+
+	// detached HEAD: branch isn't printed
+	metadata.Git = &report.GitMetadata{Commit: commit}
+	output = printIssues(t, newJSON(metadata), newTestIssues())
+	assert.Contains(t, output, `"Git":{"Commit":"`+commit+`"},`)
+
+	// metadata isn't printed without output.print-metadata
+	assert.NotContains(t, printIssues(t, newJSON(nil), newTestIssues()), `"Metadata"`)
+}
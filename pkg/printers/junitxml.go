@@ -0,0 +1,122 @@
+package printers
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name          `xml:"testsuites"`
+	Suites  []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Cases    []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",cdata"`
+}
+
+// JUnitXML prints issues as JUnit XML report: every linter is a test suite
+// and every issue is a failed test case. Enabled linters without issues
+// have one passed test case.
+type JUnitXML struct {
+	rd *report.Data
+	w  io.Writer
+}
+
+// NewJUnitXML creates JUnit XML printer: enabled linters are taken from rd
+// after reading of all issues.
+func NewJUnitXML(rd *report.Data, w io.Writer) *JUnitXML {
+	return &JUnitXML{
+		rd: rd,
+		w:  w,
+	}
+}
+
+func (p JUnitXML) Print(ctx context.Context, issues <-chan result.Issue) error {
+	suites := map[string]*junitTestSuite{}
+	getSuite := func(linter string) *junitTestSuite {
+		s := suites[linter]
+		if s == nil {
+			s = &junitTestSuite{Name: linter}
+			suites[linter] = s
+		}
+		return s
+	}
+
+	for i := range issues {
+		i := i
+		s := getSuite(i.FromLinter)
+		s.Tests++
+		s.Failures++
+		s.Cases = append(s.Cases, &junitTestCase{
+			Name:      fmt.Sprintf("%s:%d", i.FilePath(), i.Line()),
+			ClassName: i.FromLinter,
+			Failure: &junitFailure{
+				Message: i.Text,
+				Type:    severityOrDefault(&i),
+				Content: formatJUnitFailure(&i),
+			},
+		})
+	}
+
+	for _, ld := range p.rd.Linters {
+		if !ld.Enabled || suites[ld.Name] != nil {
+			continue
+		}
+
+		s := getSuite(ld.Name)
+		s.Tests++
+		s.Cases = append(s.Cases, &junitTestCase{
+			Name:      "no issues",
+			ClassName: ld.Name,
+		})
+	}
+
+	out := junitTestSuites{
+		Suites: make([]*junitTestSuite, 0, len(suites)),
+	}
+	for _, s := range suites {
+		out.Suites = append(out.Suites, s)
+	}
+	sort.Slice(out.Suites, func(i, j int) bool {
+		return out.Suites[i].Name < out.Suites[j].Name
+	})
+
+	data, err := xml.MarshalIndent(&out, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(p.w, "%s%s\n", xml.Header, data)
+	return nil
+}
+
+func formatJUnitFailure(i *result.Issue) string {
+	text := fmt.Sprintf("%s: %s", i.Pos, i.Text)
+	if len(i.SourceLines) != 0 {
+		text += "\n" + strings.Join(i.SourceLines, "\n")
+	}
+
+	return text
+}
@@ -0,0 +1,25 @@
+package printers
+
+import (
+	"io"
+	"testing"
+
+	"github.com/golangci/golangci-lint/pkg/report"
+)
+
+func newJUnitXML(rd *report.Data) func(w io.Writer) Printer {
+	return func(w io.Writer) Printer {
+		return NewJUnitXML(rd, w)
+	}
+}
+
+func TestJUnitXML(t *testing.T) {
+	rd := &report.Data{}
+	rd.AddLinter("govet", true, true)
+	rd.AddLinter("golint", true, false)
+	rd.AddLinter("errcheck", true, true) // enabled linter without issues
+	rd.AddLinter("dupl", false, false)   // disabled linter isn't printed
+
+	assertGolden(t, "junitxml", printIssues(t, newJUnitXML(rd), newTestIssues()[:2]))
+	assertGolden(t, "junitxml_no_issues", printIssues(t, newJUnitXML(&report.Data{}), nil))
+}
@@ -0,0 +1,79 @@
+package printers
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+var update = flag.Bool("update", false, "update golden files of printers")
+
+// newTestIssues returns issues covering fields used by printers
+func newTestIssues() []result.Issue {
+	return []result.Issue{
+		{
+			FromLinter: "govet",
+			Text:       "printf: Sprintf format %s reads arg #1, but call has 0 args",
+			Severity:   result.SeverityError,
+			Pos: token.Position{
+				Filename: filepath.Join("pkg", "a.go"),
+				Line:     10,
+				Column:   5,
+			},
+			SourceLines: []string{`	fmt.Sprintf("%s")`},
+			Fingerprint: "fp1",
+		},
+		{
+			FromLinter:  "golint",
+			Text:        "exported func F should have comment or be unexported",
+			Pos:         token.Position{Filename: "b.go", Line: 3},
+			SourceLines: []string{"func F() {}"},
+			Fingerprint: "fp2",
+		},
+		{
+			FromLinter:  "typecheck",
+			Text:        "undeclared name: x,\ndetails: 100%",
+			Severity:    result.SeverityInfo,
+			Pos:         token.Position{Filename: "c:d.go", Line: 1, Column: 1},
+			Fingerprint: "fp3",
+		},
+	}
+}
+
+// printIssues prints issues by the printer created by newPrinter and returns the output
+func printIssues(t *testing.T, newPrinter func(w io.Writer) Printer, issues []result.Issue) string {
+	var buf bytes.Buffer
+	p := newPrinter(&buf)
+
+	ch := make(chan result.Issue, len(issues))
+	for _, i := range issues {
+		ch <- i
+	}
+	close(ch)
+
+	require.NoError(t, p.Print(context.Background(), ch))
+	return buf.String()
+}
+
+// assertGolden compares output with testdata/<name>.golden: run tests
+// with -update flag to write current output to golden files
+func assertGolden(t *testing.T, name, output string) {
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		require.NoError(t, ioutil.WriteFile(path, []byte(output), 0644))
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), output)
+}
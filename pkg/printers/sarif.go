@@ -0,0 +1,165 @@
+package printers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/golangci/golangci-lint/pkg/report"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const (
+	sarifVersion   = "2.1.0"
+	sarifSchemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolURI   = "https://github.com/golangci/golangci-lint"
+	// sarifDefaultLevel is used for issues without known severity
+	sarifDefaultLevel = "warning"
+)
+
+type sarifReport struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool           `json:"tool"`
+	Results    []sarifResult       `json:"results"`
+	Properties *sarifRunProperties `json:"properties,omitempty"`
+}
+
+// sarifRunProperties is a property bag of the run: SARIF allows any properties in it
+type sarifRunProperties struct {
+	Metadata *report.Metadata `json:"metadata"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"` // column is unknown if it's zero
+}
+
+// SARIF prints issues in SARIF 2.1.0 format: every linter is a rule of the tool.
+type SARIF struct {
+	metadata *report.Metadata
+	w        io.Writer
+}
+
+// NewSARIF creates SARIF printer: metadata is printed only if it's not nil,
+// it's set to properties of the run and its golangci-lint version is the version
+// of the tool.
+func NewSARIF(metadata *report.Metadata, w io.Writer) *SARIF {
+	return &SARIF{
+		metadata: metadata,
+		w:        w,
+	}
+}
+
+func (p SARIF) Print(ctx context.Context, issues <-chan result.Issue) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "golangci-lint",
+				InformationURI: sarifToolURI,
+				Rules:          []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+	if p.metadata != nil {
+		run.Tool.Driver.Version = p.metadata.GolangciVersion
+		run.Properties = &sarifRunProperties{Metadata: p.metadata}
+	}
+
+	linters := map[string]bool{}
+	for i := range issues {
+		linters[i.FromLinter] = true
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:  i.FromLinter,
+			Level:   sarifLevel(i.Severity),
+			Message: sarifMessage{Text: i.Text},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(i.FilePath())},
+					Region: sarifRegion{
+						StartLine:   i.Line(),
+						StartColumn: i.Column(),
+					},
+				},
+			}},
+		})
+	}
+
+	for linter := range linters {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: linter})
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool {
+		return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID
+	})
+
+	outputJSON, err := json.Marshal(sarifReport{
+		Version: sarifVersion,
+		Schema:  sarifSchemaURI,
+		Runs:    []sarifRun{run},
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(p.w, string(outputJSON))
+	return nil
+}
+
+func sarifLevel(severity string) string {
+	switch severity {
+	case result.SeverityError, result.SeverityWarning:
+		return severity
+	case result.SeverityInfo:
+		return "note"
+	}
+
+	return sarifDefaultLevel
+}
@@ -0,0 +1,30 @@
+package printers
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golangci/golangci-lint/pkg/report"
+)
+
+func newSARIF(metadata *report.Metadata) func(w io.Writer) Printer {
+	return func(w io.Writer) Printer {
+		return NewSARIF(metadata, w)
+	}
+}
+
+func TestSARIF(t *testing.T) {
+	assertGolden(t, "sarif", printIssues(t, newSARIF(nil), newTestIssues()))
+	assertGolden(t, "sarif_no_issues", printIssues(t, newSARIF(nil), nil))
+}
+
+func TestSARIFMetadata(t *testing.T) {
+	metadata := &report.Metadata{
+		Git:             &report.GitMetadata{Commit: "0123456789abcdef", Branch: "master"},
+		GoVersion:       "go1.11.5",
+		GolangciVersion: "1.13.0",
+		Timestamp:       time.Date(2019, 2, 3, 4, 5, 6, 0, time.UTC),
+	}
+	assertGolden(t, "sarif_metadata", printIssues(t, newSARIF(metadata), newTestIssues()[:1]))
+}
@@ -4,33 +4,53 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/fatih/color"
 
+	"github.com/golangci/golangci-lint/pkg/config"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
 type Tab struct {
 	printLinterName bool
+	columns         []string
 	log             logutils.Log
+	w               io.Writer
 }
 
-func NewTab(printLinterName bool, log logutils.Log) *Tab {
+// NewTab creates the printer of tab format: if columns are set, issues are printed
+// as tab-separated values of these columns without alignment and colors
+func NewTab(printLinterName bool, columns []string, log logutils.Log, w io.Writer) *Tab {
 	return &Tab{
 		printLinterName: printLinterName,
+		columns:         columns,
 		log:             log,
+		w:               w,
 	}
 }
 
+// tabValueReplacer escapes tabs and newlines to keep one issue per line and fixed columns
+var tabValueReplacer = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
 func (p Tab) SprintfColored(ca color.Attribute, format string, args ...interface{}) string {
 	c := color.New(ca)
 	return c.Sprintf(format, args...)
 }
 
 func (p *Tab) Print(ctx context.Context, issues <-chan result.Issue) error {
-	w := tabwriter.NewWriter(logutils.StdOut, 0, 0, 2, ' ', 0)
+	if len(p.columns) != 0 {
+		for i := range issues {
+			i := i
+			p.printIssueColumns(&i)
+		}
+		return nil
+	}
+
+	w := tabwriter.NewWriter(p.w, 0, 0, 2, ' ', 0)
 
 	for i := range issues {
 		i := i
@@ -46,6 +66,9 @@ func (p *Tab) Print(ctx context.Context, issues <-chan result.Issue) error {
 
 func (p Tab) printIssue(i *result.Issue, w io.Writer) {
 	text := p.SprintfColored(color.FgRed, "%s", i.Text)
+	if i.Severity != "" {
+		text = p.SprintfColored(color.FgRed, "%s: %s", i.Severity, i.Text)
+	}
 	if p.printLinterName {
 		text = fmt.Sprintf("%s\t%s", i.FromLinter, text)
 	}
@@ -57,3 +80,27 @@ func (p Tab) printIssue(i *result.Issue, w io.Writer) {
 
 	fmt.Fprintf(w, "%s\t%s\n", pos, text)
 }
+
+func (p Tab) printIssueColumns(i *result.Issue) {
+	values := make([]string, 0, len(p.columns))
+	for _, col := range p.columns {
+		var v string
+		switch col {
+		case config.TabColumnPath:
+			v = i.FilePath()
+		case config.TabColumnLine:
+			v = strconv.Itoa(i.Line())
+		case config.TabColumnColumn:
+			v = strconv.Itoa(i.Pos.Column)
+		case config.TabColumnLinter:
+			v = i.FromLinter
+		case config.TabColumnSeverity:
+			v = i.Severity
+		case config.TabColumnMessage:
+			v = i.Text
+		}
+		values = append(values, tabValueReplacer.Replace(v))
+	}
+
+	fmt.Fprintln(p.w, strings.Join(values, "\t"))
+}
@@ -0,0 +1,54 @@
+package printers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// Tap prints issues in Test Anything Protocol version 13 format:
+// every issue is a failed test with YAML diagnostic block.
+type Tap struct {
+	w io.Writer
+}
+
+func NewTap(w io.Writer) *Tap {
+	return &Tap{
+		w: w,
+	}
+}
+
+func (p Tap) Print(ctx context.Context, issues <-chan result.Issue) error {
+	var allIssues []result.Issue
+	for i := range issues {
+		allIssues = append(allIssues, i)
+	}
+
+	fmt.Fprintln(p.w, "TAP version 13")
+	if len(allIssues) == 0 {
+		fmt.Fprintln(p.w, "1..0 # no issues")
+		return nil
+	}
+
+	fmt.Fprintf(p.w, "1..%d\n", len(allIssues))
+	for n, i := range allIssues {
+		description := fmt.Sprintf("%s:%d: %s (%s)", i.FilePath(), i.Line(), i.Text, i.FromLinter)
+		fmt.Fprintf(p.w, "not ok %d - %s\n", n+1, tapDescriptionReplacer.Replace(description))
+
+		fmt.Fprintln(p.w, "  ---")
+		fmt.Fprintf(p.w, "  severity: %s\n", severityOrDefault(&i))
+		if i.Column() != 0 {
+			fmt.Fprintf(p.w, "  column: %d\n", i.Column())
+		}
+		fmt.Fprintln(p.w, "  ...")
+	}
+
+	return nil
+}
+
+// tapDescriptionReplacer makes the description of a test one line and escapes "#":
+// otherwise TAP consumers treat the rest of it as a directive like "# SKIP"
+var tapDescriptionReplacer = strings.NewReplacer("\r\n", " ", "\n", " ", "#", `\#`)
@@ -0,0 +1,18 @@
+package printers
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTap(w io.Writer) Printer {
+	return NewTap(w)
+}
+
+func TestTap(t *testing.T) {
+	// column is printed only if it's known
+	assertGolden(t, "tap", printIssues(t, newTap, newTestIssues()))
+	assert.Equal(t, "TAP version 13\n1..0 # no issues\n", printIssues(t, newTap, nil))
+}
@@ -3,6 +3,8 @@ package printers
 import (
 	"context"
 	"fmt"
+	"io"
+	"sort"
 
 	"github.com/fatih/color"
 
@@ -14,16 +16,25 @@ type Text struct {
 	printIssuedLine bool
 	useColors       bool
 	printLinterName bool
+	showCaret       bool
+	grouped         bool
 
 	log logutils.Log
+	w   io.Writer
 }
 
-func NewText(printIssuedLine, useColors, printLinterName bool, log logutils.Log) *Text {
+// NewText creates printer of issues in lines "file:line:col: message (linter)".
+// If grouped is set, issues are sorted and grouped by file: the file is printed once
+// in the header line "file:" and its issues are printed in indented lines "line:col: message (linter)".
+func NewText(printIssuedLine, useColors, printLinterName, showCaret, grouped bool, log logutils.Log, w io.Writer) *Text {
 	return &Text{
 		printIssuedLine: printIssuedLine,
 		useColors:       useColors,
 		printLinterName: printLinterName,
+		showCaret:       showCaret,
+		grouped:         grouped,
 		log:             log,
+		w:               w,
 	}
 }
 
@@ -37,40 +48,96 @@ func (p Text) SprintfColored(ca color.Attribute, format string, args ...interfac
 }
 
 func (p *Text) Print(ctx context.Context, issues <-chan result.Issue) error {
+	if p.grouped {
+		issues = sortIssuesByFile(issues)
+	}
+
+	counts := categoryCounts{}
+	prevFile := ""
 	for i := range issues {
 		i := i
-		p.printIssue(&i)
+		counts.add(&i)
+
+		indent := ""
+		if p.grouped {
+			if i.FilePath() != prevFile {
+				fmt.Fprintf(p.w, "%s:\n", p.SprintfColored(color.Bold, "%s", i.FilePath()))
+				prevFile = i.FilePath()
+			}
+			indent = "  "
+		}
+		p.printIssue(&i, indent)
 
 		if !p.printIssuedLine {
 			continue
 		}
 
-		p.printSourceCode(&i)
-		p.printUnderLinePointer(&i)
+		p.printSourceCode(&i, indent)
+		if p.showCaret {
+			p.printUnderLinePointer(&i, indent)
+		}
+	}
+
+	if len(counts) != 0 {
+		fmt.Fprintf(p.w, "Issues by category: %s\n", counts)
 	}
 
 	return nil
 }
 
-func (p Text) printIssue(i *result.Issue) {
+// sortIssuesByFile reads all issues and returns them sorted by file path and position
+func sortIssuesByFile(issues <-chan result.Issue) <-chan result.Issue {
+	var sorted []result.Issue
+	for i := range issues {
+		sorted = append(sorted, i)
+	}
+
+	sort.SliceStable(sorted, func(a, b int) bool {
+		ia, ib := &sorted[a], &sorted[b]
+		if ia.FilePath() != ib.FilePath() {
+			return ia.FilePath() < ib.FilePath()
+		}
+		if ia.Line() != ib.Line() {
+			return ia.Line() < ib.Line()
+		}
+		return ia.Pos.Column < ib.Pos.Column
+	})
+
+	ch := make(chan result.Issue, len(sorted))
+	for _, i := range sorted {
+		ch <- i
+	}
+	close(ch)
+	return ch
+}
+
+func (p Text) printIssue(i *result.Issue, indent string) {
 	text := p.SprintfColored(color.FgRed, "%s", i.Text)
+	if i.Severity != "" {
+		text = p.SprintfColored(color.FgMagenta, "%s", i.Severity) + ": " + text
+	}
 	if p.printLinterName {
-		text += fmt.Sprintf(" (%s)", i.FromLinter)
+		text += fmt.Sprintf(" (%s)", p.SprintfColored(color.FgCyan, "%s", i.FromLinter))
+	}
+	var pos string
+	if p.grouped {
+		pos = p.SprintfColored(color.Bold, "%d", i.Line())
+	} else {
+		pos = p.SprintfColored(color.Bold, "%s:%d", i.FilePath(), i.Line())
 	}
-	pos := p.SprintfColored(color.Bold, "%s:%d", i.FilePath(), i.Line())
 	if i.Pos.Column != 0 {
 		pos += fmt.Sprintf(":%d", i.Pos.Column)
 	}
-	fmt.Fprintf(logutils.StdOut, "%s: %s\n", pos, text)
+	fmt.Fprintf(p.w, "%s%s: %s\n", indent, pos, text)
 }
 
-func (p Text) printSourceCode(i *result.Issue) {
+func (p Text) printSourceCode(i *result.Issue, indent string) {
 	for _, line := range i.SourceLines {
-		fmt.Fprintln(logutils.StdOut, line)
+		fmt.Fprintln(p.w, indent+line)
 	}
 }
 
-func (p Text) printUnderLinePointer(i *result.Issue) {
+func (p Text) printUnderLinePointer(i *result.Issue, indent string) {
 	// if column == 0 it means column is unknown (e.g. for gosec)
 	if len(i.SourceLines) != 1 || i.Pos.Column == 0 {
 		return
@@ -87,5 +154,5 @@ func (p Text) printUnderLinePointer(i *result.Issue) {
 		}
 	}
 
-	fmt.Fprintf(logutils.StdOut, "%s%s\n", string(prefixRunes), p.SprintfColored(color.FgYellow, "^"))
+	fmt.Fprintf(p.w, "%s%s%s\n", indent, string(prefixRunes), p.SprintfColored(color.FgYellow, "^"))
 }
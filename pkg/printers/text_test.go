@@ -0,0 +1,40 @@
+package printers
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newText(w io.Writer) Printer {
+	return NewText(false, false, true, false, false, logutils.NewStderrLog(""), w)
+}
+
+// newCategorizedIssues returns test issues with categories set by output.categories
+func newCategorizedIssues() []result.Issue {
+	issues := newTestIssues()
+	for i, category := range []string{"bugs", "style", "style"} {
+		issues[i].Category = category
+	}
+	return issues
+}
+
+func TestTextCategories(t *testing.T) {
+	assertGolden(t, "text_categories", printIssues(t, newText, newCategorizedIssues()))
+	assert.NotContains(t, printIssues(t, newText, newTestIssues()), "Issues by category")
+}
+
+func TestCategoryCounts(t *testing.T) {
+	counts := categoryCounts{}
+	for _, category := range []string{"style", "bugs", "security", "style", ""} {
+		counts.add(&result.Issue{Category: category})
+	}
+
+	// sorted by count, then by name; issues without category aren't counted
+	assert.Equal(t, "2 style, 1 bugs, 1 security", counts.String())
+	assert.Empty(t, categoryCounts{}.String())
+}
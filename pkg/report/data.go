@@ -1,5 +1,7 @@
 package report
 
+import "time"
+
 type Warning struct {
 	Tag  string `json:",omitempty"`
 	Text string
@@ -24,3 +26,25 @@ func (d *Data) AddLinter(name string, enabled, enabledByDefault bool) {
 		EnabledByDefault: enabledByDefault,
 	})
 }
+
+// Summary describes results of the run for dashboards: it counts only
+// printed issues, issues hidden by processors aren't counted.
+type Summary struct {
+	Issues         int
+	IssuesByLinter map[string]int
+	Packages       int
+	ElapsedMs      int64
+}
+
+type GitMetadata struct {
+	Commit string
+	Branch string `json:",omitempty"`
+}
+
+// Metadata describes the run for archived reports.
+type Metadata struct {
+	Git             *GitMetadata `json:",omitempty"` // nil if not in a git repo
+	GoVersion       string
+	GolangciVersion string
+	Timestamp       time.Time
+}
@@ -0,0 +1,37 @@
+package result
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// FingerprintWindow is the count of source lines before and after lines
+// of the issue which are used in its fingerprint
+const FingerprintWindow = 1
+
+var numberRe = regexp.MustCompile(`[0-9]+`)
+
+// NormalizeText removes numbers and extra spaces from the issue text:
+// texts often contain line numbers of related code or sizes which drift
+func NormalizeText(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	return numberRe.ReplaceAllString(text, "N")
+}
+
+// Fingerprint returns a stable id of the issue which doesn't depend on its line number:
+// it's hex-encoded sha256 of the path with slashes, linter name, normalized text and
+// source lines with trimmed spaces, every value is followed by a zero byte.
+// Source lines are lines of the issue and FingerprintWindow lines around them.
+func Fingerprint(path, linter, text string, sourceLines []string) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s\x00", filepath.ToSlash(path), linter, NormalizeText(text))
+	for _, line := range sourceLines {
+		fmt.Fprintf(h, "%s\x00", strings.TrimSpace(line))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -2,10 +2,31 @@ package result
 
 import "go/token"
 
+// Severities of issues: issue has empty severity if severity option isn't set
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
 type Range struct {
 	From, To int
 }
 
+// Replacement is a fix of the issue: Length bytes of the file
+// starting at byte Offset are replaced by NewText
+type Replacement struct {
+	Offset  int
+	Length  int
+	NewText string
+}
+
+// SourceContext contains source lines around lines of the issue
+type SourceContext struct {
+	Before []string `json:",omitempty"`
+	After  []string `json:",omitempty"`
+}
+
 type Issue struct {
 	FromLinter string
 	Text       string
@@ -13,8 +34,16 @@ type Issue struct {
 	Pos       token.Position
 	LineRange *Range `json:",omitempty"`
 	HunkPos   int    `json:",omitempty"`
+	IsOld     bool   `json:",omitempty"` // issue existed before the compared revision
+	IsTest    bool   `json:",omitempty"` // issue is in a test file (*_test.go)
+	Category  string `json:",omitempty"` // set only if output.categories option is set
+	Severity  string `json:",omitempty"` // set only if severity option is set
+
+	Replacement *Replacement `json:",omitempty"` // set only if the linter can fix the issue
 
-	SourceLines []string
+	SourceLines   []string       `json:",omitempty"`
+	SourceContext *SourceContext `json:",omitempty"` // set only if output.source-context-lines option is set
+	Fingerprint   string         `json:",omitempty"` // stable id of the issue, see Fingerprint function
 }
 
 func (i Issue) FilePath() string {
@@ -46,3 +75,20 @@ func (i Issue) GetLineRange() Range {
 
 	return *i.LineRange
 }
+
+// GetFingerprint returns the fingerprint set by the fingerprint processor: if it isn't
+// set, e.g. the issue wasn't processed by the runner, source lines aren't used in it
+func (i Issue) GetFingerprint() string {
+	if i.Fingerprint != "" {
+		return i.Fingerprint
+	}
+
+	return Fingerprint(i.FilePath(), i.FromLinter, i.Text, nil)
+}
+
+// SuppressedIssue is an issue hidden by a nolint directive at DirectivePos.
+// DirectivePos is empty for issues hidden by issues.exclude-at option.
+type SuppressedIssue struct {
+	Issue
+	DirectivePos token.Position
+}
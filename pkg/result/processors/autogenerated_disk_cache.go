@@ -0,0 +1,133 @@
+package processors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+type autogenDiskCacheEntry struct {
+	ModTime     time.Time
+	Size        int64
+	IsGenerated bool
+}
+
+type autogenDiskCacheData struct {
+	Settings string // detection settings: results are invalid if they are changed
+	Files    map[string]autogenDiskCacheEntry
+}
+
+// AutogenDiskCache keeps results of generated files detection between runs:
+// an entry is reused only if modification time and size of the file are the same.
+type AutogenDiskCache struct {
+	path    string
+	data    autogenDiskCacheData
+	changed bool
+	log     logutils.Log
+}
+
+func NewAutogenDiskCache(path string, log logutils.Log) *AutogenDiskCache {
+	c := &AutogenDiskCache{
+		path: path,
+		log:  log,
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err == nil {
+		err = json.Unmarshal(data, &c.data)
+	}
+	if err != nil && !os.IsNotExist(err) {
+		log.Warnf("Can't read autogenerated files cache %s, ignore it: %s", path, err)
+		c.data = autogenDiskCacheData{}
+	}
+
+	if c.data.Files == nil {
+		c.data.Files = map[string]autogenDiskCacheEntry{}
+	}
+	return c
+}
+
+func (c *AutogenDiskCache) setSettings(settings string) {
+	if c.data.Settings == settings {
+		return
+	}
+
+	c.data.Settings = settings
+	c.data.Files = map[string]autogenDiskCacheEntry{}
+	c.changed = true
+}
+
+func (c *AutogenDiskCache) get(filePath string) (isGenerated, ok bool) {
+	key, fi, err := c.stat(filePath)
+	if err != nil {
+		return false, false
+	}
+
+	e, ok := c.data.Files[key]
+	if !ok || !e.ModTime.Equal(fi.ModTime()) || e.Size != fi.Size() {
+		return false, false
+	}
+
+	return e.IsGenerated, true
+}
+
+func (c *AutogenDiskCache) set(filePath string, isGenerated bool) {
+	key, fi, err := c.stat(filePath)
+	if err != nil {
+		return
+	}
+
+	c.data.Files[key] = autogenDiskCacheEntry{
+		ModTime:     fi.ModTime(),
+		Size:        fi.Size(),
+		IsGenerated: isGenerated,
+	}
+	c.changed = true
+}
+
+func (c *AutogenDiskCache) stat(filePath string) (string, os.FileInfo, error) {
+	key, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	fi, err := os.Stat(key)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return key, fi, nil
+}
+
+func (c *AutogenDiskCache) save() {
+	if !c.changed {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), os.ModePerm); err != nil {
+		c.log.Warnf("Can't create dir for autogenerated files cache: %s", err)
+		return
+	}
+
+	err := fsutils.WriteFileAtomically(c.path, func(w io.Writer) error {
+		return json.NewEncoder(w).Encode(c.data)
+	})
+	if err != nil {
+		c.log.Warnf("Can't save autogenerated files cache: %s", err)
+		return
+	}
+
+	c.changed = false
+}
+
+// autogenSettingsKey returns key of settings affecting detection by file contents.
+func autogenSettingsKey(generatedTag string, markers []string, strict, scanFullFile bool) string {
+	return fmt.Sprintf("tag=%q markers=%q strict=%t full=%t", generatedTag, markers, strict, scanFullFile)
+}
@@ -4,8 +4,13 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"path/filepath"
+	"regexp"
 	"strings"
 
+	"github.com/pkg/errors"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
 	"github.com/golangci/golangci-lint/pkg/lint/astcache"
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
@@ -15,6 +20,7 @@ var autogenDebugf = logutils.Debug("autogen_exclude")
 
 type ageFileSummary struct {
 	isGenerated bool
+	reason      string // why the file is or isn't generated
 }
 
 type ageFileSummaryCache map[string]*ageFileSummary
@@ -22,13 +28,88 @@ type ageFileSummaryCache map[string]*ageFileSummary
 type AutogeneratedExclude struct {
 	fileSummaryCache ageFileSummaryCache
 	astCache         *astcache.Cache
+	generatedDirs    []string
+	generatedFiles   []string
+	neverGenerated   []string
+	generatedTag     string
+	markers          []string
+	strict           bool
+	scanFullFile     bool
+	diskCache        *AutogenDiskCache
+	log              logutils.Log
 }
 
-func NewAutogeneratedExclude(astCache *astcache.Cache) *AutogeneratedExclude {
+// AutogeneratedExcludeSettings configures which files AutogeneratedExclude treats
+// as generated.
+type AutogeneratedExcludeSettings struct {
+	GeneratedDirs  []string // globs of dirs with generated files
+	GeneratedFiles []string // globs with "**" support matching generated files without parsing
+	NeverGenerated []string // globs of files which are never treated as generated
+	GeneratedTag   string   // files requiring this build tag are generated
+
+	// ExtraMarkers are case-insensitive substrings of file header comments marking
+	// generated files in addition to the default ones.
+	ExtraMarkers []string
+
+	// If Strict is set, markers aren't used and only files with the standard
+	// generated code comment are treated as generated.
+	Strict bool
+
+	// If ScanFullFile is set and Strict isn't, comments after header comments are
+	// searched for markers too when header comments don't contain them.
+	ScanFullFile bool
+
+	// Optional DiskCache keeps results of parsing of files between runs.
+	DiskCache *AutogenDiskCache
+}
+
+// NewAutogeneratedExclude creates processor excluding issues from generated files
+// configured by settings. Detection results are logged for every file with issues.
+func NewAutogeneratedExclude(astCache *astcache.Cache, settings AutogeneratedExcludeSettings,
+	log logutils.Log) (*AutogeneratedExclude, error) {
+
+	markers := append([]string{}, defaultAutogenMarkers...)
+	for _, m := range settings.ExtraMarkers {
+		if m == "" {
+			return nil, errors.New("empty autogenerated file marker")
+		}
+		markers = append(markers, strings.ToLower(m))
+	}
+
+	for _, pattern := range settings.GeneratedDirs {
+		if err := fsutils.ValidateGlob(pattern); err != nil {
+			return nil, errors.Wrapf(err, "invalid generated dir glob %q", pattern)
+		}
+	}
+	for _, pattern := range settings.GeneratedFiles {
+		if err := fsutils.ValidateGlob(pattern); err != nil {
+			return nil, errors.Wrapf(err, "invalid generated file glob %q", pattern)
+		}
+	}
+	for _, pattern := range settings.NeverGenerated {
+		if err := fsutils.ValidateGlob(pattern); err != nil {
+			return nil, errors.Wrapf(err, "invalid never generated file glob %q", pattern)
+		}
+	}
+
+	// new settings clear the disk cache: set them only after validation
+	if settings.DiskCache != nil {
+		settings.DiskCache.setSettings(autogenSettingsKey(settings.GeneratedTag, markers, settings.Strict, settings.ScanFullFile))
+	}
+
 	return &AutogeneratedExclude{
 		fileSummaryCache: ageFileSummaryCache{},
 		astCache:         astCache,
-	}
+		generatedDirs:    settings.GeneratedDirs,
+		generatedFiles:   settings.GeneratedFiles,
+		neverGenerated:   settings.NeverGenerated,
+		generatedTag:     settings.GeneratedTag,
+		markers:          markers,
+		strict:           settings.Strict,
+		scanFullFile:     settings.ScanFullFile,
+		diskCache:        settings.DiskCache,
+		log:              log,
+	}, nil
 }
 
 var _ Processor = &AutogeneratedExclude{}
@@ -51,27 +132,27 @@ func (p *AutogeneratedExclude) shouldPassIssue(i *result.Issue) (bool, error) {
 	return !fs.isGenerated, nil
 }
 
+var defaultAutogenMarkers = []string{
+	"code generated",
+	"do not edit",
+	"autogenerated file", // easyjson
+}
+
 // isGenerated reports whether the source file is generated code.
 // Using a bit laxer rules than https://golang.org/s/generatedcode to
 // match more generated code. See #48 and #72.
-func isGeneratedFileByComment(doc string) bool {
-	const (
-		genCodeGenerated = "code generated"
-		genDoNotEdit     = "do not edit"
-		genAutoFile      = "autogenerated file" // easyjson
-	)
-
-	markers := []string{genCodeGenerated, genDoNotEdit, genAutoFile}
+// Markers must be in lower case. The matched marker is returned.
+func isGeneratedFileByComment(doc string, markers []string) (string, bool) {
 	doc = strings.ToLower(doc)
 	for _, marker := range markers {
 		if strings.Contains(doc, marker) {
 			autogenDebugf("doc contains marker %q: file is generated", marker)
-			return true
+			return marker, true
 		}
 	}
 
 	autogenDebugf("doc of len %d doesn't contain any of markers: %s", len(doc), markers)
-	return false
+	return "", false
 }
 
 func (p *AutogeneratedExclude) getOrCreateFileSummary(i *result.Issue) (*ageFileSummary, error) {
@@ -80,52 +161,357 @@ func (p *AutogeneratedExclude) getOrCreateFileSummary(i *result.Issue) (*ageFile
 		return fs, nil
 	}
 
-	fs = &ageFileSummary{}
-	p.fileSummaryCache[i.FilePath()] = fs
-
 	if i.FilePath() == "" {
 		return nil, fmt.Errorf("no file path for issue")
 	}
 
-	f := p.astCache.GetOrParse(i.FilePath(), nil)
+	isGenerated, reason, err := p.detectGenerated(i.FilePath())
+	if err != nil {
+		return nil, err
+	}
+
+	fs = &ageFileSummary{
+		isGenerated: isGenerated,
+		reason:      reason,
+	}
+	p.fileSummaryCache[i.FilePath()] = fs
+
+	if fs.isGenerated {
+		p.log.Infof("File %s is generated, its issues are hidden: %s", i.FilePath(), fs.reason)
+	} else {
+		p.log.Infof("File %s isn't generated: %s", i.FilePath(), fs.reason)
+	}
+	return fs, nil
+}
+
+// detectGenerated reports whether the file is generated and why
+func (p *AutogeneratedExclude) detectGenerated(filePath string) (bool, string, error) {
+	if p.isNeverGenerated(filePath) {
+		// don't parse files from the allowlist: they can contain markers in docs
+		return false, "it matches never generated files globs", nil
+	}
+
+	if p.isGeneratedByPath(filePath) {
+		// don't parse files matching generated files globs: save parse time
+		return true, "it matches generated files globs", nil
+	}
+
+	if p.isInGeneratedDir(filePath) {
+		// don't parse files from generated dirs: all of them are generated
+		return true, "it's in generated dir", nil
+	}
+
+	if p.diskCache != nil {
+		if isGenerated, ok := p.diskCache.get(filePath); ok {
+			return isGenerated, "detection result is taken from autogen cache", nil
+		}
+	}
+
+	isGenerated, reason, err := p.isGeneratedByContents(filePath)
+	if err != nil {
+		return false, "", err
+	}
+
+	if p.diskCache != nil {
+		p.diskCache.set(filePath, isGenerated)
+	}
+	return isGenerated, reason, nil
+}
+
+func (p *AutogeneratedExclude) isGeneratedByContents(filePath string) (bool, string, error) {
+	f := p.astCache.GetOrParse(filePath, nil)
 	if f.Err != nil {
-		return nil, fmt.Errorf("can't parse file %s: %s", i.FilePath(), f.Err)
+		return false, "", fmt.Errorf("can't parse file %s: %s", filePath, f.Err)
 	}
 
-	autogenDebugf("file %q: astcache file is %+v", i.FilePath(), *f)
+	autogenDebugf("file %q: astcache file is %+v", filePath, *f)
 
-	doc := getDoc(f.F, f.Fset, i.FilePath())
+	if p.generatedTag != "" && hasBuildTag(f.F, p.generatedTag) {
+		return true, fmt.Sprintf("it has build tag %q", p.generatedTag), nil
+	}
 
-	fs.isGenerated = isGeneratedFileByComment(doc)
-	autogenDebugf("file %q is generated: %t", i.FilePath(), fs.isGenerated)
-	return fs, nil
+	if p.strict {
+		if comment, ok := getGeneratedCodeComment(f.F); ok {
+			return true, fmt.Sprintf("it has generated code comment %q", comment), nil
+		}
+		return false, "it has no generated code comment", nil
+	}
+
+	doc := getDoc(f.F, f.Fset, filePath)
+	if marker, ok := isGeneratedFileByComment(doc, p.markers); ok {
+		return true, fmt.Sprintf("its header comments contain marker %q", marker), nil
+	}
+
+	if !p.scanFullFile {
+		return false, "its header comments don't contain any of markers", nil
+	}
+
+	// scan other comments only on a miss: they can be much longer than header ones
+	trailingDoc := getTrailingDoc(f.F, f.Fset, filePath)
+	if marker, ok := isGeneratedFileByComment(trailingDoc, p.markers); ok {
+		return true, fmt.Sprintf("its comments after header comments contain marker %q", marker), nil
+	}
+	return false, "its comments don't contain any of markers", nil
 }
 
-func getDoc(f *ast.File, fset *token.FileSet, filePath string) string {
-	// don't use just f.Doc: e.g. mockgen leaves extra line between comment and package name
+// generatedCodeRe matches the line marking generated code by https://golang.org/s/generatedcode
+var generatedCodeRe = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// getGeneratedCodeComment returns the line comment exactly matching
+// the standard generated code comment before package clause.
+func getGeneratedCodeComment(f *ast.File) (string, bool) {
+	for _, g := range f.Comments {
+		if g.Pos() > f.Package {
+			break
+		}
+
+		for _, c := range g.List {
+			// files using cgo have implicitly added comment "Code generated by cmd/cgo; DO NOT EDIT."
+			if strings.HasPrefix(c.Text, "// Code generated by cmd/cgo") {
+				continue
+			}
+
+			if generatedCodeRe.MatchString(c.Text) {
+				return c.Text, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func (p *AutogeneratedExclude) isNeverGenerated(filePath string) bool {
+	for _, pattern := range p.neverGenerated {
+		if fsutils.MatchGlob(pattern, filePath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *AutogeneratedExclude) isGeneratedByPath(filePath string) bool {
+	for _, pattern := range p.generatedFiles {
+		if fsutils.MatchGlob(pattern, filePath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isInGeneratedDir reports whether any parent dir of the file matches
+// one of the configured generated dirs globs with "**" support.
+func (p *AutogeneratedExclude) isInGeneratedDir(filePath string) bool {
+	if len(p.generatedDirs) == 0 {
+		return false
+	}
+
+	dir := filepath.ToSlash(filepath.Dir(filePath))
+	for dir != "." && dir != "/" && dir != "" {
+		for _, pattern := range p.generatedDirs {
+			if fsutils.MatchGlob(pattern, dir) {
+				return true
+			}
+		}
+
+		newDir := filepath.ToSlash(filepath.Dir(dir))
+		if newDir == dir {
+			break
+		}
+		dir = newDir
+	}
+
+	return false
+}
+
+// hasBuildTag reports whether build constraints of the file
+// (//go:build or // +build lines) require the given tag: the file
+// can't be built without it. Constraint lines are ANDed, so it's enough
+// that one line requires the tag, e.g. "// +build generated,linux" requires it
+// but "// +build generated linux" doesn't.
+func hasBuildTag(f *ast.File, tag string) bool {
+	for _, g := range f.Comments {
+		if g.Pos() > f.Package {
+			break // build constraints must be before package clause
+		}
+
+		for _, c := range g.List {
+			switch {
+			case strings.HasPrefix(c.Text, "//go:build "):
+				if goBuildExprRequiresTag(strings.TrimPrefix(c.Text, "//go:build "), tag) {
+					return true
+				}
+			case strings.HasPrefix(c.Text, "// +build "):
+				if plusBuildLineRequiresTag(strings.TrimPrefix(c.Text, "// +build "), tag) {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// plusBuildLineRequiresTag reports whether the tag is in every space-separated
+// option of the "// +build" line: options are ORed, comma-separated terms of
+// an option are ANDed.
+func plusBuildLineRequiresTag(line, tag string) bool {
+	options := strings.Fields(line)
+	if len(options) == 0 {
+		return false
+	}
+
+	for _, opt := range options {
+		hasTag := false
+		for _, term := range strings.Split(opt, ",") {
+			if term == tag {
+				hasTag = true
+				break
+			}
+		}
+		if !hasTag {
+			return false
+		}
+	}
+
+	return true
+}
+
+// goBuildExprRequiresTag reports whether the "//go:build" expression is false
+// without the tag: the tag must be ANDed into every || alternative.
+// Negated tags don't require the tag, malformed expressions don't require anything.
+func goBuildExprRequiresTag(expr, tag string) bool {
+	p := buildExprParser{tokens: tokenizeBuildExpr(expr), tag: tag, ok: true}
+	requires := p.parseOr()
+	return requires && p.ok && p.pos == len(p.tokens)
+}
+
+// tokens of operators of a "//go:build" expression
+const (
+	buildExprOr     = "||"
+	buildExprAnd    = "&&"
+	buildExprNot    = "!"
+	buildExprLParen = "("
+	buildExprRParen = ")"
+)
+
+func tokenizeBuildExpr(expr string) []string {
+	var tokens []string
+	for len(expr) != 0 {
+		switch {
+		case expr[0] == ' ' || expr[0] == '\t':
+			expr = expr[1:]
+		case strings.HasPrefix(expr, buildExprAnd) || strings.HasPrefix(expr, buildExprOr):
+			tokens = append(tokens, expr[:2])
+			expr = expr[2:]
+		case strings.ContainsRune("()!", rune(expr[0])):
+			tokens = append(tokens, expr[:1])
+			expr = expr[1:]
+		default:
+			n := strings.IndexAny(expr, " \t()!&|")
+			if n == -1 {
+				n = len(expr)
+			} else if n == 0 { // single & or |
+				n = 1
+			}
+			tokens = append(tokens, expr[:n])
+			expr = expr[n:]
+		}
+	}
+
+	return tokens
+}
+
+// buildExprParser parses a "//go:build" expression and evaluates whether it requires the tag
+type buildExprParser struct {
+	tokens []string
+	pos    int
+	tag    string
+	ok     bool
+}
+
+func (p *buildExprParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
 
-	var importPos token.Pos
+func (p *buildExprParser) parseOr() bool {
+	requires := p.parseAnd()
+	for p.peek() == buildExprOr {
+		p.pos++
+		// every alternative must require the tag
+		alt := p.parseAnd()
+		requires = requires && alt
+	}
+	return requires
+}
+
+func (p *buildExprParser) parseAnd() bool {
+	requires := p.parseNot()
+	for p.peek() == buildExprAnd {
+		p.pos++
+		// it's enough that one of ANDed terms requires the tag
+		term := p.parseNot()
+		requires = requires || term
+	}
+	return requires
+}
+
+func (p *buildExprParser) parseNot() bool {
+	tok := p.peek()
+	p.pos++
+	switch tok {
+	case buildExprNot:
+		p.parseNot()
+		return false
+	case buildExprLParen:
+		requires := p.parseOr()
+		if p.peek() != buildExprRParen {
+			p.ok = false
+			return false
+		}
+		p.pos++
+		return requires
+	case "", buildExprRParen, buildExprAnd, buildExprOr, "&", "|":
+		p.ok = false
+		return false
+	default:
+		return tok == p.tag
+	}
+}
+
+// getFirstImportPos returns the position until which header comments are searched
+func getFirstImportPos(f *ast.File) token.Pos {
 	if len(f.Imports) != 0 {
-		importPos = f.Imports[0].Pos()
-		autogenDebugf("file %q: search comments until first import pos %d (%s)",
-			filePath, importPos, fset.Position(importPos))
-	} else {
-		importPos = f.End()
-		autogenDebugf("file %q: search comments until EOF pos %d (%s)",
-			filePath, importPos, fset.Position(importPos))
+		return f.Imports[0].Pos()
 	}
 
+	return f.End()
+}
+
+// isCgoGeneratedComment reports whether the comment is implicitly added to files using cgo:
+// "Created by cgo - DO NOT EDIT" for go <= 1.10 and "Code generated by cmd/cgo" for go >= 1.11
+func isCgoGeneratedComment(text string) bool {
+	return strings.Contains(text, "Created by cgo") || strings.Contains(text, "Code generated by cmd/cgo")
+}
+
+func getDoc(f *ast.File, fset *token.FileSet, filePath string) string {
+	// don't use just f.Doc: e.g. mockgen leaves extra line between comment and package name
+
+	importPos := getFirstImportPos(f)
+	autogenDebugf("file %q: search comments until first import or EOF pos %d (%s)",
+		filePath, importPos, fset.Position(importPos))
+
 	var neededComments []string
 	for _, g := range f.Comments {
 		pos := g.Pos()
 		filePos := fset.Position(pos)
 		text := g.Text()
 
-		// files using cgo have implicitly added comment "Created by cgo - DO NOT EDIT" for go <= 1.10
-		// and "Code generated by cmd/cgo" for go >= 1.11
-		isCgoGenerated := strings.Contains(text, "Created by cgo") || strings.Contains(text, "Code generated by cmd/cgo")
-
-		isAllowed := pos < importPos && filePos.Column == 1 && !isCgoGenerated
+		isAllowed := pos < importPos && filePos.Column == 1 && !isCgoGeneratedComment(text)
 		if isAllowed {
 			autogenDebugf("file %q: pos=%d, filePos=%s: comment %q: it's allowed", filePath, pos, filePos, text)
 			neededComments = append(neededComments, text)
@@ -143,4 +529,30 @@ func getDoc(f *ast.File, fset *token.FileSet, filePath string) string {
 	return strings.Join(neededComments, "\n")
 }
 
-func (p AutogeneratedExclude) Finish() {}
+// getTrailingDoc returns comments which aren't returned by getDoc: comments after
+// the first import, e.g. footer comments, and comments not starting lines.
+// Comments added by cgo are still skipped.
+func getTrailingDoc(f *ast.File, fset *token.FileSet, filePath string) string {
+	importPos := getFirstImportPos(f)
+
+	var neededComments []string
+	for _, g := range f.Comments {
+		if g.Pos() < importPos && fset.Position(g.Pos()).Column == 1 {
+			continue // it's a header comment
+		}
+
+		text := g.Text()
+		if !isCgoGeneratedComment(text) {
+			neededComments = append(neededComments, text)
+		}
+	}
+
+	autogenDebugf("file %q: got %d trailing comments", filePath, len(neededComments))
+	return strings.Join(neededComments, "\n")
+}
+
+func (p AutogeneratedExclude) Finish() {
+	if p.diskCache != nil {
+		p.diskCache.save()
+	}
+}
@@ -1,10 +1,19 @@
 package processors
 
 import (
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/lint/astcache"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
 )
 
 func TestIsAutogeneratedDetection(t *testing.T) {
@@ -53,7 +62,7 @@ func TestIsAutogeneratedDetection(t *testing.T) {
 
 	generatedCases := strings.Split(all, "\n\n")
 	for _, gc := range generatedCases {
-		isGenerated := isGeneratedFileByComment(gc)
+		_, isGenerated := isGeneratedFileByComment(gc, defaultAutogenMarkers)
 		assert.True(t, isGenerated)
 	}
 
@@ -62,7 +71,276 @@ func TestIsAutogeneratedDetection(t *testing.T) {
 		"test",
 	}
 	for _, ngc := range notGeneratedCases {
-		isGenerated := isGeneratedFileByComment(ngc)
+		_, isGenerated := isGeneratedFileByComment(ngc, defaultAutogenMarkers)
 		assert.False(t, isGenerated)
 	}
 }
+
+func TestGeneratedDirs(t *testing.T) {
+	log := logutils.NewStderrLog("")
+	p, err := NewAutogeneratedExclude(nil, AutogeneratedExcludeSettings{
+		GeneratedDirs: []string{"gen", "api/*/gen", "services/**/gen"},
+	}, log)
+	assert.NoError(t, err)
+
+	// files aren't parsed: ast cache is nil and files don't exist
+	processAssertEmpty(t, p,
+		newFileIssue("gen/a.go"),
+		newFileIssue("gen/sub/a.go"),
+		newFileIssue("api/v1/gen/a.go"),
+		newFileIssue("api/v1/gen/sub/a.go"),
+		newFileIssue("services/gen/a.go"),
+		newFileIssue("services/a/b/gen/sub/a.go"))
+
+	assert.False(t, p.isInGeneratedDir("api/v1/beta/gen/a.go"))
+	assert.False(t, p.isInGeneratedDir("services/a/a.go"))
+	assert.False(t, p.isInGeneratedDir("pkg/gen/a.go"))
+}
+
+func TestGeneratedDirsInvalidPattern(t *testing.T) {
+	log := logutils.NewStderrLog("")
+	p, err := NewAutogeneratedExclude(nil, AutogeneratedExcludeSettings{GeneratedDirs: []string{"[gen"}}, log)
+	assert.Error(t, err)
+	assert.Nil(t, p)
+}
+
+func TestNeverGenerated(t *testing.T) {
+	log := logutils.NewStderrLog("")
+	p, err := NewAutogeneratedExclude(nil, AutogeneratedExcludeSettings{
+		GeneratedDirs:  []string{"gen"},
+		NeverGenerated: []string{"gen/handwritten.go", "docs/*.go", "gen/**/*_handwritten.go"},
+	}, log)
+	assert.NoError(t, err)
+
+	// files aren't parsed: ast cache is nil and files don't exist
+	processAssertSame(t, p, newFileIssue("gen/handwritten.go"))
+	processAssertSame(t, p, newFileIssue("docs/a.go"))
+	processAssertSame(t, p, newFileIssue("gen/a_handwritten.go"))
+	processAssertSame(t, p, newFileIssue("gen/sub/a_handwritten.go"))
+	processAssertEmpty(t, p, newFileIssue("gen/a.go"))
+}
+
+func TestNeverGeneratedInvalidPattern(t *testing.T) {
+	log := logutils.NewStderrLog("")
+	p, err := NewAutogeneratedExclude(nil, AutogeneratedExcludeSettings{NeverGenerated: []string{"[a.go"}}, log)
+	assert.Error(t, err)
+	assert.Nil(t, p)
+}
+
+func TestGeneratedBuildTag(t *testing.T) {
+	log := logutils.NewStderrLog("")
+	p, err := NewAutogeneratedExclude(astcache.NewCache(log), AutogeneratedExcludeSettings{
+		GeneratedTag: "generated",
+	}, log)
+	assert.NoError(t, err)
+
+	processAssertEmpty(t, p, newFileIssue(filepath.Join("testdata", "autogenerated_build_tag.go")))
+	processAssertSame(t, p, newFileIssue(filepath.Join("testdata", "autogenerated_build_tag_negated.go")))
+}
+
+func TestHasBuildTag(t *testing.T) {
+	cases := map[string]bool{
+		"//go:build generated":                            true,
+		"// +build generated":                             true,
+		"//go:build generated && linux":                   true,
+		"// +build generated,linux":                       true,
+		"//go:build (generated || gen) && linux":          false,
+		"//go:build (generated && a) || (b && generated)": true,
+		"//go:build generated || linux":                   false,
+		"// +build generated linux":                       false,
+		"// +build generated,a generated,b":               true,
+		"//go:build !generated":                           false,
+		"// +build !generated":                            false,
+		"//go:build !(generated || linux)":                false,
+		"//go:build generatedx":                           false,
+		"//go:build generated &&":                         false,
+		"//go:build (generated":                           false,
+		"// +build linux\n// +build generated":            true,
+	}
+	for constraints, expected := range cases {
+		src := constraints + "\n\npackage p\n\n// +build generated\n"
+		f, err := parser.ParseFile(token.NewFileSet(), "p.go", src, parser.ParseComments)
+		assert.NoError(t, err, constraints)
+		assert.Equal(t, expected, hasBuildTag(f, "generated"), constraints)
+	}
+}
+
+func TestGeneratedBuildTagDisabled(t *testing.T) {
+	log := logutils.NewStderrLog("")
+	p, err := NewAutogeneratedExclude(astcache.NewCache(log), AutogeneratedExcludeSettings{}, log)
+	assert.NoError(t, err)
+
+	processAssertSame(t, p, newFileIssue(filepath.Join("testdata", "autogenerated_build_tag.go")))
+}
+
+func TestExtraAutogenMarkers(t *testing.T) {
+	log := logutils.NewStderrLog("")
+	p, err := NewAutogeneratedExclude(astcache.NewCache(log), AutogeneratedExcludeSettings{
+		ExtraMarkers: []string{"@Generated by"},
+	}, log)
+	assert.NoError(t, err)
+
+	processAssertEmpty(t, p, newFileIssue(filepath.Join("testdata", "autogenerated_custom_marker.go")))
+	processAssertSame(t, p, newFileIssue(filepath.Join("testdata", "autogenerated_custom_marker_in_code.go")))
+}
+
+func TestAutogenScanFullFile(t *testing.T) {
+	log := logutils.NewStderrLog("")
+	footerFile := newFileIssue(filepath.Join("testdata", "autogenerated_footer.go"))
+
+	p, err := NewAutogeneratedExclude(astcache.NewCache(log), AutogeneratedExcludeSettings{}, log)
+	assert.NoError(t, err)
+	processAssertSame(t, p, footerFile)
+
+	p, err = NewAutogeneratedExclude(astcache.NewCache(log), AutogeneratedExcludeSettings{ScanFullFile: true}, log)
+	assert.NoError(t, err)
+	processAssertEmpty(t, p, footerFile)
+	processAssertSame(t, p, newFileIssue(filepath.Join("testdata", "autogenerated_custom_marker_in_code.go")))
+
+	fs, err := p.getOrCreateFileSummary(&footerFile)
+	assert.NoError(t, err)
+	assert.Equal(t, `its comments after header comments contain marker "do not edit"`, fs.reason)
+
+	// markers aren't used in strict mode
+	p, err = NewAutogeneratedExclude(astcache.NewCache(log), AutogeneratedExcludeSettings{
+		Strict:       true,
+		ScanFullFile: true,
+	}, log)
+	assert.NoError(t, err)
+	processAssertSame(t, p, footerFile)
+}
+
+func TestGetGeneratedCodeComment(t *testing.T) {
+	cases := []struct {
+		src         string
+		isGenerated bool
+	}{
+		{"// Code generated by stringer -type Pill; DO NOT EDIT.\n\npackage p\n", true},
+		{"// Copyright\n\n// Code generated by protoc-gen-go. DO NOT EDIT.\n// source: a.proto\n\npackage p\n", true},
+		{"// Code generated by stringer; DO NOT EDIT\n\npackage p\n", false},
+		{"// Code generated by stringer; DO NOT EDIT. Really.\n\npackage p\n", false},
+		{"/* Code generated by stringer; DO NOT EDIT. */\n\npackage p\n", false},
+		{"// code generated by stringer; do not edit.\n\npackage p\n", false},
+		{"// Code generated by cmd/cgo; DO NOT EDIT.\n\npackage p\n", false},
+		{"package p\n\n// Code generated by stringer; DO NOT EDIT.\n", false},
+	}
+
+	for _, c := range cases {
+		f, err := parser.ParseFile(token.NewFileSet(), "a.go", c.src, parser.ParseComments)
+		assert.NoError(t, err)
+		_, isGenerated := getGeneratedCodeComment(f)
+		assert.Equal(t, c.isGenerated, isGenerated, c.src)
+	}
+}
+
+func TestStrictAutogenMode(t *testing.T) {
+	log := logutils.NewStderrLog("")
+	p, err := NewAutogeneratedExclude(astcache.NewCache(log), AutogeneratedExcludeSettings{
+		GeneratedTag: "generated",
+		ExtraMarkers: []string{"@Generated by"},
+		Strict:       true,
+	}, log)
+	assert.NoError(t, err)
+
+	processAssertEmpty(t, p, newFileIssue(filepath.Join("testdata", "autogenerated_strict.go")))
+	processAssertEmpty(t, p, newFileIssue(filepath.Join("testdata", "autogenerated_build_tag.go")))
+	processAssertSame(t, p, newFileIssue(filepath.Join("testdata", "autogenerated_lax.go")))
+	processAssertSame(t, p, newFileIssue(filepath.Join("testdata", "autogenerated_custom_marker.go")))
+}
+
+func TestAutogenDetectionReason(t *testing.T) {
+	log := logutils.NewStderrLog("")
+	p, err := NewAutogeneratedExclude(astcache.NewCache(log), AutogeneratedExcludeSettings{
+		GeneratedTag: "generated",
+		ExtraMarkers: []string{"@Generated by"},
+	}, log)
+	assert.NoError(t, err)
+
+	cases := map[string]string{
+		"autogenerated_custom_marker.go":         `its header comments contain marker "@generated by"`,
+		"autogenerated_build_tag.go":             `it has build tag "generated"`,
+		"autogenerated_custom_marker_in_code.go": "its header comments don't contain any of markers",
+	}
+	for file, reason := range cases {
+		fs, err := p.getOrCreateFileSummary(&result.Issue{Pos: token.Position{Filename: filepath.Join("testdata", file)}})
+		assert.NoError(t, err)
+		assert.Equal(t, reason, fs.reason, file)
+	}
+}
+
+func TestEmptyAutogenMarker(t *testing.T) {
+	log := logutils.NewStderrLog("")
+	p, err := NewAutogeneratedExclude(nil, AutogeneratedExcludeSettings{ExtraMarkers: []string{""}}, log)
+	assert.Error(t, err)
+	assert.Nil(t, p)
+}
+
+func TestGeneratedFiles(t *testing.T) {
+	log := logutils.NewStderrLog("")
+	p, err := NewAutogeneratedExclude(nil, AutogeneratedExcludeSettings{
+		GeneratedFiles: []string{"**/*.gen.go", "internal/mock/**"},
+		NeverGenerated: []string{"internal/mock/handwritten.go"},
+	}, log)
+	assert.NoError(t, err)
+
+	// files aren't parsed: ast cache is nil and files don't exist
+	processAssertEmpty(t, p,
+		newFileIssue("a.gen.go"),
+		newFileIssue("pkg/sub/a.gen.go"),
+		newFileIssue("internal/mock/a.go"),
+		newFileIssue("internal/mock/sub/a.go"))
+	processAssertSame(t, p, newFileIssue("internal/mock/handwritten.go"))
+}
+
+func TestGeneratedFilesInvalidPattern(t *testing.T) {
+	log := logutils.NewStderrLog("")
+	p, err := NewAutogeneratedExclude(nil, AutogeneratedExcludeSettings{GeneratedFiles: []string{"**/[a.go"}}, log)
+	assert.Error(t, err)
+	assert.Nil(t, p)
+}
+
+func TestAutogenDiskCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "autogen_cache")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	log := logutils.NewStderrLog("")
+	cachePath := filepath.Join(dir, "autogen.json")
+	markers := []string{"@generated by"}
+	generatedFile := newFileIssue(filepath.Join("testdata", "autogenerated_custom_marker.go"))
+	notGeneratedFile := newFileIssue(filepath.Join("testdata", "autogenerated_custom_marker_in_code.go"))
+
+	p, err := NewAutogeneratedExclude(astcache.NewCache(log), AutogeneratedExcludeSettings{
+		ExtraMarkers: markers,
+		DiskCache:    NewAutogenDiskCache(cachePath, log),
+	}, log)
+	assert.NoError(t, err)
+	processAssertEmpty(t, p, generatedFile)
+	processAssertSame(t, p, notGeneratedFile)
+	p.Finish()
+
+	// invalid settings don't invalidate the cache
+	diskCache := NewAutogenDiskCache(cachePath, log)
+	_, err = NewAutogeneratedExclude(nil, AutogeneratedExcludeSettings{
+		NeverGenerated: []string{"[a.go"},
+		DiskCache:      diskCache,
+	}, log)
+	assert.Error(t, err)
+	assert.False(t, diskCache.changed)
+
+	// files aren't parsed on cache hit: ast cache is nil
+	p, err = NewAutogeneratedExclude(nil, AutogeneratedExcludeSettings{
+		ExtraMarkers: markers,
+		DiskCache:    NewAutogenDiskCache(cachePath, log),
+	}, log)
+	assert.NoError(t, err)
+	processAssertEmpty(t, p, generatedFile)
+	processAssertSame(t, p, notGeneratedFile)
+
+	// cache is invalidated by changed settings
+	p, err = NewAutogeneratedExclude(astcache.NewCache(log), AutogeneratedExcludeSettings{
+		DiskCache: NewAutogenDiskCache(cachePath, log),
+	}, log)
+	assert.NoError(t, err)
+	processAssertSame(t, p, generatedFile)
+}
@@ -0,0 +1,220 @@
+package processors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// BaselineIssue is an entry of the baseline file: issues are matched by fingerprints,
+// not by lines, to survive changes of line numbers. Path, linter and text are written
+// for readability of the file and to find issues of analyzed files and linters.
+type BaselineIssue struct {
+	Path        string `json:"path"`
+	Linter      string `json:"linter"`
+	Text        string `json:"text"`
+	Fingerprint string `json:"fingerprint"`
+	Count       int    `json:"count"`
+}
+
+type BaselineFile struct {
+	Issues []BaselineIssue `json:"issues"`
+}
+
+type Baseline struct {
+	path    string
+	keepOld bool
+	log     logutils.Log
+
+	// maps are keyed by fingerprints of issues
+	counts      map[string]int            // counts of issues from the baseline file which weren't matched yet
+	loaded      map[string]*BaselineIssue // issues of the baseline file
+	hiddenCount int
+
+	record   bool
+	recorded map[string]*BaselineIssue
+}
+
+var _ Processor = &Baseline{}
+
+// NewBaseline creates processor hiding issues from the baseline file path;
+// if record is true it also records all issues to write a new baseline file
+func NewBaseline(path string, record, keepOld bool, log logutils.Log) (*Baseline, error) {
+	p := &Baseline{
+		path:     path,
+		keepOld:  keepOld,
+		log:      log,
+		record:   record,
+		recorded: map[string]*BaselineIssue{},
+	}
+
+	if path == "" {
+		return p, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't open baseline file: %s", err)
+	}
+	defer f.Close()
+
+	var bf BaselineFile
+	if err = json.NewDecoder(f).Decode(&bf); err != nil {
+		return nil, fmt.Errorf("can't decode baseline file %s: %s", path, err)
+	}
+
+	p.counts = map[string]int{}
+	p.loaded = map[string]*BaselineIssue{}
+	for _, bi := range bf.Issues {
+		if bi.Fingerprint == "" {
+			return nil, fmt.Errorf("issue %q of linter %s in %s in baseline file %s has no fingerprint: "+
+				"write the file again", bi.Text, bi.Linter, bi.Path, path)
+		}
+
+		key := bi.Fingerprint
+		p.counts[key] += bi.Count
+		if loaded := p.loaded[key]; loaded != nil {
+			loaded.Count += bi.Count
+			continue
+		}
+
+		bi := bi
+		bi.Path = filepath.ToSlash(bi.Path)
+		p.loaded[key] = &bi
+	}
+
+	return p, nil
+}
+
+func (Baseline) Name() string {
+	return "baseline"
+}
+
+func (p *Baseline) Process(issues []result.Issue) ([]result.Issue, error) {
+	if p.record {
+		for i := range issues {
+			p.recordIssue(&issues[i])
+		}
+	}
+
+	if p.counts == nil { // no baseline file
+		return issues, nil
+	}
+
+	return transformIssues(issues, func(i *result.Issue) *result.Issue {
+		key := i.GetFingerprint()
+		if p.counts[key] <= 0 {
+			return i
+		}
+
+		p.counts[key]--
+		p.hiddenCount++
+		if !p.keepOld {
+			return nil
+		}
+
+		// keep old issue for context, but don't let it affect exit code
+		newI := *i
+		newI.IsOld = true
+		return &newI
+	}), nil
+}
+
+func (p *Baseline) recordIssue(i *result.Issue) {
+	key := i.GetFingerprint()
+	if bi := p.recorded[key]; bi != nil {
+		bi.Count++
+		return
+	}
+
+	p.recorded[key] = &BaselineIssue{
+		Path:        filepath.ToSlash(i.FilePath()),
+		Linter:      i.FromLinter,
+		Text:        i.Text,
+		Fingerprint: key,
+		Count:       1,
+	}
+}
+
+// WriteRecorded writes all recorded issues in the baseline file format
+func (p Baseline) WriteRecorded(w io.Writer) error {
+	issues := make([]BaselineIssue, 0, len(p.recorded))
+	for _, bi := range p.recorded {
+		issues = append(issues, *bi)
+	}
+
+	return writeBaselineFile(w, issues)
+}
+
+// WriteRatcheted writes recorded issues in the baseline file format, but counts
+// of issues can only decrease relative to the loaded baseline file: fixed issues
+// are dropped and new issues aren't added unless loosen is true.
+// Issues of the loaded file are dropped only if analyzed(path, linter) is true:
+// other issues weren't checked by the run and are kept as is.
+// It returns the count of new issues which weren't written.
+func (p Baseline) WriteRatcheted(w io.Writer, loosen bool, analyzed func(path, linter string) bool) (int, error) {
+	issues := make([]BaselineIssue, 0, len(p.recorded))
+	newCount := 0
+	for key, bi := range p.recorded {
+		count := bi.Count
+		if loadedCount := p.loadedCount(key); !loosen && count > loadedCount {
+			newCount += count - loadedCount
+			count = loadedCount
+		}
+		if count == 0 {
+			continue
+		}
+
+		ratcheted := *bi
+		ratcheted.Count = count
+		issues = append(issues, ratcheted)
+	}
+
+	for key, bi := range p.loaded {
+		if p.recorded[key] == nil && !analyzed(bi.Path, bi.Linter) {
+			issues = append(issues, *bi)
+		}
+	}
+
+	return newCount, writeBaselineFile(w, issues)
+}
+
+func (p Baseline) loadedCount(key string) int {
+	if bi := p.loaded[key]; bi != nil {
+		return bi.Count
+	}
+
+	return 0
+}
+
+func writeBaselineFile(w io.Writer, issues []BaselineIssue) error {
+	sort.Slice(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Linter != b.Linter {
+			return a.Linter < b.Linter
+		}
+		if a.Text != b.Text {
+			return a.Text < b.Text
+		}
+		return a.Fingerprint < b.Fingerprint
+	})
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(BaselineFile{Issues: issues})
+}
+
+func (p Baseline) Finish() {
+	if p.hiddenCount != 0 {
+		p.log.Infof("%d issues were hidden by baseline file %s", p.hiddenCount, p.path)
+	}
+}
@@ -0,0 +1,216 @@
+package processors
+
+import (
+	"bytes"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newBaselineIssue(path string, line int, linter, text string) result.Issue {
+	return result.Issue{
+		Pos: token.Position{
+			Filename: path,
+			Line:     line,
+		},
+		FromLinter: linter,
+		Text:       text,
+	}
+}
+
+func writeTestBaseline(t *testing.T, issues ...result.Issue) string {
+	p, err := NewBaseline("", true, false, logutils.NewStderrLog(""))
+	assert.NoError(t, err)
+	processAssertSame(t, p, issues...)
+
+	var buf bytes.Buffer
+	assert.NoError(t, p.WriteRecorded(&buf))
+
+	f, err := ioutil.TempFile("", "golangci-lint-baseline")
+	assert.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.Write(buf.Bytes())
+	assert.NoError(t, err)
+	return f.Name()
+}
+
+func TestBaseline(t *testing.T) {
+	path := writeTestBaseline(t,
+		newBaselineIssue("a.go", 10, "golint", "exported func F should have comment"),
+		newBaselineIssue("a.go", 20, "lll", "line is 130 characters"),
+		newBaselineIssue("a.go", 30, "errcheck", "Error return value is not checked"),
+		newBaselineIssue("a.go", 40, "errcheck", "Error return value is not checked"),
+	)
+	defer os.Remove(path)
+
+	p, err := NewBaseline(path, false, false, logutils.NewStderrLog(""))
+	assert.NoError(t, err)
+
+	// lines were shifted and numbers in texts were changed
+	processAssertEmpty(t, p,
+		newBaselineIssue("a.go", 15, "golint", "exported func F should have comment"),
+		newBaselineIssue("a.go", 25, "lll", "line is 135 characters"),
+		newBaselineIssue("a.go", 35, "errcheck", "Error return value is not checked"),
+		newBaselineIssue("a.go", 45, "errcheck", "Error return value is not checked"),
+	)
+
+	// new issues
+	processAssertSame(t, p,
+		newBaselineIssue("a.go", 50, "errcheck", "Error return value is not checked"),
+		newBaselineIssue("b.go", 10, "golint", "exported func F should have comment"),
+		newBaselineIssue("a.go", 10, "govet", "exported func F should have comment"),
+	)
+}
+
+func TestBaselineKeepOld(t *testing.T) {
+	issue := newBaselineIssue(filepath.Join("pkg", "a.go"), 10, "golint", "exported func F should have comment")
+	path := writeTestBaseline(t, issue)
+	defer os.Remove(path)
+
+	p, err := NewBaseline(path, false, true, logutils.NewStderrLog(""))
+	assert.NoError(t, err)
+
+	processed := process(t, p, issue)
+	assert.Len(t, processed, 1)
+	assert.True(t, processed[0].IsOld)
+}
+
+func TestBaselineWriteRecorded(t *testing.T) {
+	p, err := NewBaseline("", true, false, logutils.NewStderrLog(""))
+	assert.NoError(t, err)
+	processAssertSame(t, p,
+		newBaselineIssue("b.go", 1, "errcheck", "Error return value is not checked"),
+		newBaselineIssue("a.go", 1, "errcheck", "Error return value is not checked"),
+		newBaselineIssue("b.go", 2, "errcheck", "Error return value is not checked"),
+	)
+
+	var buf bytes.Buffer
+	assert.NoError(t, p.WriteRecorded(&buf))
+	assert.Equal(t, `{
+  "issues": [
+    {
+      "path": "a.go",
+      "linter": "errcheck",
+      "text": "Error return value is not checked",
+      "fingerprint": "5b07093e2d79a61915c1d914e513fa60babb92e6b2d5d4507696985e989738d1",
+      "count": 1
+    },
+    {
+      "path": "b.go",
+      "linter": "errcheck",
+      "text": "Error return value is not checked",
+      "fingerprint": "5a4a0253baae0961d3efd2c37fa6fdd97ca2884416ab8f02a60d5dd0c3493919",
+      "count": 2
+    }
+  ]
+}
+`, buf.String())
+}
+
+func TestBaselineInvalidFile(t *testing.T) {
+	_, err := NewBaseline(filepath.Join("testdata", "nolint.go"), false, false, logutils.NewStderrLog(""))
+	assert.Error(t, err)
+
+	f, err := ioutil.TempFile("", "golangci-lint-baseline")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(`{"issues": [{"path": "a.go", "linter": "lll", "text": "line is 130 characters", "count": 1}]}`)
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	_, err = NewBaseline(f.Name(), false, false, logutils.NewStderrLog(""))
+	assert.Error(t, err)
+}
+
+func TestBaselineMatchesByFingerprint(t *testing.T) {
+	withFingerprint := func(i result.Issue, sourceLines ...string) result.Issue {
+		i.Fingerprint = result.Fingerprint(i.FilePath(), i.FromLinter, i.Text, sourceLines)
+		return i
+	}
+
+	old := withFingerprint(newBaselineIssue("a.go", 10, "errcheck", errcheckText), "f.Close()")
+	path := writeTestBaseline(t, old)
+	defer os.Remove(path)
+
+	p, err := NewBaseline(path, false, false, logutils.NewStderrLog(""))
+	assert.NoError(t, err)
+
+	// the same text in the same file about another code is a new issue
+	processAssertSame(t, p, withFingerprint(newBaselineIssue("a.go", 10, "errcheck", errcheckText), "g.Close()"))
+	processAssertEmpty(t, p, withFingerprint(newBaselineIssue("a.go", 20, "errcheck", errcheckText), "f.Close()"))
+}
+
+func allAnalyzed(path, linter string) bool {
+	return true
+}
+
+func TestBaselineWriteRatcheted(t *testing.T) {
+	fixed := newBaselineIssue("a.go", 10, "golint", "exported func F should have comment")
+	old := newBaselineIssue("a.go", 20, "errcheck", "Error return value is not checked")
+	path := writeTestBaseline(t, fixed, old, old)
+	defer os.Remove(path)
+
+	newIssue := newBaselineIssue("b.go", 10, "lll", "line is 130 characters")
+	issues := []result.Issue{old, old, old, newIssue}
+
+	p, err := NewBaseline(path, true, false, logutils.NewStderrLog(""))
+	assert.NoError(t, err)
+	assert.Equal(t, issues[2:], process(t, p, issues...))
+
+	var buf bytes.Buffer
+	newCount, err := p.WriteRatcheted(&buf, false, allAnalyzed)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, newCount)
+
+	expected := writeTestBaseline(t, old, old)
+	defer os.Remove(expected)
+	expectedContent, err := ioutil.ReadFile(expected)
+	assert.NoError(t, err)
+	assert.Equal(t, string(expectedContent), buf.String())
+
+	buf.Reset()
+	newCount, err = p.WriteRatcheted(&buf, true, allAnalyzed)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, newCount)
+
+	var loosened bytes.Buffer
+	assert.NoError(t, p.WriteRecorded(&loosened))
+	assert.Equal(t, loosened.String(), buf.String())
+}
+
+func TestBaselineWriteRatchetedKeepsNotAnalyzed(t *testing.T) {
+	fixed := newBaselineIssue("a.go", 10, "golint", "exported func F should have comment")
+	otherFile := newBaselineIssue("b.go", 10, "golint", "exported func G should have comment")
+	otherLinter := newBaselineIssue("a.go", 20, "errcheck", "Error return value is not checked")
+	path := writeTestBaseline(t, fixed, otherFile, otherLinter, otherLinter)
+	defer os.Remove(path)
+
+	p, err := NewBaseline(path, true, false, logutils.NewStderrLog(""))
+	assert.NoError(t, err)
+	processAssertEmpty(t, p)
+
+	// only golint analyzed a.go: fixed issue is dropped, others weren't checked
+	analyzed := func(path, linter string) bool {
+		return path == "a.go" && linter == "golint"
+	}
+	for _, loosen := range []bool{false, true} {
+		var buf bytes.Buffer
+		newCount, err := p.WriteRatcheted(&buf, loosen, analyzed)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, newCount)
+
+		expected := writeTestBaseline(t, otherFile, otherLinter, otherLinter)
+		expectedContent, err := ioutil.ReadFile(expected)
+		assert.NoError(t, err)
+		os.Remove(expected)
+		assert.Equal(t, string(expectedContent), buf.String())
+	}
+}
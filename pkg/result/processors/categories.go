@@ -0,0 +1,58 @@
+package processors
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const UncategorizedCategory = "uncategorized"
+
+// Categories sets category of issues by their linters: issues of linters
+// without configured category are marked as uncategorized.
+type Categories struct {
+	linterToCategory map[string]string
+}
+
+var _ Processor = Categories{}
+
+func NewCategories(linterToCategory map[string]string) (*Categories, error) {
+	p := &Categories{
+		linterToCategory: map[string]string{},
+	}
+
+	dbManager := lintersdb.NewManager() // TODO: get it in constructor
+
+	for linter, category := range linterToCategory {
+		lc := dbManager.GetLinterConfig(strings.ToLower(linter))
+		if lc == nil {
+			return nil, fmt.Errorf("invalid output.categories: unknown linter %q", linter)
+		}
+		p.linterToCategory[lc.Name()] = category // normalize name to work with aliases
+	}
+
+	return p, nil
+}
+
+func (p Categories) Name() string {
+	return "categories"
+}
+
+func (p Categories) Process(issues []result.Issue) ([]result.Issue, error) {
+	if len(p.linterToCategory) == 0 {
+		return issues, nil
+	}
+
+	return transformIssues(issues, func(i *result.Issue) *result.Issue {
+		newI := *i
+		newI.Category = p.linterToCategory[i.FromLinter]
+		if newI.Category == "" {
+			newI.Category = UncategorizedCategory
+		}
+		return &newI
+	}), nil
+}
+
+func (p Categories) Finish() {}
@@ -0,0 +1,36 @@
+package processors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestCategories(t *testing.T) {
+	// gas is an alias of gosec
+	p, err := NewCategories(map[string]string{"gas": "security", "GoVet": "bugs"})
+	assert.NoError(t, err)
+
+	issues, err := p.Process([]result.Issue{
+		newLinterFileIssue("gosec", "a.go"),
+		newLinterFileIssue("govet", "a.go"),
+		newLinterFileIssue("golint", "a.go"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "security", issues[0].Category)
+	assert.Equal(t, "bugs", issues[1].Category)
+	assert.Equal(t, UncategorizedCategory, issues[2].Category)
+}
+
+func TestCategoriesNotSet(t *testing.T) {
+	p, err := NewCategories(nil)
+	assert.NoError(t, err)
+	processAssertSame(t, p, newLinterFileIssue("gosec", "a.go"))
+}
+
+func TestCategoriesUnknownLinter(t *testing.T) {
+	_, err := NewCategories(map[string]string{"unknownlinter": "style"})
+	assert.EqualError(t, err, `invalid output.categories: unknown linter "unknownlinter"`)
+}
@@ -1,15 +1,19 @@
 package processors
 
 import (
-	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/golangci/revgrep"
 
+	"github.com/golangci/golangci-lint/pkg/gitutil"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
@@ -18,17 +22,153 @@ type Diff struct {
 	fromRev       string
 	patchFilePath string
 	patch         string
+	keepOld       bool
 }
 
 var _ Processor = Diff{}
 
-func NewDiff(onlyNew bool, fromRev, patchFilePath string) *Diff {
-	return &Diff{
+// NewDiff creates the processor keeping only new issues: changes are read from
+// the patch file if patchFilePath is set, otherwise they are got from git.
+// The patch is validated here to fail before linting.
+func NewDiff(onlyNew bool, fromRev, patchFilePath string, keepOld bool) (*Diff, error) {
+	p := &Diff{
 		onlyNew:       onlyNew,
 		fromRev:       fromRev,
 		patchFilePath: patchFilePath,
 		patch:         os.Getenv("GOLANGCI_DIFF_PROCESSOR_PATCH"),
+		keepOld:       keepOld,
 	}
+
+	patchSource := "from GOLANGCI_DIFF_PROCESSOR_PATCH env var"
+	if patchFilePath != "" {
+		patch, err := ioutil.ReadFile(patchFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("can't read from patch file %s: %s", patchFilePath, err)
+		}
+		p.patch = string(patch)
+		patchSource = "file " + patchFilePath
+	}
+
+	patch, err := normalizePatch(p.patch)
+	if err != nil {
+		return nil, fmt.Errorf("invalid patch %s: %s", patchSource, err)
+	}
+	p.patch = patch
+
+	return p, nil
+}
+
+var hunkHeaderRe = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+\d+(?:,(\d+))? @@`)
+
+// normalizePatch checks the unified diff and rewrites paths of changed files
+// to the form of git diff: revgrep silently ignores or panics on malformed hunks
+// and expects b/ prefix without timestamps, but diff -u or git diff --no-prefix don't give it
+func normalizePatch(patch string) (string, error) {
+	if strings.TrimSpace(patch) == "" {
+		return patch, nil // no changes
+	}
+
+	var v patchValidator
+	body := strings.TrimSuffix(patch, "\n")
+	lines := strings.Split(body, "\n")
+	for n := range lines {
+		line, err := v.checkLine(strings.TrimSuffix(lines[n], "\r"), n+1)
+		if err != nil {
+			return "", err
+		}
+		if line != "" {
+			lines[n] = line
+		}
+	}
+
+	if v.oldLeft > 0 || v.newLeft > 0 {
+		return "", fmt.Errorf("hunk of %s at line %d is truncated at the end of the patch", v.file, v.hunkLine)
+	}
+	if !v.hasHeaders {
+		return "", errors.New("no headers of changed files were found: it isn't a unified diff")
+	}
+
+	return strings.Join(lines, "\n") + patch[len(body):], nil
+}
+
+// patchValidator keeps the state of checking lines of a unified diff
+type patchValidator struct {
+	file             string
+	hasHeaders       bool
+	oldLeft, newLeft int
+	hunkLine         int
+}
+
+// checkLine checks the n-th line of a patch and returns its rewritten form if it's needed
+func (v *patchValidator) checkLine(line string, n int) (string, error) {
+	if v.oldLeft > 0 || v.newLeft > 0 {
+		return "", v.checkHunkLine(line, n)
+	}
+
+	switch {
+	case strings.HasPrefix(line, "diff "):
+		v.hasHeaders = true
+	case strings.HasPrefix(line, "+++ "):
+		v.hasHeaders = true
+		v.file = changedFilePath(strings.TrimPrefix(line, "+++ "))
+		return "+++ " + v.file, nil
+	case strings.HasPrefix(line, "@@ "):
+		if v.file == "" {
+			return "", fmt.Errorf("hunk at line %d has no header of changed file", n)
+		}
+
+		m := hunkHeaderRe.FindStringSubmatch(line)
+		if m == nil {
+			return "", fmt.Errorf("invalid hunk header %q at line %d", line, n)
+		}
+		v.oldLeft, v.newLeft, v.hunkLine = hunkLinesCount(m[1]), hunkLinesCount(m[2]), n
+	}
+
+	return "", nil
+}
+
+func (v *patchValidator) checkHunkLine(line string, n int) error {
+	switch {
+	case line == "" || line[0] == ' ':
+		v.oldLeft--
+		v.newLeft--
+	case line[0] == '-':
+		v.oldLeft--
+	case line[0] == '+':
+		v.newLeft--
+	case line[0] == '\\': // \ No newline at end of file
+		return nil
+	default:
+		return fmt.Errorf("hunk of %s at line %d is truncated at line %d", v.file, v.hunkLine, n)
+	}
+
+	if v.oldLeft < 0 || v.newLeft < 0 {
+		return fmt.Errorf("hunk of %s at line %d has more lines than its header says", v.file, v.hunkLine)
+	}
+	return nil
+}
+
+// changedFilePath converts a path from the +++ header to the form of git diff:
+// diff -u appends a timestamp after a tab and gives paths without b/ prefix
+func changedFilePath(path string) string {
+	if i := strings.IndexByte(path, '\t'); i != -1 {
+		path = path[:i]
+	}
+	if path == "/dev/null" || strings.HasPrefix(path, "b/") {
+		return path
+	}
+
+	return "b/" + strings.TrimPrefix(path, "./")
+}
+
+// hunkLinesCount parses count of lines in a hunk header: it's 1 if it's omitted
+func hunkLinesCount(s string) int {
+	if s == "" {
+		return 1
+	}
+
+	n, _ := strconv.Atoi(s) // it's validated by regexp
+	return n
 }
 
 func (p Diff) Name() string {
@@ -40,20 +180,14 @@ func (p Diff) Process(issues []result.Issue) ([]result.Issue, error) {
 		return issues, nil
 	}
 
-	var patchReader io.Reader
-	if p.patchFilePath != "" {
-		patch, err := ioutil.ReadFile(p.patchFilePath)
-		if err != nil {
-			return nil, fmt.Errorf("can't read from patch file %s: %s", p.patchFilePath, err)
-		}
-		patchReader = bytes.NewReader(patch)
-	} else if p.patch != "" {
-		patchReader = strings.NewReader(p.patch)
+	patchReader, newFiles, err := p.getPatch()
+	if err != nil {
+		return nil, err
 	}
 
 	c := revgrep.Checker{
-		Patch:        patchReader,
-		RevisionFrom: p.fromRev,
+		Patch:    patchReader,
+		NewFiles: newFiles,
 	}
 	if err := c.Prepare(); err != nil {
 		return nil, fmt.Errorf("can't prepare diff by revgrep: %s", err)
@@ -62,7 +196,14 @@ func (p Diff) Process(issues []result.Issue) ([]result.Issue, error) {
 	return transformIssues(issues, func(i *result.Issue) *result.Issue {
 		hunkPos, isNew := c.IsNewIssue(i)
 		if !isNew {
-			return nil
+			if !p.keepOld {
+				return nil
+			}
+
+			// keep old issue for context, but don't let it affect exit code
+			newI := *i
+			newI.IsOld = true
+			return &newI
 		}
 
 		newI := *i
@@ -71,4 +212,17 @@ func (p Diff) Process(issues []result.Issue) ([]result.Issue, error) {
 	}), nil
 }
 
+// getPatch returns the patch and new files: they are got from git if the patch isn't set
+func (p Diff) getPatch() (io.Reader, []string, error) {
+	if p.patchFilePath != "" || p.patch != "" {
+		return strings.NewReader(p.patch), nil, nil
+	}
+
+	patch, untracked, err := gitutil.Patch(context.Background(), "", p.fromRev)
+	if err != nil {
+		return nil, nil, fmt.Errorf("can't get changes from git: %s", err)
+	}
+	return strings.NewReader(patch), untracked, nil
+}
+
 func (Diff) Finish() {}
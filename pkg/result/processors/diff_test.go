@@ -0,0 +1,134 @@
+package processors
+
+import (
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newDiffIssue(line int) result.Issue {
+	return result.Issue{
+		Pos: token.Position{
+			Filename: "a.go",
+			Line:     line,
+		},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	p, err := NewDiff(false, "", filepath.Join("testdata", "diff.patch"), false)
+	assert.NoError(t, err)
+
+	processedIssues := process(t, p, newDiffIssue(3), newDiffIssue(4))
+	assert.Len(t, processedIssues, 1)
+	assert.Equal(t, 3, processedIssues[0].Line())
+	assert.False(t, processedIssues[0].IsOld)
+}
+
+func TestDiffKeepOld(t *testing.T) {
+	p, err := NewDiff(false, "", filepath.Join("testdata", "diff.patch"), true)
+	assert.NoError(t, err)
+
+	processedIssues := process(t, p, newDiffIssue(3), newDiffIssue(4))
+	assert.Len(t, processedIssues, 2)
+	assert.False(t, processedIssues[0].IsOld)
+	assert.True(t, processedIssues[1].IsOld)
+}
+
+func TestDiffUnified(t *testing.T) {
+	p, err := NewDiff(false, "", filepath.Join("testdata", "diff_unified.patch"), false)
+	assert.NoError(t, err)
+
+	processedIssues := process(t, p, newDiffIssue(3), newDiffIssue(4))
+	assert.Len(t, processedIssues, 1)
+	assert.Equal(t, 3, processedIssues[0].Line())
+}
+
+func TestDiffRenamedAndNewFiles(t *testing.T) {
+	p, err := NewDiff(false, "", filepath.Join("testdata", "diff_rename.patch"), false)
+	assert.NoError(t, err)
+
+	newIssue := func(file string, line int) result.Issue {
+		i := newDiffIssue(line)
+		i.Pos.Filename = file
+		return i
+	}
+
+	processedIssues := process(t, p,
+		newIssue("renamed.go", 3), // renamed and changed file: only changed lines
+		newIssue("renamed.go", 4),
+		newIssue("moved2.go", 1), // renamed file without changes
+		newIssue("new.go", 1),    // new file: all lines
+		newIssue("new.go", 3),
+	)
+	assert.Len(t, processedIssues, 3)
+	assert.Equal(t, "renamed.go:3", processedIssues[0].Pos.String())
+	assert.Equal(t, "new.go:1", processedIssues[1].Pos.String())
+	assert.Equal(t, "new.go:3", processedIssues[2].Pos.String())
+}
+
+func TestNormalizePatch(t *testing.T) {
+	valid := []string{
+		"",
+		"diff --git a/moved.go b/moved2.go\nsimilarity index 100%\nrename from moved.go\nrename to moved2.go\n",
+		"--- a/a.go\n+++ b/a.go\n@@ -1,2 +1,2 @@\n package a\n-var x = 1\n+var x = 2\n\\ No newline at end of file\n",
+		"--- a/a.go\n+++ b/a.go\n@@ -1 +1,2 @@\n package a\n+--- not a header\n",
+		"--- a/a.go\r\n+++ b/a.go\r\n@@ -1,2 +1,2 @@\r\n\r\n-a\r\n+b\r\n",
+		"--- a/a.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-package a\n",
+	}
+	for _, patch := range valid {
+		_, err := normalizePatch(patch)
+		assert.NoError(t, err, patch)
+	}
+
+	normalized := map[string]string{
+		"--- a/a.go\n+++ b/a.go\n@@ -1 +1 @@\n-a\n+b\n":                     "--- a/a.go\n+++ b/a.go\n@@ -1 +1 @@\n-a\n+b\n",
+		"--- a.go\t2019-01-01\n+++ a.go\t2019-01-02\n@@ -1 +1 @@\n-a\n+b\n": "--- a.go\t2019-01-01\n+++ b/a.go\n@@ -1 +1 @@\n-a\n+b\n",
+		"--- ./a/a.go\n+++ ./a/a.go\n@@ -1 +1,2 @@\n a\n++++ b\n":           "--- ./a/a.go\n+++ b/a/a.go\n@@ -1 +1,2 @@\n a\n++++ b\n",
+		"--- a/a.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-a":                      "--- a/a.go\n+++ /dev/null\n@@ -1 +0,0 @@\n-a",
+	}
+	for patch, want := range normalized {
+		got, err := normalizePatch(patch)
+		assert.NoError(t, err, patch)
+		assert.Equal(t, want, got)
+	}
+
+	invalid := []struct {
+		patch, errText string
+	}{
+		{
+			patch:   "just text\n",
+			errText: "it isn't a unified diff",
+		},
+		{
+			patch:   "--- a/a.go\n+++ b/a.go\n@@ -1,2 +1,2 @@\n a\n-b\n",
+			errText: "hunk of b/a.go at line 3 is truncated at the end of the patch",
+		},
+		{
+			patch:   "--- a/a.go\n+++ b/a.go\n@@ -1,2 +1 @@\n+b\n+c\n",
+			errText: "hunk of b/a.go at line 3 has more lines than its header says",
+		},
+		{
+			patch:   "--- a/a.go\n+++ b/a.go\n@@ -1,3 +1,3 @@\n a\ndiff --git a/b.go b/b.go\n",
+			errText: "hunk of b/a.go at line 3 is truncated at line 5",
+		},
+		{
+			patch:   "--- a/a.go\n+++ b/a.go\n@@ -x +1 @@\n",
+			errText: `invalid hunk header "@@ -x +1 @@" at line 3`,
+		},
+		{
+			patch:   "@@ -1 +1 @@\n-a\n+b\n",
+			errText: "hunk at line 1 has no header of changed file",
+		},
+	}
+	for _, tc := range invalid {
+		_, err := normalizePatch(tc.patch)
+		if assert.Error(t, err, tc.patch) {
+			assert.Contains(t, err.Error(), tc.errText, tc.patch)
+		}
+	}
+}
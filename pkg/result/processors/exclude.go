@@ -1,25 +1,61 @@
 package processors
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
+// ExcludePattern matches issues of any of Linters with texts matching
+// case-insensitive regexp Pattern: empty Linters match issues of all linters.
+type ExcludePattern struct {
+	Pattern string
+	Linters []string
+}
+
 type Exclude struct {
-	pattern *regexp.Regexp
+	pattern        *regexp.Regexp            // patterns for all linters
+	linterPatterns map[string]*regexp.Regexp // patterns for specific linters by linter names
 }
 
 var _ Processor = Exclude{}
 
-func NewExclude(pattern string) *Exclude {
-	var patternRe *regexp.Regexp
-	if pattern != "" {
-		patternRe = regexp.MustCompile("(?i)" + pattern)
+// NewExclude creates processor hiding issues matched by any of patterns:
+// every pattern is compiled separately to report invalid ones
+func NewExclude(patterns []ExcludePattern) (*Exclude, error) {
+	var allPatterns []string
+	linterPatterns := map[string][]string{}
+	for _, p := range patterns {
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			return nil, fmt.Errorf("can't compile exclude regexp %q: %s", p.Pattern, err)
+		}
+
+		if len(p.Linters) == 0 {
+			allPatterns = append(allPatterns, p.Pattern)
+		}
+		for _, linter := range p.Linters {
+			linterPatterns[linter] = append(linterPatterns[linter], p.Pattern)
+		}
+	}
+
+	ret := &Exclude{
+		pattern:        compileExcludePatterns(allPatterns),
+		linterPatterns: map[string]*regexp.Regexp{},
 	}
-	return &Exclude{
-		pattern: patternRe,
+	for linter, ps := range linterPatterns {
+		ret.linterPatterns[linter] = compileExcludePatterns(ps)
 	}
+	return ret, nil
+}
+
+func compileExcludePatterns(patterns []string) *regexp.Regexp {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	return regexp.MustCompile(fmt.Sprintf("(?i)(%s)", strings.Join(patterns, "|")))
 }
 
 func (p Exclude) Name() string {
@@ -27,12 +63,17 @@ func (p Exclude) Name() string {
 }
 
 func (p Exclude) Process(issues []result.Issue) ([]result.Issue, error) {
-	if p.pattern == nil {
+	if p.pattern == nil && len(p.linterPatterns) == 0 {
 		return issues, nil
 	}
 
 	return filterIssues(issues, func(i *result.Issue) bool {
-		return !p.pattern.MatchString(i.Text)
+		if p.pattern != nil && p.pattern.MatchString(i.Text) {
+			return false
+		}
+
+		linterPattern := p.linterPatterns[i.FromLinter]
+		return linterPattern == nil || !linterPattern.MatchString(i.Text)
 	}), nil
 }
 
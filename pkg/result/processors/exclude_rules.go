@@ -0,0 +1,168 @@
+package processors
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// ExcludeRule matches issues from any of linters, with file path matching regexp path,
+// text matching case-insensitive regexp text and source line matching regexp source:
+// empty fields match any issue.
+type ExcludeRule struct {
+	Linters []string
+	Path    string
+	Text    string
+	Source  string
+}
+
+type excludeRule struct {
+	linters map[string]bool
+	path    *regexp.Regexp
+	text    *regexp.Regexp
+	source  *regexp.Regexp
+}
+
+// ExcludeRules hides issues matched by any of rules: an issue is matched
+// by a rule only if all set fields of the rule match it.
+type ExcludeRules struct {
+	rules      []excludeRule
+	linesCache *filesLineCache
+	log        logutils.Log
+}
+
+var _ Processor = &ExcludeRules{}
+
+func compileRuleRegexp(name, pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("can't compile %s regexp %q: %s", name, pattern, err)
+	}
+
+	return re, nil
+}
+
+// NewExcludeRules creates the processor: source rules match unsaved contents
+// of files from the overlay
+func NewExcludeRules(rules []ExcludeRule, overlay map[string][]byte, log logutils.Log) (*ExcludeRules, error) {
+	p := &ExcludeRules{
+		linesCache: newFilesLineCache(overlay),
+		log:        log,
+	}
+
+	dbManager := lintersdb.NewManager() // TODO: get it in constructor
+
+	for n, r := range rules {
+		er, err := newExcludeRule(r, dbManager)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclude rule #%d: %s", n+1, err)
+		}
+		p.rules = append(p.rules, *er)
+	}
+
+	return p, nil
+}
+
+func newExcludeRule(r ExcludeRule, dbManager *lintersdb.Manager) (*excludeRule, error) {
+	if len(r.Linters) == 0 && r.Path == "" && r.Text == "" && r.Source == "" {
+		return nil, errors.New("at least one of linters, path, text and source must be set")
+	}
+
+	linters, err := normalizeRuleLinters(r.Linters, dbManager)
+	if err != nil {
+		return nil, err
+	}
+
+	er := &excludeRule{
+		linters: linters,
+	}
+	if er.path, err = compileRuleRegexp("path", r.Path); err != nil {
+		return nil, err
+	}
+	text := r.Text
+	if text != "" {
+		text = "(?i)" + text
+	}
+	if er.text, err = compileRuleRegexp("text", text); err != nil {
+		return nil, err
+	}
+	if er.source, err = compileRuleRegexp("source", r.Source); err != nil {
+		return nil, err
+	}
+
+	return er, nil
+}
+
+// normalizeRuleLinters returns the set of names of linters: aliases are replaced by names
+func normalizeRuleLinters(linters []string, dbManager *lintersdb.Manager) (map[string]bool, error) {
+	ret := map[string]bool{}
+	for _, linter := range linters {
+		lc := dbManager.GetLinterConfig(strings.ToLower(linter))
+		if lc == nil {
+			return nil, fmt.Errorf("unknown linter %q", linter)
+		}
+		ret[lc.Name()] = true
+	}
+
+	return ret, nil
+}
+
+func (ExcludeRules) Name() string {
+	return "exclude_rules"
+}
+
+func (p *ExcludeRules) Process(issues []result.Issue) ([]result.Issue, error) {
+	if len(p.rules) == 0 {
+		return issues, nil
+	}
+
+	return filterIssues(issues, func(i *result.Issue) bool {
+		for _, r := range p.rules {
+			if p.match(&r, i) {
+				return false
+			}
+		}
+
+		return true
+	}), nil
+}
+
+func (p *ExcludeRules) match(r *excludeRule, i *result.Issue) bool {
+	if len(r.linters) != 0 && !r.linters[i.FromLinter] {
+		return false
+	}
+	if r.path != nil && !r.path.MatchString(i.FilePath()) {
+		return false
+	}
+	if r.text != nil && !r.text.MatchString(i.Text) {
+		return false
+	}
+
+	return r.source == nil || r.source.MatchString(p.sourceLine(i))
+}
+
+func (p *ExcludeRules) sourceLine(i *result.Issue) string {
+	lines, err := p.linesCache.getLines(i.FilePath())
+	if err != nil {
+		p.log.Warnf("Can't match source of exclude rule: %s", err)
+		return ""
+	}
+
+	if i.Line() < 1 || i.Line() > len(lines) {
+		return ""
+	}
+
+	return string(bytes.Trim(lines[i.Line()-1], "\r"))
+}
+
+func (ExcludeRules) Finish() {}
@@ -0,0 +1,64 @@
+package processors
+
+import (
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newRuleIssue(path string, line int, linter, text string) result.Issue {
+	return result.Issue{
+		Pos: token.Position{
+			Filename: path,
+			Line:     line,
+		},
+		FromLinter: linter,
+		Text:       text,
+	}
+}
+
+func TestExcludeRules(t *testing.T) {
+	p, err := NewExcludeRules([]ExcludeRule{
+		{Linters: []string{"errcheck", "Gas"}, Path: `_test\.go`},
+		{Path: `^generated/`},
+		{Text: "^should have comment"},
+		{Linters: []string{"lll"}, Source: "^//go:generate "},
+	}, nil, logutils.NewStderrLog(""))
+	assert.NoError(t, err)
+
+	sourceFile := filepath.Join("testdata", "exclude_rules.go")
+	processAssertEmpty(t, p,
+		newRuleIssue("a_test.go", 1, "errcheck", "Error return value is not checked"),
+		newRuleIssue("a_test.go", 1, "gosec", "Errors unhandled"), // gas is an alias of gosec
+		newRuleIssue("generated/a.go", 1, "golint", "text"),
+		newRuleIssue("a.go", 1, "golint", "Should have comment"),
+		newRuleIssue(sourceFile, 3, "lll", "line is 130 characters"),
+	)
+
+	processAssertSame(t, p,
+		newRuleIssue("a_test.go", 1, "golint", "Error return value is not checked"),
+		newRuleIssue("a.go", 1, "errcheck", "Error return value is not checked"),
+		newRuleIssue("pkg/generated/a.go", 1, "golint", "text"),
+		newRuleIssue("a.go", 1, "golint", "exported func should have comment"),
+		newRuleIssue(sourceFile, 5, "lll", "line is 130 characters"),
+		newRuleIssue(sourceFile, 3, "golint", "line is 130 characters"),
+	)
+}
+
+func TestExcludeRulesInvalid(t *testing.T) {
+	_, err := NewExcludeRules([]ExcludeRule{{}}, nil, logutils.NewStderrLog(""))
+	assert.EqualError(t, err, "invalid exclude rule #1: at least one of linters, path, text and source must be set")
+
+	_, err = NewExcludeRules([]ExcludeRule{{Path: "a("}}, nil, logutils.NewStderrLog(""))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), `invalid exclude rule #1: can't compile path regexp "a("`)
+	}
+
+	_, err = NewExcludeRules([]ExcludeRule{{Linters: []string{"errchek"}}}, nil, logutils.NewStderrLog(""))
+	assert.EqualError(t, err, `invalid exclude rule #1: unknown linter "errchek"`)
+}
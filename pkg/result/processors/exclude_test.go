@@ -31,23 +31,56 @@ func processAssertEmpty(t *testing.T, p Processor, issues ...result.Issue) {
 }
 
 func TestExclude(t *testing.T) {
-	p := NewExclude("^exclude$")
-	texts := []string{"excLude", "1", "", "exclud", "notexclude"}
+	p, err := NewExclude([]ExcludePattern{{Pattern: "^exclude$"}, {Pattern: "^other$"}})
+	assert.NoError(t, err)
+	texts := []string{"excLude", "1", "", "exclud", "notexclude", "Other"}
 	var issues []result.Issue
 	for _, t := range texts {
 		issues = append(issues, newTextIssue(t))
 	}
 
 	processedIssues := process(t, p, issues...)
-	assert.Len(t, processedIssues, len(issues)-1)
+	assert.Len(t, processedIssues, len(issues)-2)
 
 	var processedTexts []string
 	for _, i := range processedIssues {
 		processedTexts = append(processedTexts, i.Text)
 	}
-	assert.Equal(t, texts[1:], processedTexts)
+	assert.Equal(t, texts[1:5], processedTexts)
+}
+
+func TestExcludeLinters(t *testing.T) {
+	p, err := NewExclude([]ExcludePattern{
+		{Pattern: "^exclude$", Linters: []string{"golint", "govet"}},
+		{Pattern: "^other$", Linters: []string{"golint"}},
+		{Pattern: "^all$"},
+	})
+	assert.NoError(t, err)
+
+	newIssue := func(linter, text string) result.Issue {
+		return result.Issue{FromLinter: linter, Text: text}
+	}
+	processAssertEmpty(t, p,
+		newIssue("golint", "exclude"),
+		newIssue("golint", "other"),
+		newIssue("govet", "exclude"),
+		newIssue("errcheck", "all"),
+	)
+	processAssertSame(t, p,
+		newIssue("govet", "other"),
+		newIssue("errcheck", "exclude"),
+		newIssue("errcheck", "other"),
+	)
 }
 
 func TestNoExclude(t *testing.T) {
-	processAssertSame(t, NewExclude(""), newTextIssue("test"))
+	p, err := NewExclude(nil)
+	assert.NoError(t, err)
+	processAssertSame(t, p, newTextIssue("test"))
+}
+
+func TestExcludeInvalidPattern(t *testing.T) {
+	_, err := NewExclude([]ExcludePattern{{Pattern: "valid"}, {Pattern: "invalid)", Linters: []string{"golint"}}})
+	assert.EqualError(t, err, "can't compile exclude regexp \"invalid)\": "+
+		"error parsing regexp: unexpected ): `invalid)`")
 }
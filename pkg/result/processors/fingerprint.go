@@ -0,0 +1,63 @@
+package processors
+
+import (
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// Fingerprint sets fingerprints of issues: they are used by baseline and snapshot
+// files and are printed by json and Code Climate printers. It must be before
+// processors changing paths of issues, e.g. path shortener and absolutizer.
+type Fingerprint struct {
+	cache *filesLineCache
+}
+
+var _ Processor = Fingerprint{}
+
+// NewFingerprint creates the processor: lines of files from the overlay are got
+// from their unsaved contents
+func NewFingerprint(overlay map[string][]byte) *Fingerprint {
+	return &Fingerprint{
+		cache: newFilesLineCache(overlay),
+	}
+}
+
+func (p Fingerprint) Name() string {
+	return "fingerprint"
+}
+
+func (p Fingerprint) Process(issues []result.Issue) ([]result.Issue, error) {
+	return transformIssues(issues, func(i *result.Issue) *result.Issue {
+		newI := *i
+
+		// if the file can't be read, source lines are just absent in the fingerprint:
+		// the source code processor warns about it
+		lines, err := p.cache.getLines(i.FilePath())
+		if err != nil {
+			lines = nil
+		}
+		newI.Fingerprint = result.Fingerprint(i.FilePath(), i.FromLinter, i.Text, getFingerprintLines(lines, i))
+		return &newI
+	}), nil
+}
+
+func (Fingerprint) Finish() {}
+
+// getFingerprintLines returns lines of the issue and result.FingerprintWindow lines around them
+func getFingerprintLines(lines linesCache, i *result.Issue) []string {
+	lineRange := i.GetLineRange()
+	from := lineRange.From - result.FingerprintWindow
+	if from < 1 {
+		from = 1
+	}
+	to := lineRange.To + result.FingerprintWindow
+	if to > len(lines) {
+		to = len(lines)
+	}
+
+	var ret []string
+	for line := from; line <= to; line++ {
+		ret = append(ret, string(lines[line-1]))
+	}
+
+	return ret
+}
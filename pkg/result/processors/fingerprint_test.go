@@ -0,0 +1,52 @@
+package processors
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+const errcheckText = "Error return value is not checked"
+
+func TestFingerprint(t *testing.T) {
+	f, err := ioutil.TempFile("", "golangci-lint-fingerprint")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	assert.NoError(t, f.Close())
+
+	getFingerprint := func(content string, line int) string {
+		assert.NoError(t, ioutil.WriteFile(f.Name(), []byte(content), os.ModePerm))
+
+		issue := newFileIssue(f.Name())
+		issue.Pos.Line = line
+		issue.FromLinter = "errcheck"
+		issue.Text = errcheckText
+
+		processedIssues := process(t, NewFingerprint(nil), issue)
+		assert.Len(t, processedIssues, 1)
+		return processedIssues[0].Fingerprint
+	}
+
+	fp := getFingerprint("func F() {\n\tf.Close()\n}\n", 2)
+	assert.Len(t, fp, 64)
+
+	// unrelated lines were added above and indentation was changed
+	assert.Equal(t, fp, getFingerprint("// F does nothing\n\nfunc F() {\n    f.Close()\n}\n", 4))
+
+	// the issue line was changed
+	assert.NotEqual(t, fp, getFingerprint("func F() {\n\tg.Close()\n}\n", 2))
+
+	// the line around the issue was changed
+	assert.NotEqual(t, fp, getFingerprint("func G() {\n\tf.Close()\n}\n", 2))
+
+	// the file can't be read: the fingerprint doesn't depend on source lines
+	issue := newFileIssue(f.Name() + ".absent")
+	processedIssues := process(t, NewFingerprint(nil), issue)
+	assert.Len(t, processedIssues, 1)
+	assert.Equal(t, result.Fingerprint(issue.FilePath(), issue.FromLinter, issue.Text, nil),
+		processedIssues[0].Fingerprint)
+}
@@ -0,0 +1,148 @@
+package processors
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// Fixer hides issues having replacements and applies replacements to files in Finish:
+// files are changed only after all linters finished because offsets of replacements
+// are offsets in the original files. If a replacement overlaps with a replacement
+// of an already hidden issue, the issue isn't fixed and is kept. Hidden issues can't be
+// reported if fixing of their file failed in Finish: such files are returned by FailedFiles
+// to fail the run.
+type Fixer struct {
+	enabled bool
+	log     logutils.Log
+
+	fileFixes    map[string][]result.Replacement
+	unfixedCount int
+	failedFiles  []string
+}
+
+func NewFixer(enabled bool, log logutils.Log) *Fixer {
+	return &Fixer{
+		enabled:   enabled,
+		log:       log,
+		fileFixes: map[string][]result.Replacement{},
+	}
+}
+
+var _ Processor = &Fixer{}
+
+func (Fixer) Name() string {
+	return "fixer"
+}
+
+func replacementsOverlap(a, b result.Replacement) bool {
+	if a.Offset == b.Offset {
+		return true // insertions at the same offset can't be ordered
+	}
+
+	return a.Offset < b.Offset+b.Length && b.Offset < a.Offset+a.Length
+}
+
+func (p *Fixer) Process(issues []result.Issue) ([]result.Issue, error) {
+	if !p.enabled {
+		return issues, nil
+	}
+
+	return filterIssues(issues, func(i *result.Issue) bool {
+		if i.Replacement == nil {
+			return true
+		}
+
+		fixes := p.fileFixes[i.FilePath()]
+		for _, r := range fixes {
+			if replacementsOverlap(r, *i.Replacement) {
+				p.unfixedCount++
+				return true
+			}
+		}
+
+		p.fileFixes[i.FilePath()] = append(fixes, *i.Replacement)
+		return false
+	}), nil
+}
+
+func applyReplacements(content []byte, fixes []result.Replacement) ([]byte, error) {
+	sort.Slice(fixes, func(i, j int) bool {
+		return fixes[i].Offset < fixes[j].Offset
+	})
+
+	var buf bytes.Buffer
+	pos := 0
+	for _, r := range fixes {
+		if r.Offset < pos || r.Offset+r.Length > len(content) {
+			return nil, fmt.Errorf("replacement at offset %d is out of file bounds", r.Offset)
+		}
+
+		buf.Write(content[pos:r.Offset])
+		buf.WriteString(r.NewText)
+		pos = r.Offset + r.Length
+	}
+	buf.Write(content[pos:])
+
+	return buf.Bytes(), nil
+}
+
+func fixFile(path string, fixes []result.Replacement) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	fixedContent, err := applyReplacements(content, fixes)
+	if err != nil {
+		return err
+	}
+
+	err = fsutils.WriteFileAtomically(path, func(w io.Writer) error {
+		_, err := w.Write(fixedContent)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return os.Chmod(path, fi.Mode())
+}
+
+func (p *Fixer) Finish() {
+	fixedCount, fixedFilesCount := 0, 0
+	for path, fixes := range p.fileFixes {
+		if err := fixFile(path, fixes); err != nil {
+			p.log.Errorf("Can't fix %d issues in file %s: %s", len(fixes), path, err)
+			p.failedFiles = append(p.failedFiles, path)
+			continue
+		}
+		fixedCount += len(fixes)
+		fixedFilesCount++
+	}
+
+	if fixedCount != 0 {
+		p.log.Infof("Fixed %d issues in %d files", fixedCount, fixedFilesCount)
+	}
+	if p.unfixedCount != 0 {
+		p.log.Infof("%d issues weren't fixed: their fixes overlap with fixes of other issues", p.unfixedCount)
+	}
+}
+
+// FailedFiles returns sorted files which weren't fixed: their fixed issues weren't reported.
+func (p Fixer) FailedFiles() []string {
+	sort.Strings(p.failedFiles)
+	return p.failedFiles
+}
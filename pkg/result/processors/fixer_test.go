@@ -0,0 +1,80 @@
+package processors
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newFixableIssue(file string, offset, length int, newText string) result.Issue {
+	i := newFileIssue(file)
+	i.Replacement = &result.Replacement{
+		Offset:  offset,
+		Length:  length,
+		NewText: newText,
+	}
+	return i
+}
+
+func TestFixer(t *testing.T) {
+	f, err := ioutil.TempFile("", "golangci-lint-fixer")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString("// a funtion\nfunc F() {\n    return\n}\n")
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	p := NewFixer(true, logutils.NewStderrLog(""))
+
+	notFixable := newFileIssue(f.Name())
+	processAssertSame(t, p, notFixable)
+
+	processAssertEmpty(t, p,
+		newFixableIssue(f.Name(), 5, 7, "function"),
+		newFixableIssue(f.Name(), 24, 4, "\t"),
+	)
+
+	// overlaps with the first fix
+	overlapping := newFixableIssue(f.Name(), 3, 9, "func")
+	processAssertSame(t, p, overlapping)
+
+	p.Finish()
+	content, err := ioutil.ReadFile(f.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, "// a function\nfunc F() {\n\treturn\n}\n", string(content))
+}
+
+func TestFixerFailedFiles(t *testing.T) {
+	f, err := ioutil.TempFile("", "golangci-lint-fixer")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString("packag p\n")
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	assert.NoError(t, os.Chmod(f.Name(), 0600))
+
+	p := NewFixer(true, logutils.NewStderrLog(""))
+	processAssertEmpty(t, p,
+		newFixableIssue(f.Name(), 0, 6, "package"),
+		newFixableIssue("not_existing.go", 0, 1, "b"),
+	)
+	p.Finish()
+	assert.Equal(t, []string{"not_existing.go"}, p.FailedFiles())
+
+	content, err := ioutil.ReadFile(f.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, "package p\n", string(content))
+	fi, err := os.Stat(f.Name())
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+}
+
+func TestFixerDisabled(t *testing.T) {
+	p := NewFixer(false, logutils.NewStderrLog(""))
+	processAssertSame(t, p, newFixableIssue("a.go", 0, 1, "b"))
+}
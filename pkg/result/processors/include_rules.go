@@ -0,0 +1,83 @@
+package processors
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// IncludeRule scopes linters to files with path matching regexp path
+type IncludeRule struct {
+	Linters []string
+	Path    string
+}
+
+// IncludeRules keeps issues of linters listed in rules only if the file path
+// matches path of any rule of the linter: such linters are active only for
+// matching paths. Issues of other linters aren't changed. Issues kept by
+// include rules can be hidden by exclude rules.
+type IncludeRules struct {
+	linterPaths map[string][]*regexp.Regexp
+}
+
+var _ Processor = &IncludeRules{}
+
+func NewIncludeRules(rules []IncludeRule) (*IncludeRules, error) {
+	p := &IncludeRules{
+		linterPaths: map[string][]*regexp.Regexp{},
+	}
+
+	dbManager := lintersdb.NewManager() // TODO: get it in constructor
+
+	for n, r := range rules {
+		if len(r.Linters) == 0 || r.Path == "" {
+			return nil, fmt.Errorf("invalid include rule #%d: linters and path must be set", n+1)
+		}
+
+		path, err := regexp.Compile(r.Path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid include rule #%d: can't compile path regexp %q: %s", n+1, r.Path, err)
+		}
+
+		for _, linter := range r.Linters {
+			lc := dbManager.GetLinterConfig(strings.ToLower(linter))
+			if lc == nil {
+				return nil, fmt.Errorf("invalid include rule #%d: unknown linter %q", n+1, linter)
+			}
+			// normalize name to work with aliases
+			p.linterPaths[lc.Name()] = append(p.linterPaths[lc.Name()], path)
+		}
+	}
+
+	return p, nil
+}
+
+func (IncludeRules) Name() string {
+	return "include_rules"
+}
+
+func (p *IncludeRules) Process(issues []result.Issue) ([]result.Issue, error) {
+	if len(p.linterPaths) == 0 {
+		return issues, nil
+	}
+
+	return filterIssues(issues, func(i *result.Issue) bool {
+		paths, ok := p.linterPaths[i.FromLinter]
+		if !ok {
+			return true // the linter isn't scoped
+		}
+
+		for _, path := range paths {
+			if path.MatchString(i.FilePath()) {
+				return true
+			}
+		}
+
+		return false
+	}), nil
+}
+
+func (IncludeRules) Finish() {}
@@ -0,0 +1,42 @@
+package processors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncludeRules(t *testing.T) {
+	p, err := NewIncludeRules([]IncludeRule{
+		{Linters: []string{"dupl"}, Path: `^internal/`},
+		{Linters: []string{"dupl", "Gas"}, Path: `^cmd/tool/`},
+	})
+	assert.NoError(t, err)
+
+	processAssertEmpty(t, p,
+		newRuleIssue("pkg/a.go", 1, "dupl", "duplicate of pkg/b.go:1-10"),
+		newRuleIssue("pkg/internal/a.go", 1, "dupl", "duplicate of pkg/b.go:1-10"),
+		newRuleIssue("internal/a.go", 1, "gosec", "Errors unhandled"), // gas is an alias of gosec
+	)
+
+	processAssertSame(t, p,
+		newRuleIssue("internal/a.go", 1, "dupl", "duplicate of internal/b.go:1-10"),
+		newRuleIssue("cmd/tool/a.go", 1, "dupl", "duplicate of cmd/tool/b.go:1-10"),
+		newRuleIssue("cmd/tool/a.go", 1, "gosec", "Errors unhandled"),
+		newRuleIssue("pkg/a.go", 1, "golint", "text"), // not scoped linter
+	)
+}
+
+func TestIncludeRulesInvalid(t *testing.T) {
+	_, err := NewIncludeRules([]IncludeRule{{Path: "^internal/"}})
+	assert.Error(t, err)
+
+	_, err = NewIncludeRules([]IncludeRule{{Linters: []string{"dupl"}}})
+	assert.Error(t, err)
+
+	_, err = NewIncludeRules([]IncludeRule{{Linters: []string{"dupl"}, Path: "a("}})
+	assert.Error(t, err)
+
+	_, err = NewIncludeRules([]IncludeRule{{Linters: []string{"dupll"}, Path: "^internal/"}})
+	assert.Error(t, err)
+}
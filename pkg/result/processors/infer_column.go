@@ -0,0 +1,135 @@
+package processors
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/golangci/golangci-lint/pkg/lint/astcache"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// lineColumns maps line numbers to columns of their first tokens
+type lineColumns map[int]int
+
+// InferColumn sets column of issues reported only with a line to the column
+// of the first token on the line. It's a heuristic: tokens are found by
+// positions of AST nodes and comments, so e.g. lines inside of multiline
+// string literals don't get a column.
+type InferColumn struct {
+	enabled  bool
+	astCache *astcache.Cache
+	log      logutils.Log
+
+	fileColumns map[string]lineColumns
+}
+
+func NewInferColumn(enabled bool, astCache *astcache.Cache, log logutils.Log) *InferColumn {
+	return &InferColumn{
+		enabled:     enabled,
+		astCache:    astCache,
+		log:         log,
+		fileColumns: map[string]lineColumns{},
+	}
+}
+
+var _ Processor = &InferColumn{}
+
+func (InferColumn) Name() string {
+	return "infer_column"
+}
+
+func (p *InferColumn) Process(issues []result.Issue) ([]result.Issue, error) {
+	if !p.enabled {
+		return issues, nil
+	}
+
+	return transformIssues(issues, func(i *result.Issue) *result.Issue {
+		if i.Column() != 0 || i.Line() <= 0 || i.FilePath() == "" {
+			return i
+		}
+
+		column := p.getLineColumns(i.FilePath())[i.Line()]
+		if column == 0 {
+			return i
+		}
+
+		newI := *i
+		newI.Pos.Column = column
+		return &newI
+	}), nil
+}
+
+func (p *InferColumn) getLineColumns(path string) lineColumns {
+	if lc, ok := p.fileColumns[path]; ok {
+		return lc
+	}
+
+	var lc lineColumns
+	if f := p.astCache.GetOrParse(path, nil); f.Err != nil {
+		p.log.Infof("Can't infer columns of issues in file %s: %s", path, f.Err)
+	} else {
+		lc = findLineColumns(f.F, f.Fset)
+	}
+
+	p.fileColumns[path] = lc
+	return lc
+}
+
+func findLineColumns(f *ast.File, fset *token.FileSet) lineColumns {
+	lc := lineColumns{}
+	add := func(pos token.Pos) {
+		if !pos.IsValid() {
+			return
+		}
+
+		p := fset.Position(pos)
+		if c, ok := lc[p.Line]; !ok || p.Column < c {
+			lc[p.Line] = p.Column
+		}
+	}
+
+	ast.Inspect(f, func(node ast.Node) bool {
+		if node == nil {
+			return false
+		}
+
+		add(node.Pos())
+		add(closingPos(node))
+		return true
+	})
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			add(c.Pos())
+		}
+	}
+
+	return lc
+}
+
+// closingPos returns position of the closing brace, bracket or parenthesis of the node:
+// it can be the first token on its line
+func closingPos(node ast.Node) token.Pos {
+	switch n := node.(type) {
+	case *ast.BlockStmt:
+		return n.Rbrace
+	case *ast.CompositeLit:
+		return n.Rbrace
+	case *ast.FieldList:
+		return n.Closing
+	case *ast.CallExpr:
+		return n.Rparen
+	case *ast.ParenExpr:
+		return n.Rparen
+	case *ast.GenDecl:
+		return n.Rparen
+	case *ast.IndexExpr:
+		return n.Rbrack
+	case *ast.SliceExpr:
+		return n.Rbrack
+	}
+
+	return token.NoPos
+}
+
+func (InferColumn) Finish() {}
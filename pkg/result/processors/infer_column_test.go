@@ -0,0 +1,54 @@
+package processors
+
+import (
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/lint/astcache"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newLineIssue(line, column int) result.Issue {
+	return result.Issue{
+		Pos: token.Position{
+			Filename: filepath.Join("testdata", "infer_column.go"),
+			Line:     line,
+			Column:   column,
+		},
+	}
+}
+
+func TestInferColumn(t *testing.T) {
+	log := logutils.NewStderrLog("")
+	p := NewInferColumn(true, astcache.NewCache(log), log)
+
+	cases := []struct {
+		issue  result.Issue
+		column int
+	}{
+		{newLineIssue(1, 0), 1}, // package clause
+		{newLineIssue(3, 0), 1}, // comment
+		{newLineIssue(5, 0), 2}, // statement
+		{newLineIssue(6, 0), 0}, // inside of multiline string
+		{newLineIssue(8, 0), 3}, // nested statement
+		{newLineIssue(9, 0), 2}, // closing brace
+		{newLineIssue(10, 0), 2},
+		{newLineIssue(8, 10), 10},
+	}
+
+	for _, c := range cases {
+		processedIssues := process(t, p, c.issue)
+		assert.Len(t, processedIssues, 1)
+		assert.Equal(t, c.column, processedIssues[0].Column(), "line %d", c.issue.Line())
+	}
+}
+
+func TestInferColumnDisabled(t *testing.T) {
+	log := logutils.NewStderrLog("")
+	p := NewInferColumn(false, astcache.NewCache(log), log)
+	processAssertSame(t, p, newLineIssue(5, 0))
+}
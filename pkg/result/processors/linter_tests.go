@@ -0,0 +1,48 @@
+package processors
+
+import (
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// LinterTests drops issues in test files for linters which must not analyze tests:
+// per-linter settings override the global run.tests option.
+type LinterTests struct {
+	analyzeTests  bool
+	testsByLinter map[string]bool
+}
+
+var _ Processor = LinterTests{}
+
+func NewLinterTests(analyzeTests bool, testsByLinter map[string]bool) *LinterTests {
+	return &LinterTests{
+		analyzeTests:  analyzeTests,
+		testsByLinter: testsByLinter,
+	}
+}
+
+func (p LinterTests) Name() string {
+	return "linter_tests"
+}
+
+func (p LinterTests) Process(issues []result.Issue) ([]result.Issue, error) {
+	if len(p.testsByLinter) == 0 {
+		return issues, nil
+	}
+
+	return filterIssues(issues, func(i *result.Issue) bool {
+		if !strings.HasSuffix(i.FilePath(), "_test.go") {
+			return true
+		}
+
+		analyzeTests, ok := p.testsByLinter[i.FromLinter]
+		if !ok {
+			return p.analyzeTests
+		}
+
+		return analyzeTests
+	}), nil
+}
+
+func (p LinterTests) Finish() {}
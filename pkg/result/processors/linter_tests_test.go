@@ -0,0 +1,29 @@
+package processors
+
+import (
+	"testing"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newLinterFileIssue(linter, file string) result.Issue {
+	i := newFileIssue(file)
+	i.FromLinter = linter
+	return i
+}
+
+func TestLinterTests(t *testing.T) {
+	p := NewLinterTests(true, map[string]bool{"errcheck": false})
+
+	processAssertSame(t, p, newLinterFileIssue("errcheck", "a.go"))
+	processAssertEmpty(t, p, newLinterFileIssue("errcheck", "a_test.go"))
+	processAssertSame(t, p, newLinterFileIssue("golint", "a_test.go"))
+}
+
+func TestLinterTestsOverrideDisabledTests(t *testing.T) {
+	p := NewLinterTests(false, map[string]bool{"golint": true})
+
+	processAssertSame(t, p, newLinterFileIssue("golint", "a_test.go"))
+	processAssertEmpty(t, p, newLinterFileIssue("errcheck", "a_test.go"))
+	processAssertSame(t, p, newLinterFileIssue("errcheck", "a.go"))
+}
@@ -1,6 +1,9 @@
 package processors
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
@@ -36,11 +39,18 @@ func (p *MaxFromLinter) Process(issues []result.Issue) ([]result.Issue, error) {
 	}), nil
 }
 
+// Finish prints a single summary line about hidden issues of all linters:
+// it's shown by default because the limit can hide important issues.
 func (p MaxFromLinter) Finish() {
+	var hidden []string
 	walkStringToIntMapSortedByValue(p.lc, func(linter string, count int) {
 		if count > p.limit {
-			p.log.Infof("%d/%d issues from linter %s were hidden, use --max-issues-per-linter",
-				count-p.limit, count, linter)
+			hidden = append(hidden, fmt.Sprintf("%d/%d from %s", count-p.limit, count, linter))
 		}
 	})
+
+	if len(hidden) != 0 {
+		p.log.Warnf("Issues were hidden by the limit of %d issues per linter, use --max-issues-per-linter: %s",
+			p.limit, strings.Join(hidden, ", "))
+	}
 }
@@ -3,6 +3,8 @@ package processors
 import (
 	"testing"
 
+	"github.com/golang/mock/gomock"
+
 	"github.com/golangci/golangci-lint/pkg/logutils"
 )
 
@@ -14,3 +16,18 @@ func TestMaxFromLinter(t *testing.T) {
 	processAssertSame(t, p, gofmt)     // ok: another
 	processAssertEmpty(t, p, gosimple) // skip
 }
+
+func TestMaxFromLinterSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log := logutils.NewMockLog(ctrl)
+	log.EXPECT().Warnf(gomock.Any(), 1, "2/3 from gosimple, 1/2 from gofmt").Times(1)
+
+	p := NewMaxFromLinter(1, log)
+	gosimple := newFromLinterIssue("gosimple")
+	gofmt := newFromLinterIssue("gofmt")
+	processAssertSame(t, p, gosimple, gofmt)
+	processAssertEmpty(t, p, gosimple, gofmt, gosimple)
+	p.Finish()
+}
@@ -1,7 +1,9 @@
 package processors
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
@@ -29,24 +31,34 @@ func (MaxSameIssues) Name() string {
 	return "max_same_issues"
 }
 
+// Process counts issues by normalized text: texts differing only in numbers
+// or spaces, e.g. in line numbers of related code, are the same.
 func (p *MaxSameIssues) Process(issues []result.Issue) ([]result.Issue, error) {
 	if p.limit <= 0 { // no limit
 		return issues, nil
 	}
 
 	return filterIssues(issues, func(i *result.Issue) bool {
-		p.tc[i.Text]++ // always inc for stat
-		return p.tc[i.Text] <= p.limit
+		text := result.NormalizeText(i.Text)
+		p.tc[text]++ // always inc for stat
+		return p.tc[text] <= p.limit
 	}), nil
 }
 
+// Finish logs a single summary line about hidden issues of all texts:
+// it's an info message because repeated issues are hidden by default.
 func (p MaxSameIssues) Finish() {
+	var hidden []string
 	walkStringToIntMapSortedByValue(p.tc, func(text string, count int) {
 		if count > p.limit {
-			p.log.Infof("%d/%d issues with text %q were hidden, use --max-same-issues",
-				count-p.limit, count, text)
+			hidden = append(hidden, fmt.Sprintf("%d/%d with text %q", count-p.limit, count, text))
 		}
 	})
+
+	if len(hidden) != 0 {
+		p.log.Infof("Issues were hidden by the limit of %d issues with the same text, use --max-same-issues: %s",
+			p.limit, strings.Join(hidden, ", "))
+	}
 }
 
 type kv struct {
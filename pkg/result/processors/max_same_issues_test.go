@@ -3,6 +3,8 @@ package processors
 import (
 	"testing"
 
+	"github.com/golang/mock/gomock"
+
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
@@ -10,13 +12,42 @@ import (
 func TestMaxSameIssues(t *testing.T) {
 	p := NewMaxSameIssues(1, logutils.NewStderrLog(""))
 	i1 := result.Issue{
-		Text: "1",
+		Text: "a",
 	}
 	i2 := result.Issue{
-		Text: "2",
+		Text: "b",
 	}
 
 	processAssertSame(t, p, i1)  // ok
 	processAssertSame(t, p, i2)  // ok: another
 	processAssertEmpty(t, p, i1) // skip
 }
+
+func TestMaxSameIssuesNormalizedText(t *testing.T) {
+	p := NewMaxSameIssues(1, logutils.NewStderrLog(""))
+	i1 := result.Issue{
+		Text: "Error return value of `f` is not checked at line 10",
+	}
+	i2 := result.Issue{
+		Text: "Error return value of `f`  is not checked at line 20",
+	}
+
+	processAssertSame(t, p, i1)  // ok
+	processAssertEmpty(t, p, i2) // skip: differs only in numbers and spaces
+}
+
+func TestMaxSameIssuesSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log := logutils.NewMockLog(ctrl)
+	log.EXPECT().Infof(gomock.Any(), 1, `2/3 with text "a N", 1/2 with text "b"`).Times(1)
+
+	p := NewMaxSameIssues(1, log)
+	a1 := result.Issue{Text: "a 1"}
+	a2 := result.Issue{Text: "a 2"}
+	b := result.Issue{Text: "b"}
+	processAssertSame(t, p, a1, b)
+	processAssertEmpty(t, p, a2, b, a1)
+	p.Finish()
+}
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/golangci/golangci-lint/pkg/golinters"
 	"github.com/golangci/golangci-lint/pkg/lint/astcache"
 	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
 	"github.com/golangci/golangci-lint/pkg/logutils"
@@ -15,10 +18,17 @@ import (
 
 var nolintDebugf = logutils.Debug("nolint")
 
+// allLintersName is a name in //nolint:all directive: it ignores all linters like bare //nolint
+const allLintersName = "all"
+
 type ignoredRange struct {
 	linters []string
 	result.Range
 	col int
+
+	// trailing is true when directive is placed after code on the same line:
+	// it mustn't be expanded to the node on the next line
+	trailing bool
 }
 
 func (i *ignoredRange) doesMatch(issue *result.Issue) bool {
@@ -27,7 +37,8 @@ func (i *ignoredRange) doesMatch(issue *result.Issue) bool {
 	}
 
 	if len(i.linters) == 0 {
-		return true
+		// bare //nolint without explanation mustn't suppress the issue about itself
+		return issue.FromLinter != golinters.NolintlintName
 	}
 
 	for _, linterName := range i.linters {
@@ -45,6 +56,20 @@ type fileData struct {
 
 type filesCache map[string]*fileData
 
+// excludeAtEntry is an entry of issues.exclude-at option: it suppresses
+// issues of the linter at the file line like a nolint directive
+type excludeAtEntry struct {
+	text   string
+	file   string
+	line   int
+	linter string
+	used   bool
+}
+
+func (e *excludeAtEntry) doesMatch(issue *result.Issue) bool {
+	return issue.Line() == e.line && issue.FromLinter == e.linter && filepath.Clean(issue.FilePath()) == e.file
+}
+
 type Nolint struct {
 	cache     filesCache
 	astCache  *astcache.Cache
@@ -52,16 +77,76 @@ type Nolint struct {
 	log       logutils.Log
 
 	unknownLintersSet map[string]bool
+
+	excludeAt             []*excludeAtEntry
+	reportUnusedExcludeAt bool
+
+	collectSuppressed bool
+	suppressedIssues  []result.SuppressedIssue
 }
 
-func NewNolint(astCache *astcache.Cache, log logutils.Log) *Nolint {
+// NewNolint creates processor hiding issues by nolint directives and by excludeAt
+// entries in format file:line:linter. If reportUnusedExcludeAt is set, entries
+// not matching any issue are reported in Finish.
+func NewNolint(astCache *astcache.Cache, log logutils.Log, collectSuppressed bool,
+	excludeAt []string, reportUnusedExcludeAt bool) (*Nolint, error) {
+
+	dbManager := lintersdb.NewManager() // TODO: get it in constructor
+	var entries []*excludeAtEntry
+	for _, text := range excludeAt {
+		e, err := parseExcludeAtEntry(text, dbManager)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
 	return &Nolint{
-		cache:             filesCache{},
-		astCache:          astCache,
-		dbManager:         lintersdb.NewManager(), // TODO: get it in constructor
-		log:               log,
-		unknownLintersSet: map[string]bool{},
+		cache:                 filesCache{},
+		astCache:              astCache,
+		dbManager:             dbManager,
+		log:                   log,
+		unknownLintersSet:     map[string]bool{},
+		excludeAt:             entries,
+		reportUnusedExcludeAt: reportUnusedExcludeAt,
+		collectSuppressed:     collectSuppressed,
+	}, nil
+}
+
+func parseExcludeAtEntry(text string, dbManager *lintersdb.Manager) (*excludeAtEntry, error) {
+	// file path can contain colons, e.g. on Windows: split from the end
+	linterSep := strings.LastIndex(text, ":")
+	if linterSep == -1 {
+		return nil, fmt.Errorf("invalid exclude-at entry %q: it must be in format file:line:linter", text)
 	}
+	lineSep := strings.LastIndex(text[:linterSep], ":")
+	if lineSep <= 0 {
+		return nil, fmt.Errorf("invalid exclude-at entry %q: it must be in format file:line:linter", text)
+	}
+
+	line, err := strconv.Atoi(text[lineSep+1 : linterSep])
+	if err != nil || line <= 0 {
+		return nil, fmt.Errorf("invalid line in exclude-at entry %q", text)
+	}
+
+	linterName := strings.ToLower(text[linterSep+1:])
+	lc := dbManager.GetLinterConfig(linterName)
+	if lc == nil {
+		return nil, fmt.Errorf("unknown linter %q in exclude-at entry %q", linterName, text)
+	}
+
+	return &excludeAtEntry{
+		text:   text,
+		file:   filepath.Clean(text[:lineSep]),
+		line:   line,
+		linter: lc.Name(), // normalize name to work with aliases
+	}, nil
+}
+
+// SuppressedIssues returns issues hidden by nolint directives
+// if collecting of them was enabled.
+func (p Nolint) SuppressedIssues() []result.SuppressedIssue {
+	return p.suppressedIssues
 }
 
 var _ Processor = &Nolint{}
@@ -105,6 +190,8 @@ func (p *Nolint) buildIgnoredRangesForFile(f *ast.File, fset *token.FileSet, fil
 		return nil
 	}
 
+	markTrailingRanges(f, fset, inlineRanges)
+
 	e := rangeExpander{
 		fset:         fset,
 		inlineRanges: inlineRanges,
@@ -120,6 +207,17 @@ func (p *Nolint) buildIgnoredRangesForFile(f *ast.File, fset *token.FileSet, fil
 }
 
 func (p *Nolint) shouldPassIssue(i *result.Issue) (bool, error) {
+	for _, e := range p.excludeAt {
+		if e.doesMatch(i) {
+			e.used = true
+			if p.collectSuppressed {
+				// there is no directive: position is empty
+				p.suppressedIssues = append(p.suppressedIssues, result.SuppressedIssue{Issue: *i})
+			}
+			return false, nil
+		}
+	}
+
 	fd, err := p.getOrCreateFileData(i)
 	if err != nil {
 		return false, err
@@ -127,6 +225,16 @@ func (p *Nolint) shouldPassIssue(i *result.Issue) (bool, error) {
 
 	for _, ir := range fd.ignoredRanges {
 		if ir.doesMatch(i) {
+			if p.collectSuppressed {
+				p.suppressedIssues = append(p.suppressedIssues, result.SuppressedIssue{
+					Issue: *i,
+					DirectivePos: token.Position{
+						Filename: i.FilePath(),
+						Line:     ir.From, // expanded ranges keep line of directive
+						Column:   ir.col,
+					},
+				})
+			}
 			return false, nil
 		}
 	}
@@ -151,12 +259,15 @@ func (e *rangeExpander) Visit(node ast.Node) ast.Visitor {
 
 	var foundRange *ignoredRange
 	for _, r := range e.inlineRanges {
-		if r.To == nodeStartLine-1 && nodeStartPos.Column == r.col {
+		if !r.trailing && r.To == nodeStartLine-1 && nodeStartPos.Column == r.col {
 			r := r
 			foundRange = &r
 			break
 		}
 	}
+	if foundRange == nil {
+		foundRange = e.findBlockOpeningLineRange(node)
+	}
 	if foundRange == nil {
 		return e
 	}
@@ -172,6 +283,45 @@ func (e *rangeExpander) Visit(node ast.Node) ast.Visitor {
 	return e
 }
 
+// findBlockOpeningLineRange finds inline range on the opening line
+// of grouped declaration like "var ( //nolint": it covers the whole block.
+func (e *rangeExpander) findBlockOpeningLineRange(node ast.Node) *ignoredRange {
+	decl, ok := node.(*ast.GenDecl)
+	if !ok || !decl.Lparen.IsValid() {
+		return nil
+	}
+
+	lparenPos := e.fset.Position(decl.Lparen)
+	for _, r := range e.inlineRanges {
+		if r.From == lparenPos.Line && r.col > lparenPos.Column {
+			r := r
+			return &r
+		}
+	}
+
+	return nil
+}
+
+// markTrailingRanges marks ranges of directives placed after code on the same line,
+// e.g. "} //nolint": otherwise they could leak to the next declaration.
+func markTrailingRanges(f *ast.File, fset *token.FileSet, ranges []ignoredRange) {
+	ast.Inspect(f, func(node ast.Node) bool {
+		switch node.(type) {
+		case nil, *ast.Comment, *ast.CommentGroup:
+			return false
+		}
+
+		endPos := fset.Position(node.End())
+		for i := range ranges {
+			r := &ranges[i]
+			if r.From == endPos.Line && r.col >= endPos.Column {
+				r.trailing = true
+			}
+		}
+		return true
+	})
+}
+
 func (p *Nolint) extractFileCommentsInlineRanges(fset *token.FileSet, comments ...*ast.CommentGroup) []ignoredRange {
 	var ret []ignoredRange
 	for _, g := range comments {
@@ -188,6 +338,11 @@ func (p *Nolint) extractFileCommentsInlineRanges(fset *token.FileSet, comments .
 				linterItems := strings.Split(strings.TrimPrefix(text, "nolint:"), ",")
 				for _, linter := range linterItems {
 					linterName := strings.ToLower(strings.TrimSpace(linter))
+					if linterName == allLintersName {
+						linters = nil
+						break
+					}
+
 					lc := p.dbManager.GetLinterConfig(linterName)
 					if lc == nil {
 						p.unknownLintersSet[linterName] = true
@@ -214,6 +369,18 @@ func (p *Nolint) extractFileCommentsInlineRanges(fset *token.FileSet, comments .
 }
 
 func (p Nolint) Finish() {
+	if p.reportUnusedExcludeAt {
+		var unused []string
+		for _, e := range p.excludeAt {
+			if !e.used {
+				unused = append(unused, e.text)
+			}
+		}
+		if len(unused) != 0 {
+			p.log.Warnf("Found exclude-at entries not matching any issue, remove them: %s", strings.Join(unused, ", "))
+		}
+	}
+
 	if len(p.unknownLintersSet) == 0 {
 		return
 	}
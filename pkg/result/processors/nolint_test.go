@@ -31,7 +31,8 @@ func newNolint2FileIssue(line int) result.Issue {
 }
 
 func newTestNolintProcessor(log logutils.Log) *Nolint {
-	return NewNolint(astcache.NewCache(log), log)
+	p, _ := NewNolint(astcache.NewCache(log), log, false, nil, false) // no exclude-at entries to fail on
+	return p
 }
 
 func getOkLogger(ctrl *gomock.Controller) *logutils.MockLog {
@@ -219,3 +220,163 @@ func TestIgnoredRangeMatches(t *testing.T) {
 		assert.Equal(t, testcase.expected, ir.doesMatch(&testcase.issue), testcase.doc)
 	}
 }
+
+func TestNolintSuppressedIssues(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log := getOkLogger(ctrl)
+	p, err := NewNolint(astcache.NewCache(log), log, true, nil, false)
+	assert.NoError(t, err)
+
+	processAssertEmpty(t, p, newNolintFileIssue(3, "gofmt")) // inline comment
+	processAssertEmpty(t, p, newNolintFileIssue(10, "any"))  // preceding comment
+	processAssertSame(t, p, newNolintFileIssue(1, "golint")) // no directive
+	p.Finish()
+
+	suppressed := p.SuppressedIssues()
+	assert.Len(t, suppressed, 2)
+	assert.Equal(t, 3, suppressed[0].Line())
+	assert.Equal(t, 3, suppressed[0].DirectivePos.Line)
+	assert.Equal(t, 10, suppressed[1].Line())
+	assert.Equal(t, 9, suppressed[1].DirectivePos.Line)
+	assert.Equal(t, suppressed[1].FilePath(), suppressed[1].DirectivePos.Filename)
+}
+
+func TestNolintExcludeAt(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log := getOkLogger(ctrl)
+	log.EXPECT().Warnf("Found exclude-at entries not matching any issue, remove them: %s", "nolint2.go:1:golint")
+
+	file := filepath.Join("testdata", "nolint.go")
+	p, err := NewNolint(astcache.NewCache(log), log, true,
+		[]string{"./" + file + ":1:Golint", file + ":2:megacheck", "nolint2.go:1:golint"}, true)
+	assert.NoError(t, err)
+
+	processAssertEmpty(t, p, newNolintFileIssue(1, "golint"))
+	processAssertEmpty(t, p, newNolintFileIssue(2, "megacheck"))
+	processAssertSame(t, p, newNolintFileIssue(1, "errcheck")) // another linter
+	processAssertSame(t, p, newNolintFileIssue(2, "golint"))   // another line
+	p.Finish()
+
+	suppressed := p.SuppressedIssues()
+	assert.Len(t, suppressed, 2)
+	assert.Equal(t, 0, suppressed[0].DirectivePos.Line)
+}
+
+func TestNolintInvalidExcludeAt(t *testing.T) {
+	log := logutils.NewStderrLog("")
+	for _, entry := range []string{"a.go:1", "a.go:golint", ":1:golint", "a.go:0:golint", "a.go:x:golint", "a.go:1:nolinter"} {
+		p, err := NewNolint(nil, log, false, []string{entry}, false)
+		assert.Error(t, err, entry)
+		assert.Nil(t, p)
+	}
+}
+
+func newNolintBlockFileIssue(line int, fromLinter string) result.Issue {
+	i := newNolintFileIssue(line, fromLinter)
+	i.Pos.Filename = filepath.Join("testdata", "nolint_block.go")
+	return i
+}
+
+func TestNolintBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := newTestNolintProcessor(getOkLogger(ctrl))
+	defer p.Finish()
+
+	// import block
+	for i := 3; i <= 7; i++ {
+		processAssertEmpty(t, p, newNolintBlockFileIssue(i, "any"))
+	}
+
+	// const block
+	for i := 9; i <= 13; i++ {
+		processAssertEmpty(t, p, newNolintBlockFileIssue(i, "any"))
+	}
+
+	// var block with specific linter
+	for i := 15; i <= 19; i++ {
+		processAssertEmpty(t, p, newNolintBlockFileIssue(i, "errcheck"))
+		processAssertSame(t, p, newNolintBlockFileIssue(i, "golint"))
+	}
+
+	// directive inside block covers only the next spec
+	processAssertSame(t, p, newNolintBlockFileIssue(22, "any"))
+	processAssertEmpty(t, p, newNolintBlockFileIssue(24, "any"))
+	processAssertSame(t, p, newNolintBlockFileIssue(25, "any"))
+
+	processAssertSame(t, p, newNolintBlockFileIssue(28, "any"))
+
+	// inline comment on the opening line of block
+	for i := 30; i <= 33; i++ {
+		processAssertEmpty(t, p, newNolintBlockFileIssue(i, "any"))
+	}
+
+	// inline comment on the first spec of block covers only it
+	processAssertEmpty(t, p, newNolintBlockFileIssue(36, "any"))
+	processAssertSame(t, p, newNolintBlockFileIssue(37, "any"))
+}
+
+func newNolintScopedFileIssue(line int, fromLinter string) result.Issue {
+	i := newNolintFileIssue(line, fromLinter)
+	i.Pos.Filename = filepath.Join("testdata", "nolint_scoped.go")
+	return i
+}
+
+func TestNolintScopedToLinters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := newTestNolintProcessor(getOkLogger(ctrl))
+	defer p.Finish()
+
+	// preceding directive
+	processAssertEmpty(t, p, newNolintScopedFileIssue(7, "govet"))
+	processAssertEmpty(t, p, newNolintScopedFileIssue(7, "golint"))
+	processAssertSame(t, p, newNolintScopedFileIssue(7, "errcheck"))
+
+	// inline directive
+	processAssertEmpty(t, p, newNolintScopedFileIssue(8, "govet"))
+	processAssertSame(t, p, newNolintScopedFileIssue(8, "golint"))
+
+	// no directive
+	processAssertSame(t, p, newNolintScopedFileIssue(9, "govet"))
+}
+
+func newNolintAllFileIssue(line int, fromLinter string) result.Issue {
+	i := newNolintFileIssue(line, fromLinter)
+	i.Pos.Filename = filepath.Join("testdata", "nolint_all.go")
+	return i
+}
+
+func TestNolintAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := newTestNolintProcessor(getOkLogger(ctrl))
+	defer p.Finish()
+
+	// function declaration
+	for i := 5; i <= 8; i++ {
+		processAssertEmpty(t, p, newNolintAllFileIssue(i, "govet"))
+	}
+	processAssertSame(t, p, newNolintAllFileIssue(11, "govet"))
+
+	// type declaration
+	for i := 14; i <= 17; i++ {
+		processAssertEmpty(t, p, newNolintAllFileIssue(i, "unused"))
+	}
+
+	// closure: directive doesn't leak to the enclosing function
+	processAssertEmpty(t, p, newNolintAllFileIssue(22, "govet"))
+	processAssertSame(t, p, newNolintAllFileIssue(24, "govet"))
+
+	// directive on the closing brace line covers only this line
+	processAssertSame(t, p, newNolintAllFileIssue(30, "govet"))
+	processAssertEmpty(t, p, newNolintAllFileIssue(31, "govet"))
+	processAssertSame(t, p, newNolintAllFileIssue(32, "govet"))
+}
@@ -0,0 +1,45 @@
+package processors
+
+import (
+	"path/filepath"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// OnlyFiles keeps only issues in the given files: whole packages of the files
+// are analyzed to have full type info, but issues in other files are hidden.
+// Paths of files must be relative to the current dir as paths of issues.
+type OnlyFiles struct {
+	files map[string]bool
+}
+
+var _ Processor = OnlyFiles{}
+
+// NewOnlyFiles creates processor keeping issues only in files;
+// all issues are kept if files is empty
+func NewOnlyFiles(files []string) *OnlyFiles {
+	filesSet := map[string]bool{}
+	for _, f := range files {
+		filesSet[filepath.Clean(f)] = true
+	}
+
+	return &OnlyFiles{
+		files: filesSet,
+	}
+}
+
+func (p OnlyFiles) Name() string {
+	return "only_files"
+}
+
+func (p OnlyFiles) Process(issues []result.Issue) ([]result.Issue, error) {
+	if len(p.files) == 0 {
+		return issues, nil
+	}
+
+	return filterIssues(issues, func(i *result.Issue) bool {
+		return p.files[filepath.Clean(i.FilePath())]
+	}), nil
+}
+
+func (p OnlyFiles) Finish() {}
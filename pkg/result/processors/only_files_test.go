@@ -0,0 +1,15 @@
+package processors
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestOnlyFiles(t *testing.T) {
+	p := NewOnlyFiles([]string{"a.go", filepath.Join("pkg", ".", "b.go")})
+
+	processAssertSame(t, p, newFileIssue("a.go"), newFileIssue(filepath.Join("pkg", "b.go")))
+	processAssertEmpty(t, p, newFileIssue("b.go"), newFileIssue(filepath.Join("pkg", "a.go")))
+
+	processAssertSame(t, NewOnlyFiles(nil), newFileIssue("a.go"))
+}
@@ -0,0 +1,51 @@
+package processors
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// PathAbsolutizer makes paths of issues absolute if output.path-mode is absolute:
+// relative paths are resolved from the current working dir.
+type PathAbsolutizer struct {
+	wd      string
+	enabled bool
+}
+
+var _ Processor = PathAbsolutizer{}
+
+func NewPathAbsolutizer(enabled bool) *PathAbsolutizer {
+	wd, err := fsutils.Getwd()
+	if err != nil {
+		panic(fmt.Sprintf("Can't get working dir: %s", err))
+	}
+	return &PathAbsolutizer{
+		wd:      wd,
+		enabled: enabled,
+	}
+}
+
+func (p PathAbsolutizer) Name() string {
+	return "path_absolutizer"
+}
+
+func (p PathAbsolutizer) Process(issues []result.Issue) ([]result.Issue, error) {
+	if !p.enabled {
+		return issues, nil
+	}
+
+	return transformIssues(issues, func(i *result.Issue) *result.Issue {
+		if i.FilePath() == "" || filepath.IsAbs(i.FilePath()) {
+			return i
+		}
+
+		newI := i
+		newI.Pos.Filename = filepath.Join(p.wd, i.FilePath())
+		return newI
+	}), nil
+}
+
+func (p PathAbsolutizer) Finish() {}
@@ -0,0 +1,25 @@
+package processors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/fsutils"
+)
+
+func TestPathAbsolutizer(t *testing.T) {
+	wd, err := fsutils.Getwd()
+	assert.NoError(t, err)
+
+	processAssertSame(t, NewPathAbsolutizer(false), newFileIssue(filepath.Join("a", "b.go")))
+
+	p := NewPathAbsolutizer(true)
+	processed := process(t, p, newFileIssue(filepath.Join("a", "b.go")))
+	assert.Len(t, processed, 1)
+	assert.Equal(t, filepath.Join(wd, "a", "b.go"), processed[0].FilePath())
+
+	abs := filepath.Join(wd, "c.go")
+	processAssertSame(t, p, newFileIssue(abs))
+}
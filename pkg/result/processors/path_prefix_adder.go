@@ -0,0 +1,44 @@
+package processors
+
+import (
+	"path/filepath"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// PathPrefixAdder adds the prefix to relative paths of issues: it's
+// the counterpart of PathPrefixStripper and runs after it.
+type PathPrefixAdder struct {
+	prefix string
+}
+
+var _ Processor = PathPrefixAdder{}
+
+func NewPathPrefixAdder(prefix string) *PathPrefixAdder {
+	return &PathPrefixAdder{
+		prefix: prefix,
+	}
+}
+
+func (p PathPrefixAdder) Name() string {
+	return "path_prefix_adder"
+}
+
+func (p PathPrefixAdder) Process(issues []result.Issue) ([]result.Issue, error) {
+	if p.prefix == "" {
+		return issues, nil
+	}
+
+	return transformIssues(issues, func(i *result.Issue) *result.Issue {
+		// the prefix can be added only to relative paths
+		if i.FilePath() == "" || filepath.IsAbs(i.FilePath()) {
+			return i
+		}
+
+		newI := i
+		newI.Pos.Filename = filepath.Join(p.prefix, i.FilePath())
+		return newI
+	}), nil
+}
+
+func (p PathPrefixAdder) Finish() {}
@@ -0,0 +1,35 @@
+package processors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathPrefixAdder(t *testing.T) {
+	for _, prefix := range []string{"services/api", "services/api/"} {
+		p := NewPathPrefixAdder(filepath.FromSlash(prefix))
+
+		processedIssues := process(t, p,
+			newFileIssue(filepath.FromSlash("pkg/a.go")),
+			newFileIssue("b.go"),
+			newFileIssue(filepath.FromSlash("/app/c.go")))
+
+		var paths []string
+		for _, i := range processedIssues {
+			paths = append(paths, filepath.ToSlash(i.FilePath()))
+		}
+		assert.Equal(t, []string{"services/api/pkg/a.go", "services/api/b.go", "/app/c.go"}, paths)
+	}
+}
+
+func TestPathPrefixAdderAfterStripper(t *testing.T) {
+	issues := process(t, NewPathPrefixStripper(filepath.FromSlash("/app")), newFileIssue(filepath.FromSlash("/app/pkg/a.go")))
+	issues = process(t, NewPathPrefixAdder(filepath.FromSlash("services/api")), issues...)
+	assert.Equal(t, "services/api/pkg/a.go", filepath.ToSlash(issues[0].FilePath()))
+}
+
+func TestNoPathPrefixAdder(t *testing.T) {
+	processAssertSame(t, NewPathPrefixAdder(""), newFileIssue("a/foo.go"))
+}
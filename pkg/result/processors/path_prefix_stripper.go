@@ -0,0 +1,44 @@
+package processors
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+type PathPrefixStripper struct {
+	prefix string
+}
+
+var _ Processor = PathPrefixStripper{}
+
+func NewPathPrefixStripper(prefix string) *PathPrefixStripper {
+	return &PathPrefixStripper{
+		prefix: strings.TrimSuffix(filepath.Clean(prefix), string(filepath.Separator)),
+	}
+}
+
+func (p PathPrefixStripper) Name() string {
+	return "path_prefix_stripper"
+}
+
+func (p PathPrefixStripper) Process(issues []result.Issue) ([]result.Issue, error) {
+	if p.prefix == "" || p.prefix == "." {
+		return issues, nil
+	}
+
+	return transformIssues(issues, func(i *result.Issue) *result.Issue {
+		// strip only whole path components: prefix a/b must not change a/bc.go
+		prefixWithSep := p.prefix + string(filepath.Separator)
+		if !strings.HasPrefix(i.FilePath(), prefixWithSep) {
+			return i
+		}
+
+		newI := i
+		newI.Pos.Filename = strings.TrimPrefix(i.FilePath(), prefixWithSep)
+		return newI
+	}), nil
+}
+
+func (p PathPrefixStripper) Finish() {}
@@ -0,0 +1,30 @@
+package processors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathPrefixStripper(t *testing.T) {
+	for _, prefix := range []string{"vendor/github.com/x/y", "vendor/github.com/x/y/"} {
+		p := NewPathPrefixStripper(filepath.FromSlash(prefix))
+
+		processedIssues := process(t, p,
+			newFileIssue(filepath.FromSlash("vendor/github.com/x/y/foo.go")),
+			newFileIssue(filepath.FromSlash("vendor/github.com/x/y/z/foo.go")),
+			newFileIssue(filepath.FromSlash("vendor/github.com/x/yy/foo.go")),
+			newFileIssue("foo.go"))
+
+		var paths []string
+		for _, i := range processedIssues {
+			paths = append(paths, filepath.ToSlash(i.FilePath()))
+		}
+		assert.Equal(t, []string{"foo.go", "z/foo.go", "vendor/github.com/x/yy/foo.go", "foo.go"}, paths)
+	}
+}
+
+func TestNoPathPrefixStripper(t *testing.T) {
+	processAssertSame(t, NewPathPrefixStripper(""), newFileIssue("a/foo.go"))
+}
@@ -0,0 +1,135 @@
+package processors
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/lint/lintersdb"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// SeverityRule sets severity of issues from any of linters and with file path
+// matching regexp path: empty linters or path match any issue.
+type SeverityRule struct {
+	Severity string
+	Linters  []string
+	Path     string
+}
+
+type severityRule struct {
+	severity string
+	linters  map[string]bool
+	path     *regexp.Regexp
+}
+
+func (r severityRule) match(i *result.Issue) bool {
+	if len(r.linters) != 0 && !r.linters[i.FromLinter] {
+		return false
+	}
+
+	return r.path == nil || r.path.MatchString(i.FilePath())
+}
+
+// Severity sets severity of issues by the first matched rule:
+// issues not matched by rules and rules without severity get default severity.
+type Severity struct {
+	defaultSeverity string
+	rules           []severityRule
+}
+
+var _ Processor = &Severity{}
+
+func NewSeverity(defaultSeverity string, rules []SeverityRule) (*Severity, error) {
+	if err := validateSeverity(defaultSeverity); err != nil {
+		return nil, fmt.Errorf("invalid default severity: %s", err)
+	}
+
+	p := &Severity{
+		defaultSeverity: defaultSeverity,
+	}
+
+	dbManager := lintersdb.NewManager() // TODO: get it in constructor
+
+	for n, r := range rules {
+		sr, err := newSeverityRule(r, dbManager)
+		if err != nil {
+			return nil, fmt.Errorf("invalid severity rule #%d: %s", n+1, err)
+		}
+		p.rules = append(p.rules, *sr)
+	}
+
+	return p, nil
+}
+
+func newSeverityRule(r SeverityRule, dbManager *lintersdb.Manager) (*severityRule, error) {
+	if err := validateSeverity(r.Severity); err != nil {
+		return nil, err
+	}
+	if len(r.Linters) == 0 && r.Path == "" {
+		return nil, errors.New("at least one of linters and path must be set")
+	}
+
+	sr := &severityRule{
+		severity: r.Severity,
+		linters:  map[string]bool{},
+	}
+	for _, linter := range r.Linters {
+		lc := dbManager.GetLinterConfig(strings.ToLower(linter))
+		if lc == nil {
+			return nil, fmt.Errorf("unknown linter %q", linter)
+		}
+		sr.linters[lc.Name()] = true // normalize name to work with aliases
+	}
+	if r.Path != "" {
+		re, err := regexp.Compile(r.Path)
+		if err != nil {
+			return nil, fmt.Errorf("can't compile path regexp %q: %s", r.Path, err)
+		}
+		sr.path = re
+	}
+
+	return sr, nil
+}
+
+func validateSeverity(severity string) error {
+	switch severity {
+	case "", result.SeverityError, result.SeverityWarning, result.SeverityInfo:
+		return nil
+	}
+
+	return fmt.Errorf("unknown severity %q, valid are: %s, %s, %s",
+		severity, result.SeverityError, result.SeverityWarning, result.SeverityInfo)
+}
+
+func (Severity) Name() string {
+	return "severity"
+}
+
+func (p Severity) Process(issues []result.Issue) ([]result.Issue, error) {
+	if p.defaultSeverity == "" && len(p.rules) == 0 {
+		return issues, nil
+	}
+
+	return transformIssues(issues, func(i *result.Issue) *result.Issue {
+		newI := *i
+		newI.Severity = p.severityOf(i)
+		return &newI
+	}), nil
+}
+
+func (p Severity) severityOf(i *result.Issue) string {
+	for _, r := range p.rules {
+		if r.match(i) {
+			if r.severity != "" {
+				return r.severity
+			}
+			break
+		}
+	}
+
+	return p.defaultSeverity
+}
+
+func (Severity) Finish() {}
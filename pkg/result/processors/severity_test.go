@@ -0,0 +1,76 @@
+package processors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newSeverityIssue(path, linter string) result.Issue {
+	i := newTextIssue("text")
+	i.Pos.Filename = path
+	i.FromLinter = linter
+	return i
+}
+
+func TestSeverity(t *testing.T) {
+	p, err := NewSeverity(result.SeverityWarning, []SeverityRule{
+		{Severity: result.SeverityError, Linters: []string{"govet", "errcheck"}},
+		{Severity: result.SeverityInfo, Path: `_test\.go$`},
+		{Linters: []string{"golint"}},
+		{Severity: result.SeverityInfo, Linters: []string{"golint", "lll"}},
+	})
+	assert.NoError(t, err)
+
+	var testCases = []struct {
+		path, linter, severity string
+	}{
+		{"a.go", "govet", result.SeverityError},
+		{"a_test.go", "errcheck", result.SeverityError}, // the first matched rule wins
+		{"a_test.go", "gofmt", result.SeverityInfo},
+		{"a.go", "gofmt", result.SeverityWarning},
+		{"a.go", "golint", result.SeverityWarning}, // rule without severity
+		{"a.go", "lll", result.SeverityInfo},
+	}
+	for _, tc := range testCases {
+		processed := process(t, p, newSeverityIssue(tc.path, tc.linter))
+		assert.Len(t, processed, 1)
+		assert.Equal(t, tc.severity, processed[0].Severity, "%s from %s", tc.path, tc.linter)
+	}
+}
+
+func TestSeverityNoConfig(t *testing.T) {
+	p, err := NewSeverity("", nil)
+	assert.NoError(t, err)
+	processAssertSame(t, p, newSeverityIssue("a.go", "govet"))
+}
+
+func TestSeverityInvalidConfig(t *testing.T) {
+	_, err := NewSeverity("fatal", nil)
+	assert.Error(t, err)
+
+	_, err = NewSeverity("", []SeverityRule{{Severity: "fatal", Linters: []string{"govet"}}})
+	assert.Error(t, err)
+
+	_, err = NewSeverity("", []SeverityRule{{Severity: result.SeverityError}})
+	assert.Error(t, err)
+
+	_, err = NewSeverity("", []SeverityRule{{Severity: result.SeverityError, Path: "("}})
+	assert.Error(t, err)
+
+	_, err = NewSeverity("", []SeverityRule{{Severity: result.SeverityError, Linters: []string{"govet", "unknownlinter"}}})
+	assert.EqualError(t, err, `invalid severity rule #1: unknown linter "unknownlinter"`)
+}
+
+func TestSeverityLinterAliases(t *testing.T) {
+	p, err := NewSeverity("", []SeverityRule{{Severity: result.SeverityInfo, Linters: []string{"gas", "GoVet"}}})
+	assert.NoError(t, err)
+
+	for _, linter := range []string{"gosec", "govet"} {
+		processed := process(t, p, newSeverityIssue("a.go", linter))
+		assert.Len(t, processed, 1)
+		assert.Equal(t, result.SeverityInfo, processed[0].Severity, linter)
+	}
+}
@@ -1,6 +1,7 @@
 package processors
 
 import (
+	"fmt"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -13,10 +14,11 @@ import (
 )
 
 type SkipDirs struct {
-	patterns      []*regexp.Regexp
-	log           logutils.Log
-	skippedDirs   map[string]bool
-	sortedAbsArgs []string
+	patterns         []*regexp.Regexp
+	anchoredPatterns []*regexp.Regexp // matched with dirs relative to the current dir
+	log              logutils.Log
+	skippedDirs      map[string]bool
+	sortedAbsArgs    []string
 }
 
 var _ Processor = SkipFiles{}
@@ -27,9 +29,22 @@ func (s sortedByLenStrings) Len() int           { return len(s) }
 func (s sortedByLenStrings) Less(i, j int) bool { return len(s[i]) > len(s[j]) }
 func (s sortedByLenStrings) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 
+// NewSkipDirs creates processor hiding issues in dirs matching regexps patterns: patterns
+// are matched with dirs relative to the longest containing run arg, but patterns starting
+// with / are anchored at the current dir (usually the module root), e.g. /testdata
+// matches only the top-level testdata dir and its subdirs.
 func NewSkipDirs(patterns []string, log logutils.Log, runArgs []string) (*SkipDirs, error) {
-	var patternsRe []*regexp.Regexp
+	var patternsRe, anchoredPatternsRe []*regexp.Regexp
 	for _, p := range patterns {
+		if strings.HasPrefix(p, "/") {
+			patternRe, err := regexp.Compile(fmt.Sprintf("^(%s)(/|$)", strings.TrimPrefix(p, "/")))
+			if err != nil {
+				return nil, errors.Wrapf(err, "can't compile regexp %q", p)
+			}
+			anchoredPatternsRe = append(anchoredPatternsRe, patternRe)
+			continue
+		}
+
 		patternRe, err := regexp.Compile(p)
 		if err != nil {
 			return nil, errors.Wrapf(err, "can't compile regexp %q", p)
@@ -54,10 +69,11 @@ func NewSkipDirs(patterns []string, log logutils.Log, runArgs []string) (*SkipDi
 	sort.Sort(sortedByLenStrings(sortedAbsArgs))
 
 	return &SkipDirs{
-		patterns:      patternsRe,
-		log:           log,
-		skippedDirs:   map[string]bool{},
-		sortedAbsArgs: sortedAbsArgs,
+		patterns:         patternsRe,
+		anchoredPatterns: anchoredPatternsRe,
+		log:              log,
+		skippedDirs:      map[string]bool{},
+		sortedAbsArgs:    sortedAbsArgs,
 	}, nil
 }
 
@@ -66,7 +82,7 @@ func (p SkipDirs) Name() string {
 }
 
 func (p *SkipDirs) Process(issues []result.Issue) ([]result.Issue, error) {
-	if len(p.patterns) == 0 {
+	if len(p.patterns) == 0 && len(p.anchoredPatterns) == 0 {
 		return issues, nil
 	}
 
@@ -95,6 +111,16 @@ func (p *SkipDirs) getLongestArgRelativeIssuePath(i *result.Issue) string {
 }
 
 func (p *SkipDirs) shouldPassIssue(i *result.Issue) bool {
+	if !filepath.IsAbs(i.FilePath()) { // paths were made relative to the current dir by path prettifier
+		dir := filepath.ToSlash(filepath.Dir(i.FilePath()))
+		for _, pattern := range p.anchoredPatterns {
+			if pattern.MatchString(dir) {
+				p.skippedDirs["/"+dir] = true
+				return false
+			}
+		}
+	}
+
 	relIssuePath := p.getLongestArgRelativeIssuePath(i)
 	if relIssuePath == "" {
 		return true
@@ -0,0 +1,46 @@
+package processors
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+)
+
+func newTestSkipDirs(t *testing.T, patterns ...string) *SkipDirs {
+	p, err := NewSkipDirs(patterns, logutils.NewStderrLog(""), nil)
+	assert.NoError(t, err)
+	return p
+}
+
+func TestSkipDirs(t *testing.T) {
+	processAssertSame(t, newTestSkipDirs(t), newFileIssue(filepath.Join("testdata", "a.go")))
+
+	// patterns match dirs at any level
+	processAssertEmpty(t, newTestSkipDirs(t, "skip_me"),
+		newFileIssue(filepath.Join("skip_me", "a.go")),
+		newFileIssue(filepath.Join("a", "skip_me", "b", "c.go")))
+	processAssertSame(t, newTestSkipDirs(t, "skip_me"), newFileIssue(filepath.Join("a", "b.go")))
+
+	// anchored patterns match only top-level dirs
+	p := newTestSkipDirs(t, "/testdata")
+	processAssertEmpty(t, p,
+		newFileIssue(filepath.Join("testdata", "a.go")),
+		newFileIssue(filepath.Join("testdata", "nested", "a.go")))
+	processAssertSame(t, p,
+		newFileIssue("a.go"),
+		newFileIssue(filepath.Join("pkg", "testdata", "a.go")),
+		newFileIssue(filepath.Join("testdata2", "a.go")))
+
+	processAssertEmpty(t, newTestSkipDirs(t, "/pkg/testdata"), newFileIssue(filepath.Join("pkg", "testdata", "a.go")))
+}
+
+func TestSkipDirsInvalidPattern(t *testing.T) {
+	for _, pattern := range []string{"\\o", "/\\o"} {
+		p, err := NewSkipDirs([]string{pattern}, logutils.NewStderrLog(""), nil)
+		assert.Error(t, err)
+		assert.Nil(t, p)
+	}
+}
@@ -18,7 +18,7 @@ func NewSkipFiles(patterns []string) (*SkipFiles, error) {
 	for _, p := range patterns {
 		patternRe, err := regexp.Compile(p)
 		if err != nil {
-			return nil, fmt.Errorf("can't compile regexp %q: %s", p, err)
+			return nil, fmt.Errorf("can't compile skip-files regexp %q: %s", p, err)
 		}
 		patternsRe = append(patternsRe, patternRe)
 	}
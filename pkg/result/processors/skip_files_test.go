@@ -41,6 +41,10 @@ func TestSkipFiles(t *testing.T) {
 
 	processAssertEmpty(t, newTestSkipFiles(t, ".*\\.pb\\.go$"), newFileIssue("a/b.pb.go"))
 	processAssertSame(t, newTestSkipFiles(t, ".*\\.pb\\.go$"), newFileIssue("a/b.go"))
+
+	// files in any dir
+	processAssertEmpty(t, newTestSkipFiles(t, "_mock\\.go$"), newFileIssue("a_mock.go"), newFileIssue("a/b/c_mock.go"))
+	processAssertSame(t, newTestSkipFiles(t, "_mock\\.go$"), newFileIssue("a/b/c_mock.go.txt"))
 }
 
 func TestSkipFilesInvalidPattern(t *testing.T) {
@@ -0,0 +1,64 @@
+package processors
+
+import (
+	"sort"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// SortResults sorts issues by file, line, column and linter name to make output
+// independent of the order of finishing of linters. Unlike other processors it
+// needs all issues at once: it's applied to the stream of issues after processing
+// of all batches, so issues are printed only after all linters finished.
+type SortResults struct {
+	enabled bool
+}
+
+func NewSortResults(enabled bool) *SortResults {
+	return &SortResults{
+		enabled: enabled,
+	}
+}
+
+func (SortResults) Name() string {
+	return "sort_results"
+}
+
+func (p SortResults) Process(issues <-chan result.Issue) <-chan result.Issue {
+	if !p.enabled {
+		return issues
+	}
+
+	sortedIssues := make(chan result.Issue, 1024)
+	go func() {
+		defer close(sortedIssues)
+
+		var allIssues []result.Issue
+		for i := range issues {
+			allIssues = append(allIssues, i)
+		}
+
+		sortIssues(allIssues)
+		for _, i := range allIssues {
+			sortedIssues <- i
+		}
+	}()
+
+	return sortedIssues
+}
+
+func sortIssues(issues []result.Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := &issues[i], &issues[j]
+		if a.FilePath() != b.FilePath() {
+			return a.FilePath() < b.FilePath()
+		}
+		if a.Line() != b.Line() {
+			return a.Line() < b.Line()
+		}
+		if a.Column() != b.Column() {
+			return a.Column() < b.Column()
+		}
+		return a.FromLinter < b.FromLinter
+	})
+}
@@ -0,0 +1,53 @@
+package processors
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newSortIssue(file string, line, column int, linter, text string) result.Issue {
+	return result.Issue{
+		Pos: token.Position{
+			Filename: file,
+			Line:     line,
+			Column:   column,
+		},
+		FromLinter: linter,
+		Text:       text,
+	}
+}
+
+func sortResults(p *SortResults, issues ...result.Issue) []result.Issue {
+	ch := make(chan result.Issue, len(issues))
+	for _, i := range issues {
+		ch <- i
+	}
+	close(ch)
+
+	var ret []result.Issue
+	for i := range p.Process(ch) {
+		ret = append(ret, i)
+	}
+	return ret
+}
+
+func TestSortResults(t *testing.T) {
+	issues := []result.Issue{
+		newSortIssue("b.go", 1, 1, "golint", "1"),
+		newSortIssue("a.go", 10, 1, "golint", "2"),
+		newSortIssue("a.go", 2, 5, "govet", "3"),
+		newSortIssue("a.go", 2, 5, "errcheck", "4"),
+		newSortIssue("a.go", 2, 1, "govet", "5"),
+		newSortIssue("a.go", 2, 5, "errcheck", "6"),
+	}
+
+	assert.Equal(t, []result.Issue{
+		issues[4], issues[3], issues[5], issues[2], issues[1], issues[0],
+	}, sortResults(NewSortResults(true), issues...))
+
+	assert.Equal(t, issues, sortResults(NewSortResults(false), issues...))
+}
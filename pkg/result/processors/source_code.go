@@ -4,25 +4,44 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
 
 	"github.com/golangci/golangci-lint/pkg/logutils"
 	"github.com/golangci/golangci-lint/pkg/result"
 )
 
 type linesCache [][]byte
-type filesLineCache map[string]linesCache
+
+// filesLineCache caches lines of files: unsaved contents of files from the overlay
+// are used instead of files on disk
+type filesLineCache struct {
+	files   map[string]linesCache
+	overlay map[string][]byte // contents by absolute paths
+}
+
+func newFilesLineCache(overlay map[string][]byte) *filesLineCache {
+	return &filesLineCache{
+		files:   map[string]linesCache{},
+		overlay: overlay,
+	}
+}
 
 type SourceCode struct {
-	cache filesLineCache
-	log   logutils.Log
+	cache        *filesLineCache
+	contextLines int
+	log          logutils.Log
 }
 
 var _ Processor = SourceCode{}
 
-func NewSourceCode(log logutils.Log) *SourceCode {
+// NewSourceCode creates the processor setting source lines of issues: contextLines
+// lines before and after them are set to SourceContext if contextLines is positive;
+// lines of files from the overlay are got from their unsaved contents
+func NewSourceCode(contextLines int, overlay map[string][]byte, log logutils.Log) *SourceCode {
 	return &SourceCode{
-		cache: filesLineCache{},
-		log:   log,
+		cache:        newFilesLineCache(overlay),
+		contextLines: contextLines,
+		log:          log,
 	}
 }
 
@@ -57,25 +76,72 @@ func (p SourceCode) Process(issues []result.Issue) ([]result.Issue, error) {
 			newI.SourceLines = append(newI.SourceLines, lineStr)
 		}
 
+		if p.contextLines > 0 && len(newI.SourceLines) != 0 {
+			newI.SourceContext = getSourceContext(lines, i, p.contextLines)
+		}
+
 		return &newI
 	}), nil
 }
 
+func getSourceContext(lines linesCache, i *result.Issue, contextLines int) *result.SourceContext {
+	lineRange := i.GetLineRange()
+	if lineRange.From == 0 {
+		lineRange.From = 1
+	}
+	if lineRange.To < lineRange.From {
+		lineRange.To = lineRange.From
+	}
+
+	getLines := func(from, to int) []string {
+		var ret []string
+		for line := from; line <= to; line++ {
+			if line >= 1 && line <= len(lines) {
+				ret = append(ret, string(bytes.Trim(lines[line-1], "\r")))
+			}
+		}
+		return ret
+	}
+
+	return &result.SourceContext{
+		Before: getLines(lineRange.From-contextLines, lineRange.From-1),
+		After:  getLines(lineRange.To+1, lineRange.To+contextLines),
+	}
+}
+
 func (p *SourceCode) getFileLinesForIssue(i *result.Issue) (linesCache, error) {
-	fc := p.cache[i.FilePath()]
+	return p.cache.getLines(i.FilePath())
+}
+
+func (c *filesLineCache) getLines(path string) (linesCache, error) {
+	fc := c.files[path]
 	if fc != nil {
 		return fc, nil
 	}
 
 	// TODO: make more optimal algorithm: don't load all files into memory
-	fileBytes, err := ioutil.ReadFile(i.FilePath())
+	fileBytes, err := c.readFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("can't read file %s for printing issued line: %s", i.FilePath(), err)
+		return nil, fmt.Errorf("can't read file %s for getting source lines: %s", path, err)
 	}
 	lines := bytes.Split(fileBytes, []byte("\n")) // TODO: what about \r\n?
 	fc = lines
-	p.cache[i.FilePath()] = fc
+	c.files[path] = fc
 	return fc, nil
 }
 
+func (c *filesLineCache) readFile(path string) ([]byte, error) {
+	if len(c.overlay) != 0 {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, err
+		}
+		if contents, ok := c.overlay[absPath]; ok {
+			return contents, nil
+		}
+	}
+
+	return ioutil.ReadFile(path)
+}
+
 func (p SourceCode) Finish() {}
@@ -0,0 +1,52 @@
+package processors
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestSourceCodeLines(t *testing.T) {
+	f, err := ioutil.TempFile("", "golangci-lint-source-code")
+	assert.NoError(t, err)
+	defer os.Remove(f.Name())
+	assert.NoError(t, f.Close())
+	assert.NoError(t, ioutil.WriteFile(f.Name(), []byte("package p\n\nfunc F() {\r\n\tf.Close()\n\tg()\n}\n"), os.ModePerm))
+
+	issue := newFileIssue(f.Name())
+	issue.Pos.Line = 4
+	issue.LineRange = &result.Range{From: 4, To: 5}
+
+	processedIssues := process(t, NewSourceCode(0, nil, logutils.NewStderrLog("")), issue)
+	assert.Len(t, processedIssues, 1)
+	assert.Equal(t, []string{"\tf.Close()", "\tg()"}, processedIssues[0].SourceLines)
+	assert.Nil(t, processedIssues[0].SourceContext)
+
+	processedIssues = process(t, NewSourceCode(2, nil, logutils.NewStderrLog("")), issue)
+	assert.Len(t, processedIssues, 1)
+	assert.Equal(t, []string{"\tf.Close()", "\tg()"}, processedIssues[0].SourceLines)
+	assert.Equal(t, &result.SourceContext{
+		Before: []string{"", "func F() {"},
+		After:  []string{"}", ""},
+	}, processedIssues[0].SourceContext)
+}
+
+func TestSourceCodeLinesOverlay(t *testing.T) {
+	absPath, err := filepath.Abs(filepath.Join("testdata", "exclude_rules.go"))
+	assert.NoError(t, err)
+
+	issue := newFileIssue(filepath.Join("testdata", "exclude_rules.go"))
+	issue.Pos.Line = 3
+
+	// the file on disk has "//go:generate ..." at the line
+	overlay := map[string][]byte{absPath: []byte("package p\n\nvar unsaved = 1\n")}
+	processedIssues := process(t, NewSourceCode(0, overlay, logutils.NewStderrLog("")), issue)
+	assert.Len(t, processedIssues, 1)
+	assert.Equal(t, []string{"var unsaved = 1"}, processedIssues[0].SourceLines)
+}
@@ -0,0 +1,158 @@
+package processors
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/lint/astcache"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+var structTagDebugf = logutils.Debug("struct_tag_exclude")
+
+type excludedTag struct {
+	key   string
+	value string // empty value means any value
+}
+
+func (t excludedTag) doesMatch(tag reflect.StructTag) bool {
+	value, ok := tag.Lookup(t.key)
+	if !ok {
+		return false
+	}
+
+	if t.value == "" {
+		return true
+	}
+
+	for _, v := range strings.Split(value, ",") {
+		if strings.TrimSpace(v) == t.value {
+			return true
+		}
+	}
+
+	return false
+}
+
+type stFileSummary struct {
+	excludedRanges []result.Range
+}
+
+type stFileSummaryCache map[string]*stFileSummary
+
+type StructTagExclude struct {
+	tags             []excludedTag
+	fileSummaryCache stFileSummaryCache
+	astCache         *astcache.Cache
+}
+
+// NewStructTagExclude creates processor excluding issues on struct fields with tags:
+// every tag is a `key` (any value) or a `key:value` pair, e.g. `lint:ignore`.
+func NewStructTagExclude(tags []string, astCache *astcache.Cache) *StructTagExclude {
+	var excludedTags []excludedTag
+	for _, t := range tags {
+		parts := strings.SplitN(t, ":", 2)
+		et := excludedTag{
+			key: strings.TrimSpace(parts[0]),
+		}
+		if len(parts) == 2 {
+			et.value = strings.TrimSpace(parts[1])
+		}
+		excludedTags = append(excludedTags, et)
+	}
+
+	return &StructTagExclude{
+		tags:             excludedTags,
+		fileSummaryCache: stFileSummaryCache{},
+		astCache:         astCache,
+	}
+}
+
+var _ Processor = &StructTagExclude{}
+
+func (p StructTagExclude) Name() string {
+	return "struct_tag_exclude"
+}
+
+func (p *StructTagExclude) Process(issues []result.Issue) ([]result.Issue, error) {
+	if len(p.tags) == 0 {
+		return issues, nil
+	}
+
+	return filterIssuesErr(issues, p.shouldPassIssue)
+}
+
+func (p *StructTagExclude) shouldPassIssue(i *result.Issue) (bool, error) {
+	fs, err := p.getOrCreateFileSummary(i)
+	if err != nil {
+		return false, err
+	}
+
+	for _, r := range fs.excludedRanges {
+		if i.Line() >= r.From && i.Line() <= r.To {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (p *StructTagExclude) getOrCreateFileSummary(i *result.Issue) (*stFileSummary, error) {
+	fs := p.fileSummaryCache[i.FilePath()]
+	if fs != nil {
+		return fs, nil
+	}
+
+	fs = &stFileSummary{}
+	p.fileSummaryCache[i.FilePath()] = fs
+
+	if i.FilePath() == "" {
+		return nil, fmt.Errorf("no file path for issue")
+	}
+
+	f := p.astCache.GetOrParse(i.FilePath(), nil)
+	if f.Err != nil {
+		return nil, fmt.Errorf("can't parse file %s: %s", i.FilePath(), f.Err)
+	}
+
+	fs.excludedRanges = p.findExcludedFields(f.F, f.Fset)
+	structTagDebugf("file %s: excluded struct fields ranges are %+v", i.FilePath(), fs.excludedRanges)
+	return fs, nil
+}
+
+func (p *StructTagExclude) findExcludedFields(f *ast.File, fset *token.FileSet) []result.Range {
+	var ret []result.Range
+	ast.Inspect(f, func(node ast.Node) bool {
+		field, ok := node.(*ast.Field)
+		if !ok || field.Tag == nil {
+			return true
+		}
+
+		tagValue, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			return true
+		}
+
+		tag := reflect.StructTag(tagValue)
+		for _, t := range p.tags {
+			if t.doesMatch(tag) {
+				ret = append(ret, result.Range{
+					From: fset.Position(field.Pos()).Line,
+					To:   fset.Position(field.End()).Line,
+				})
+				break
+			}
+		}
+
+		return true
+	})
+
+	return ret
+}
+
+func (p StructTagExclude) Finish() {}
@@ -0,0 +1,40 @@
+package processors
+
+import (
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/golangci/golangci-lint/pkg/lint/astcache"
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newStructTagIssue(line int) result.Issue {
+	return result.Issue{
+		Pos: token.Position{
+			Filename: filepath.Join("testdata", "struct_tag_exclude.go"),
+			Line:     line,
+		},
+	}
+}
+
+func TestStructTagExclude(t *testing.T) {
+	p := NewStructTagExclude([]string{"lint:ignore", "skip"}, astcache.NewCache(logutils.NewStderrLog("")))
+
+	processAssertEmpty(t, p, newStructTagIssue(4)) // tag with value
+	processAssertSame(t, p, newStructTagIssue(5))  // other tag
+	processAssertEmpty(t, p, newStructTagIssue(6)) // one of values
+	processAssertSame(t, p, newStructTagIssue(7))  // other value
+
+	// multiline field with any value of tag
+	for i := 8; i <= 10; i++ {
+		processAssertEmpty(t, p, newStructTagIssue(i))
+	}
+
+	processAssertSame(t, p, newStructTagIssue(3)) // not a field
+}
+
+func TestNoStructTagExclude(t *testing.T) {
+	processAssertSame(t, NewStructTagExclude(nil, nil), newStructTagIssue(4))
+}
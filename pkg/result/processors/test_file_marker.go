@@ -0,0 +1,35 @@
+package processors
+
+import (
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+// TestFileMarker sets IsTest of issues in test files (*_test.go):
+// consumers of json output can tell test and production code issues apart.
+type TestFileMarker struct{}
+
+var _ Processor = TestFileMarker{}
+
+func NewTestFileMarker() *TestFileMarker {
+	return &TestFileMarker{}
+}
+
+func (p TestFileMarker) Name() string {
+	return "test_file_marker"
+}
+
+func (p TestFileMarker) Process(issues []result.Issue) ([]result.Issue, error) {
+	return transformIssues(issues, func(i *result.Issue) *result.Issue {
+		if !strings.HasSuffix(i.FilePath(), "_test.go") {
+			return i
+		}
+
+		newI := *i
+		newI.IsTest = true
+		return &newI
+	}), nil
+}
+
+func (p TestFileMarker) Finish() {}
@@ -0,0 +1,21 @@
+package processors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func TestTestFileMarker(t *testing.T) {
+	issues, err := NewTestFileMarker().Process([]result.Issue{
+		newLinterFileIssue("golint", "a.go"),
+		newLinterFileIssue("golint", "a_test.go"),
+		newLinterFileIssue("golint", "pkg/test.go"),
+	})
+	assert.NoError(t, err)
+	assert.False(t, issues[0].IsTest)
+	assert.True(t, issues[1].IsTest)
+	assert.False(t, issues[2].IsTest)
+}
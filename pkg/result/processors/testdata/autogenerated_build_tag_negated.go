@@ -0,0 +1,6 @@
+//go:build !generated
+// +build !generated
+
+package testdata
+
+var AutogeneratedBuildTagNegated int
@@ -0,0 +1,5 @@
+// @generated by protoc-gen-foo
+
+package testdata
+
+var AutogeneratedCustomMarker int
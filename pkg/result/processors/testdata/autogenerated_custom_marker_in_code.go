@@ -0,0 +1,6 @@
+package testdata
+
+import "fmt"
+
+// @generated by protoc-gen-foo: marker after imports doesn't mark file as generated
+var AutogeneratedCustomMarkerInCode = fmt.Sprint("@generated by")
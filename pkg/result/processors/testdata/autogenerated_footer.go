@@ -0,0 +1,9 @@
+package testdata
+
+import "fmt"
+
+func AutogeneratedFooter() {
+	fmt.Println("footer")
+}
+
+// This file was generated by a build rule. DO NOT EDIT.
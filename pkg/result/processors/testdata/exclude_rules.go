@@ -0,0 +1,5 @@
+package testdata
+
+//go:generate stringer -type=Kind
+
+func F() {}
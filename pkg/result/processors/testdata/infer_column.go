@@ -0,0 +1,11 @@
+package testdata
+
+// F is a function
+func F() string {
+	s := `multiline
+string`
+	if s == "" {
+		return s
+	}
+	return "a"
+}
@@ -0,0 +1,33 @@
+package testdata
+
+import "fmt"
+
+//nolint:all
+func nolintAllFunc() {
+	fmt.Printf("%d", "a")
+}
+
+func dontNolintAllAfterFunc() {
+	fmt.Printf("%d", "b")
+}
+
+//nolint:all
+type nolintAllType struct {
+	a int
+}
+
+func nolintAllClosure() {
+	//nolint:all
+	f := func() {
+		fmt.Printf("%d", "c")
+	}
+	fmt.Printf("%d", "d")
+	f()
+}
+
+func nolintAllNestedFunc() {
+	func() {
+		fmt.Printf("%d", "e")
+	}() //nolint:all
+	fmt.Printf("%d", "f")
+}
@@ -0,0 +1,38 @@
+package testdata
+
+//nolint
+import (
+	"fmt"
+	"os"
+)
+
+//nolint
+const (
+	nolintConstA = "a"
+	nolintConstB = "b"
+)
+
+//nolint:errcheck
+var (
+	nolintVarA = fmt.Sprint()
+	nolintVarB = os.Args
+)
+
+const (
+	dontNolintConstA = "a"
+	//nolint
+	nolintConstInBlock = "b"
+	dontNolintConstB   = "c"
+)
+
+var dontNolintVarAfterBlocks int
+
+var ( //nolint
+	nolintVarByInlineBlockCommentA int
+	nolintVarByInlineBlockCommentB int
+)
+
+const (
+	dontNolintConstC = "c" //nolint
+	dontNolintConstD = "d"
+)
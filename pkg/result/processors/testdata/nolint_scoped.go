@@ -0,0 +1,10 @@
+package testdata
+
+import "fmt"
+
+func nolintScopedStatements() {
+	//nolint:golint,govet
+	fmt.Printf("%d", "a")
+	fmt.Printf("%d", "b") //nolint:govet
+	fmt.Printf("%d", "c")
+}
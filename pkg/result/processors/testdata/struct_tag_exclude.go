@@ -0,0 +1,11 @@
+package testdata
+
+type T struct {
+	A int `lint:"ignore"`
+	B int `json:"b"`
+	C int `json:"c" lint:"other,ignore"`
+	D int `lint:"other"`
+	E struct {
+		F int
+	} `skip:""`
+}
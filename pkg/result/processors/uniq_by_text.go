@@ -0,0 +1,76 @@
+package processors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golangci/golangci-lint/pkg/logutils"
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+type issuePositionAndText struct {
+	file         string
+	line, column int
+	text         string
+}
+
+// UniqByText keeps only the first of issues with the same position and text:
+// the same problem is often reported by several linters, e.g. govet and staticcheck.
+// Reporting linters are recorded: the kept issue can be already printed when
+// duplicates are found, so they are only logged.
+type UniqByText struct {
+	reportedBy map[issuePositionAndText][]string
+	log        logutils.Log
+}
+
+func NewUniqByText(log logutils.Log) *UniqByText {
+	return &UniqByText{
+		reportedBy: map[issuePositionAndText][]string{},
+		log:        log,
+	}
+}
+
+var _ Processor = &UniqByText{}
+
+func (p UniqByText) Name() string {
+	return "uniq_by_text"
+}
+
+func normalizeIssueText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+func (p *UniqByText) Process(issues []result.Issue) ([]result.Issue, error) {
+	return filterIssues(issues, func(i *result.Issue) bool {
+		key := issuePositionAndText{
+			file:   i.FilePath(),
+			line:   i.Line(),
+			column: i.Column(),
+			text:   normalizeIssueText(i.Text),
+		}
+
+		linters, found := p.reportedBy[key]
+		p.reportedBy[key] = append(linters, i.FromLinter)
+		return !found
+	}), nil
+}
+
+func (p UniqByText) Finish() {
+	linterToCount := map[string]int{}
+	for _, linters := range p.reportedBy {
+		if len(linters) > 1 {
+			linterToCount[strings.Join(linters, ", ")] += len(linters) - 1
+		}
+	}
+
+	var hidden []string
+	for linters, count := range linterToCount {
+		hidden = append(hidden, fmt.Sprintf("%d by [%s]", count, linters))
+	}
+	sort.Strings(hidden)
+
+	if len(hidden) != 0 {
+		p.log.Infof("Duplicated issues were hidden, reported by linters: %s", strings.Join(hidden, "; "))
+	}
+}
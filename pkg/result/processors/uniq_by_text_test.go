@@ -0,0 +1,38 @@
+package processors
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/golangci/golangci-lint/pkg/result"
+)
+
+func newUniqByTextIssue(linter string, line, column int, text string) result.Issue {
+	return result.Issue{
+		FromLinter: linter,
+		Text:       text,
+		Pos: token.Position{
+			Filename: "f1",
+			Line:     line,
+			Column:   column,
+		},
+	}
+}
+
+func TestUniqByText(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	p := NewUniqByText(getOkLogger(ctrl))
+
+	processAssertSame(t, p, newUniqByTextIssue("govet", 1, 2, "unreachable code"))
+	processAssertEmpty(t, p, newUniqByTextIssue("staticcheck", 1, 2, "Unreachable  code")) // normalized text
+
+	processAssertSame(t, p, newUniqByTextIssue("staticcheck", 1, 3, "unreachable code")) // another column
+	processAssertSame(t, p, newUniqByTextIssue("staticcheck", 2, 2, "unreachable code")) // another line
+	processAssertSame(t, p, newUniqByTextIssue("golint", 1, 2, "another text"))
+
+	p.Finish()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"sort"
+
+	"github.com/golangci/golangci-lint/pkg/lintlock"
+)
+
+func main() {
+	const (
+		modulesTxtPath = "vendor/modules.txt"
+		outPath        = "pkg/lintlock/modules.go"
+	)
+
+	if err := genModules(modulesTxtPath, outPath); err != nil {
+		log.Fatalf("failed: %s", err)
+	}
+	log.Printf("Successfully generated %s", outPath)
+}
+
+func genModules(modulesTxtPath, outPath string) error {
+	f, err := os.Open(modulesTxtPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	modules, err := lintlock.ParseModulesTxt(f)
+	if err != nil {
+		return fmt.Errorf("can't parse %s: %s", modulesTxtPath, err)
+	}
+
+	paths := make([]string, 0, len(modules))
+	for path := range modules {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by scripts/gen_lintlock from %s. DO NOT EDIT.\n\n", modulesTxtPath)
+	fmt.Fprintf(&buf, "package lintlock\n\n")
+	fmt.Fprintf(&buf, "// vendoredModules are versions of modules linked into golangci-lint\n")
+	fmt.Fprintf(&buf, "var vendoredModules = map[string]string{\n")
+	for _, path := range paths {
+		fmt.Fprintf(&buf, "%q: %q,\n", path, modules[path])
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(outPath, src, 0644)
+}
@@ -0,0 +1,75 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/golangci/golangci-lint/pkg/config"
+	"github.com/golangci/golangci-lint/pkg/lint"
+)
+
+func TestRunAPI(t *testing.T) {
+	cfg := config.NewDefault()
+	cfg.Linters.DisableAll = true
+	cfg.Linters.Enable = []string{"golint"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ { // concurrent runs share the config
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			issues, err := lint.Run(context.Background(), cfg, []string{getTestDataDir("skipdirs", "...")})
+			require.NoError(t, err)
+
+			// default skip dirs of the run command are applied: examples dir is skipped
+			var returnIssues int
+			for _, i := range issues {
+				assert.Equal(t, "golint", i.FromLinter)
+				if strings.Contains(i.Text, "if block ends with a return statement") {
+					returnIssues++
+				}
+			}
+			assert.Equal(t, 2, returnIssues)
+		}()
+	}
+	wg.Wait()
+
+	assert.Empty(t, cfg.Run.Args)
+	assert.Equal(t, config.NewDefault().Run, cfg.Run)
+}
+
+func TestRunAPIMaxSameIssues(t *testing.T) {
+	cfg := config.NewDefault()
+	cfg.Linters.DisableAll = true
+	cfg.Linters.Enable = []string{"golint"}
+	cfg.Issues.MaxSameIssues = 1
+
+	issues, err := lint.Run(context.Background(), cfg, []string{getTestDataDir("skipdirs", "...")})
+	require.NoError(t, err)
+	assert.Len(t, issues, 1)
+}
+
+func TestRunAPIError(t *testing.T) {
+	cfg := config.NewDefault()
+	cfg.Linters.DisableAll = true
+	cfg.Linters.Enable = []string{"golnt"}
+
+	_, err := lint.Run(context.Background(), cfg, []string{getTestDataDir("skipdirs", "...")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "golnt")
+}
+
+func TestRunAPIInvalidGoVersion(t *testing.T) {
+	cfg := config.NewDefault()
+	cfg.Run.GoVersion = "go1.10"
+
+	_, err := lint.Run(context.Background(), cfg, []string{getTestDataDir("skipdirs", "...")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid Go version "go1.10"`)
+}
@@ -1,8 +1,16 @@
 package test
 
 import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/golangci/golangci-lint/test/testshared"
 
 	"github.com/golangci/golangci-lint/pkg/exitcodes"
@@ -22,6 +30,17 @@ func TestEmptyDirRun(t *testing.T) {
 		ExpectOutputContains(": no go files to analyze")
 }
 
+func TestNotMatchedImportPathRun(t *testing.T) {
+	testshared.NewLintRunner(t).Run("github.com/golangci/golangci-lint/no_such_pkg/...").
+		ExpectExitCode(exitcodes.NoGoFiles).
+		ExpectOutputContains("patterns github.com/golangci/golangci-lint/no_such_pkg/... matched no packages")
+}
+
+func TestImportPathRun(t *testing.T) {
+	testshared.NewLintRunner(t).Run("--no-config", "--disable-all", "-Egolint",
+		"github.com/golangci/golangci-lint/pkg/exitcodes").ExpectNoIssues()
+}
+
 func TestNotExistingDirRun(t *testing.T) {
 	testshared.NewLintRunner(t).Run(getTestDataDir("no_such_dir")).
 		ExpectHasIssue(`cannot find package \"./testdata/no_such_dir\"`)
@@ -31,10 +50,71 @@ func TestSymlinkLoop(t *testing.T) {
 	testshared.NewLintRunner(t).Run(getTestDataDir("symlink_loop", "...")).ExpectNoIssues()
 }
 
-func TestDeadline(t *testing.T) {
-	testshared.NewLintRunner(t).Run("--deadline=1ms", getProjectRoot()).
+func TestRespectGitignore(t *testing.T) {
+	// the git repository is created at runtime: files ignored by a committed .gitignore
+	// wouldn't be committed into this repository
+	dir := getTestDataDir("gitignore_repo")
+	require.NoError(t, os.RemoveAll(dir))
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		".gitignore":   "build/\n",
+		"build/gen.go": "package build\n\nfunc F() {\n\tif true {\n\t\treturn\n\t} else {\n\t\treturn\n\t}\n}\n",
+		"pkg/a.go":     "// Package pkg is for tests\npackage pkg\n",
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), os.ModePerm))
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), os.ModePerm))
+	}
+
+	args := []string{"--no-config", "--disable-all", "-Egolint", filepath.Join(dir, "...")}
+	r := testshared.NewLintRunner(t)
+	r.Run(args...).
+		ExpectHasIssue("if block ends with a return statement")
+	r.Run(append(args, "--respect-gitignore")...).
+		ExpectNoIssues()
+}
+
+func TestTimeout(t *testing.T) {
+	testshared.NewLintRunner(t).Run("--timeout=1ms", getProjectRoot()).
+		ExpectExitCode(exitcodes.Timeout).
+		ExpectOutputContains(`Timeout exceeded: try increase it by passing --timeout option`)
+}
+
+func TestDeprecatedDeadline(t *testing.T) {
+	r := testshared.NewLintRunner(t)
+	r.Run("-v", "--deadline=1ms", getProjectRoot()).
+		ExpectExitCode(exitcodes.Timeout).
+		ExpectOutputContains("Flag --deadline is deprecated, use --timeout instead").
+		ExpectOutputContains(`Timeout exceeded: try increase it by passing --timeout option`)
+	r.RunWithYamlConfig(`
+		run:
+			deadline: 1ms
+		`, "-v", getProjectRoot()).
 		ExpectExitCode(exitcodes.Timeout).
-		ExpectOutputContains(`Deadline exceeded: try increase it by passing --deadline option`)
+		ExpectOutputContains("Option run.deadline is deprecated, use run.timeout instead")
+	r.RunWithYamlConfig(`
+		run:
+			deadline: 1ms
+			timeout: 5m
+		`, "--disable-all", "-Egolint", getTestDataDir("withtests")).
+		ExpectHasIssue("if block ends with a return")
+}
+
+func TestPathPrefixAliases(t *testing.T) {
+	r := testshared.NewLintRunner(t)
+	r.RunWithYamlConfig(`
+		issues:
+			path-prefix-add: services/api
+		`, "--disable-all", "-Egolint", getTestDataDir("withtests")).
+		ExpectOutputContains(filepath.Join("services", "api", "testdata", "withtests"))
+	r.RunWithYamlConfig(
+		`{output: {path-prefix: services/web}, issues: {path-prefix-add: services/api}}`,
+		"--disable-all", "-Egolint", getTestDataDir("withtests")).
+		ExpectOutputContains(filepath.Join("services", "web", "testdata", "withtests")).
+		ExpectOutputNotContains("services/api")
 }
 
 func TestTestsAreLintedByDefault(t *testing.T) {
@@ -42,6 +122,65 @@ func TestTestsAreLintedByDefault(t *testing.T) {
 		ExpectHasIssue("if block ends with a return")
 }
 
+func TestTestsAreExcludedForLinters(t *testing.T) {
+	args := []string{"--disable-all", "-Egolint", getTestDataDir("withtests")}
+
+	r := testshared.NewLintRunner(t)
+	r.RunWithYamlConfig(`
+		issues:
+			exclude-rules:
+				- path: _test\.go
+				  linters:
+						- golint
+	`, args...).ExpectNoIssues()
+	r.RunWithYamlConfig(`
+		issues:
+			exclude-rules:
+				- path: _test\.go
+				  linters:
+						- errcheck
+	`, args...).ExpectHasIssue("if block ends with a return")
+}
+
+func TestYamlAnchorsInConfig(t *testing.T) {
+	args := []string{"--disable-all", "-Egolint", getTestDataDir("withtests")}
+
+	r := testshared.NewLintRunner(t)
+	r.RunWithYamlConfig(`
+		# the shared rule is defined before its usage
+		x-golint-rule: &golint-rule
+			linters:
+				- golint
+			text: if block
+		issues:
+			exclude-rules:
+				- <<: *golint-rule
+				  path: _test\.go
+	`, args...).ExpectNoIssues()
+	r.RunWithYamlConfig(`
+		issues:
+			exclude-rules:
+				- &golint-rule
+				  path: _test\.go
+				  linters:
+						- errcheck
+				- <<: *golint-rule
+				  linters:
+						- golint
+	`, args...).ExpectNoIssues()
+	r.RunWithYamlConfig(`
+		# the merged text doesn't match the issue
+		x-golint-rule: &golint-rule
+			linters:
+				- golint
+			text: unknown text
+		issues:
+			exclude-rules:
+				- <<: *golint-rule
+				  path: _test\.go
+	`, args...).ExpectHasIssue("if block ends with a return")
+}
+
 func TestCgoOk(t *testing.T) {
 	testshared.NewLintRunner(t).Run("--enable-all", getTestDataDir("cgo")).ExpectNoIssues()
 }
@@ -51,6 +190,38 @@ func TestCgoWithIssues(t *testing.T) {
 		ExpectHasIssue("Printf format %t has arg cs of wrong type")
 }
 
+func TestGovetAnalyzersSettings(t *testing.T) {
+	r := testshared.NewLintRunner(t)
+	r.RunWithYamlConfig(`
+		linters-settings:
+			govet:
+				enable:
+					- printf
+	`, "--disable-all", "-Egovet", getTestDataDir("cgo_with_issues")).
+		ExpectHasIssue("Printf format %t has arg cs of wrong type")
+	r.RunWithYamlConfig(`
+		linters-settings:
+			govet:
+				disable:
+					- printf
+	`, "--disable-all", "-Egovet", getTestDataDir("cgo_with_issues")).
+		ExpectNoIssues()
+	r.RunWithYamlConfig(`
+		linters-settings:
+			govet:
+				enable:
+					- nilness
+	`, "--disable-all", "-Egovet", getTestDataDir("cgo_with_issues")).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains(`no such govet analyzer \"nilness\"`)
+}
+
+func TestCgoWithBuildTags(t *testing.T) {
+	testshared.NewLintRunner(t).Run("--enable-all", getTestDataDir("cgo_build_tags")).ExpectNoIssues()
+	testshared.NewLintRunner(t).Run("--enable-all", "--build-tags=integration", getTestDataDir("cgo_build_tags")).
+		ExpectHasIssue("ineffectual assignment to `x`")
+}
+
 func TestUnsafeOk(t *testing.T) {
 	testshared.NewLintRunner(t).Run("--enable-all", getTestDataDir("unsafe")).ExpectNoIssues()
 }
@@ -64,6 +235,139 @@ func TestSkippedDirs(t *testing.T) {
 			"a return statement, so drop this else and outdent its block (golint)\n")
 }
 
+func TestSkipDirsUseDefaultAndAnchoring(t *testing.T) {
+	args := []string{"--print-issued-lines=false", "--no-config", "--disable-all", "-Egolint",
+		getTestDataDir("skipdirs", "...")}
+
+	r := testshared.NewLintRunner(t)
+	r.Run(append(args, "--skip-dirs-use-default=false", "--skip-dirs=/testdata/skipdirs/skip_me")...).
+		ExpectExitCode(exitcodes.IssuesFound).
+		ExpectOutputEq("testdata/skipdirs/examples/with_issue.go:8:9: if block ends with " +
+			"a return statement, so drop this else and outdent its block (golint)\n" +
+			"testdata/skipdirs/examples_no_skip/with_issue.go:8:9: if block ends with " +
+			"a return statement, so drop this else and outdent its block (golint)\n")
+
+	// anchored pattern doesn't match nested dirs
+	r.Run(append(args, "--skip-dirs=/skip_me")...).
+		ExpectHasIssue("testdata/skipdirs/skip_me/nested/with_issue.go:8:9")
+}
+
+func TestIssuesExitCode(t *testing.T) {
+	args := []string{"--no-config", "--disable-all", "-Egolint", getTestDataDir("skipdirs", "...")}
+
+	r := testshared.NewLintRunner(t)
+	r.Run(append(args, "--issues-exit-code=0")...).ExpectExitCode(exitcodes.Success)
+	r.Run(append(args, "--issues-exit-code=10")...).ExpectExitCode(10)
+	r.Run(append(args, "--issues-exit-code=4")...).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("invalid issues exit code 4: it's reserved for timeout")
+}
+
+func TestConcurrency(t *testing.T) {
+	args := []string{"--no-config", "--disable-all", "-Egolint", getTestDataDir("skipdirs", "...")}
+
+	r := testshared.NewLintRunner(t)
+	r.Run(append(args, "-j1")...).ExpectHasIssue("if block ends with a return statement")
+	r.Run(append(args, "-j0")...).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("invalid concurrency 0: it must be positive")
+}
+
+func TestFailOnLinterErrors(t *testing.T) {
+	cfg := `
+		linters-settings:
+			misspell:
+				locale: NZ
+	`
+	args := []string{"--disable-all", "-Emisspell", getTestDataDir("skipdirs", "...")}
+
+	r := testshared.NewLintRunner(t)
+	r.RunWithYamlConfig(cfg, args...).
+		ExpectExitCode(exitcodes.Success, exitcodes.WarningInTest). // warnings fail test runs with GL_TEST_RUN=1
+		ExpectOutputContains(`Linter misspell failed to run, its issues aren't reported: unknown locale: \"NZ\"`)
+	r.RunWithYamlConfig(cfg, append(args, "--fail-on-linter-errors")...).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("linters failed to run: misspell")
+}
+
+func TestLinterTimeout(t *testing.T) {
+	args := []string{"--disable-all", "-Egolint", "--fail-on-linter-errors", getTestDataDir("skipdirs", "...")}
+
+	r := testshared.NewLintRunner(t)
+	r.RunWithYamlConfig(`
+		linters-settings:
+			golint:
+				timeout: 1ns
+	`, args...).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("Linter golint failed to run, its issues aren't reported: timeout 1ns of the linter exceeded")
+	r.RunWithYamlConfig(`
+		linters-settings:
+			golint:
+				timeout: 1m
+	`, args...).
+		ExpectHasIssue("if block ends with a return statement")
+	r.RunWithYamlConfig(`
+		linters-settings:
+			golnt:
+				timeout: 1m
+	`, args...).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("unknown key `golnt` under `linters-settings`, did you mean `golint`?")
+}
+
+func TestCustomLinterLoadFailure(t *testing.T) {
+	r := testshared.NewLintRunner(t)
+	r.RunWithYamlConfig(`
+		linters-settings:
+			custom:
+				example:
+					path: /not/existing/example.so
+	`, "--disable-all", "-Eexample", getTestDataDir("skipdirs", "...")).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("Can't load custom linters: custom linter example: can't load plugin /not/existing/example.so")
+	r.RunWithYamlConfig(`
+		linters-settings:
+			custom:
+				golint:
+					path: /not/existing/golint.so
+	`, "--disable-all", "-Egolint", getTestDataDir("skipdirs", "...")).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("custom linter golint: linter with this name already exists")
+}
+
+func TestStopOnTypecheckErrors(t *testing.T) {
+	args := []string{"--no-config", "--disable-all", "-Egolint", "-Etypecheck", getTestDataDir("typecheck_with_issues")}
+	const golintIssue = "if block ends with a return statement"
+	const typecheckIssue = "cannot use F(1) (value of type int) as string value in variable declaration"
+
+	r := testshared.NewLintRunner(t)
+	r.Run(args...).ExpectHasIssue(golintIssue).ExpectHasIssue(typecheckIssue)
+	r.Run(append(args, "--stop-on-typecheck-errors")...).
+		ExpectHasIssue(typecheckIssue).
+		ExpectOutputNotContains(golintIssue)
+	r.Run(append(args, "--stop-on-typecheck-errors", "--exclude=cannot use")...).
+		ExpectHasIssue(golintIssue).
+		ExpectOutputNotContains(typecheckIssue)
+}
+
+func TestErrorAndWarningLinters(t *testing.T) {
+	args := []string{"--no-config", "--disable-all", "-Egolint", getTestDataDir("skipdirs", "...")}
+	const golintIssue = "if block ends with a return statement"
+
+	r := testshared.NewLintRunner(t)
+	r.Run(append(args, "--warning-linters=golint")...).
+		ExpectExitCode(exitcodes.Success).
+		ExpectOutputContains(golintIssue)
+	r.Run(append(args, "--error-linters=golint")...).ExpectHasIssue(golintIssue)
+	r.Run(append(args, "--error-linters=misspell")...).
+		ExpectExitCode(exitcodes.Success).
+		ExpectOutputContains(golintIssue)
+	r.Run(append(args, "--error-linters=golnt")...).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains(`no such linter \"golnt\" in option issues.error-linters`)
+}
+
 func TestDeadcodeNoFalsePositivesInMainPkg(t *testing.T) {
 	testshared.NewLintRunner(t).Run("--no-config", "--disable-all", "-Edeadcode", getTestDataDir("deadcode_main_pkg")).ExpectNoIssues()
 }
@@ -83,6 +387,32 @@ func TestConfigFileIsDetected(t *testing.T) {
 	checkGotConfig(r.Run(getTestDataDir("withconfig", "...")))
 }
 
+func TestConfigFileFromOption(t *testing.T) {
+	cfgPath := getTestDataDir("withconfig", ".golangci.yml")
+
+	r := testshared.NewLintRunner(t)
+	r.Run("--config", cfgPath, getTestDataDir("skipdirs", "...")).
+		ExpectExitCode(exitcodes.Success).
+		ExpectOutputEq("test\n")
+	r.Run("--config", getTestDataDir("not_existing.yml"), getTestDataDir("skipdirs", "...")).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("not_existing.yml from --config option doesn't exist")
+	r.Run("--config", cfgPath, "--no-config", getTestDataDir("skipdirs", "...")).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("can't combine option --config and --no-config")
+}
+
+func TestBaseConfigIsExtended(t *testing.T) {
+	r := testshared.NewLintRunner(t)
+	// base config contains InternalTest: true
+	r.Run(getTestDataDir("withbaseconfig", "pkg")).
+		ExpectExitCode(exitcodes.Success).
+		ExpectOutputEq("test\n")
+	r.Run(getTestDataDir("withbaseconfig", "cycle")).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("cycle of extended configs")
+}
+
 func TestEnableAllFastAndEnableCanCoexist(t *testing.T) {
 	r := testshared.NewLintRunner(t)
 	r.Run("--fast", "--enable-all", "--enable=typecheck").ExpectNoIssues()
@@ -94,6 +424,58 @@ func TestEnabledPresetsAreNotDuplicated(t *testing.T) {
 		ExpectOutputContains("Active presets: [bugs style]")
 }
 
+func TestPresetsInConfig(t *testing.T) {
+	r := testshared.NewLintRunner(t)
+	r.RunWithYamlConfig(`
+		linters:
+			presets:
+				- bugs
+				- style
+		`, "-v", "-p", "style", getTestDataDir("withtests")).
+		ExpectOutputContains("Active presets: [bugs style]")
+	r.RunWithYamlConfig(`
+		linters:
+			presets:
+				- bugz
+		`, getTestDataDir("withtests")).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains(`no such preset \"bugz\": only next presets exist`)
+}
+
+func TestCommandLineOverridesConfigLinters(t *testing.T) {
+	r := testshared.NewLintRunner(t)
+	r.RunWithYamlConfig(`
+		linters:
+			disable-all: true
+			enable:
+				- golint
+				- misspell
+		`, "--disable", "golint", getTestDataDir("withtests")).
+		ExpectNoIssues()
+	r.RunWithYamlConfig(`
+		linters:
+			disable-all: true
+			enable:
+				- misspell
+			disable:
+				- golint
+		`, "--enable", "golint", getTestDataDir("withtests")).
+		ExpectHasIssue("if block ends with a return")
+}
+
+func TestNoLintersEnabled(t *testing.T) {
+	args := []string{"--no-config", "-punused", "--fast", "-Dstructcheck,varcheck,ineffassign,deadcode",
+		getTestDataDir("withtests")}
+
+	r := testshared.NewLintRunner(t)
+	r.Run(args...).
+		ExpectExitCode(exitcodes.Success).
+		ExpectOutputEq("level=warning msg=\"[lintersdb] No linters enabled: check your config/presets\"\n")
+	r.Run(append(args, "--fail-if-no-linters")...).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("no linters enabled: check your config/presets")
+}
+
 func TestDisallowedOptionsInConfig(t *testing.T) {
 	type tc struct {
 		cfg    string
@@ -149,3 +531,301 @@ func TestDisallowedOptionsInConfig(t *testing.T) {
 		r.RunWithYamlConfig(c.cfg, args...).ExpectExitCode(exitcodes.Failure)
 	}
 }
+
+func TestUnknownOptionInConfig(t *testing.T) {
+	cfg := `
+		run:
+			skipdirs:
+				- a
+	`
+	testshared.NewLintRunner(t).RunWithYamlConfig(cfg).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("unknown key `skipdirs` under `run`, did you mean `skip-dirs`?")
+}
+
+func TestCommonOptionsOfLintersWithoutSettings(t *testing.T) {
+	testshared.NewLintRunner(t).RunWithYamlConfig(`
+		linters-settings:
+			staticcheck:
+				tests: false
+			gas:
+				timeout: 1m
+	`, "--disable-all", "-Egolint", getTestDataDir("withtests")).
+		ExpectHasIssue("if block ends with a return")
+}
+
+func TestPrintSummary(t *testing.T) {
+	testshared.NewLintRunner(t).Run("--no-config", "--disable-all", "-Egolint", "--print-summary",
+		getTestDataDir("skipdirs", "...")).
+		ExpectHasIssue("if block ends with a return statement").
+		ExpectOutputContains(`{"Issues":2,"IssuesByLinter":{"golint":2},"Packages":3,`)
+}
+
+func TestPrintLinterTime(t *testing.T) {
+	args := []string{"--no-config", "--disable-all", "-Egolint", "-Emisspell", getTestDataDir("skipdirs", "...")}
+
+	r := testshared.NewLintRunner(t)
+	r.Run(append(args, "--print-linter-time")...).
+		ExpectHasIssue("if block ends with a return statement").
+		ExpectOutputContains("Linter    Time\n").
+		ExpectOutputContains("\ngolint    ").
+		ExpectOutputContains("\nmisspell  ")
+	r.Run(args...).
+		ExpectOutputNotContains("Linter    Time")
+}
+
+func TestTabColumns(t *testing.T) {
+	args := []string{"--no-config", "--disable-all", "-Egolint", "--out-format=tab",
+		getTestDataDir("skipdirs", "examples_no_skip")}
+
+	r := testshared.NewLintRunner(t)
+	r.Run(args...).
+		ExpectOutputContains("testdata/skipdirs/examples_no_skip/with_issue.go:8:9  golint  if block ends with a return statement")
+	r.Run(append(args, "--tab-columns=linter,line,col,message")...).
+		ExpectOutputEq("golint\t8\t9\tif block ends with a return statement, so drop this else and outdent its block\n")
+	r.Run(append(args, "--tab-columns=path,text")...).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains(`unknown tab column \"text\": allowed are path, line, col, linter, severity, message`)
+}
+
+func TestJSONSourceLines(t *testing.T) {
+	args := []string{"--no-config", "--disable-all", "-Egolint", "--out-format=json",
+		getTestDataDir("skipdirs", "examples_no_skip")}
+
+	r := testshared.NewLintRunner(t)
+	r.Run(args...).
+		ExpectOutputContains(`"SourceLines":["\t} else {"]`).
+		ExpectOutputNotContains(`"SourceContext"`)
+	r.Run(append(args, "--source-context-lines=1")...).
+		ExpectOutputContains(`"SourceLines":["\t} else {"],"SourceContext":{"Before":["\t\treturn"],"After":["\t\tfmt.Printf(\"\")"]}`)
+	r.Run(append(args, "--source-context-lines=1", "--print-issued-lines=false")...).
+		ExpectOutputContains(`if block ends with a return statement`).
+		ExpectOutputNotContains(`"SourceLines"`).
+		ExpectOutputNotContains(`"SourceContext"`)
+}
+
+func TestIncludeRules(t *testing.T) {
+	args := []string{"--disable-all", "-Egolint", "-Emisspell", getTestDataDir("skipdirs", "...")}
+
+	r := testshared.NewLintRunner(t)
+	r.RunWithYamlConfig(`
+		issues:
+			include-rules:
+				- path: examples_no_skip/
+				  linters:
+						- golint
+	`, args...).
+		ExpectOutputContains("examples_no_skip/with_issue.go:8:9: if block ends with a return statement").
+		ExpectOutputNotContains("skip_me/nested/with_issue.go")
+	r.RunWithYamlConfig(`
+		issues:
+			include-rules:
+				- path: examples_no_skip/
+				  linters:
+						- golint
+			exclude-rules:
+				- path: examples_no_skip/
+				  linters:
+						- golint
+	`, args...).
+		ExpectNoIssues()
+	r.RunWithYamlConfig(`
+		issues:
+			include-rules:
+				- path: examples_no_skip/
+	`, args...).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("invalid include rule #1: linters and path must be set")
+}
+
+func TestNewFromPatch(t *testing.T) {
+	f, err := ioutil.TempFile("", "golangci-lint-patch")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	require.NoError(t, f.Close())
+
+	writePatch := func(patch string) {
+		require.NoError(t, ioutil.WriteFile(f.Name(), []byte(patch), os.ModePerm))
+	}
+	args := []string{"--no-config", "--disable-all", "-Egolint", "--new-from-patch=" + f.Name(),
+		getTestDataDir("skipdirs", "...")}
+
+	r := testshared.NewLintRunner(t)
+	writePatch(`diff --git a/testdata/skipdirs/examples_no_skip/with_issue.go b/testdata/skipdirs/examples_no_skip/with_issue.go
+--- a/testdata/skipdirs/examples_no_skip/with_issue.go
++++ b/testdata/skipdirs/examples_no_skip/with_issue.go
+@@ -7,3 +7,3 @@ func main() {
+ 		return
+-	}
++	} else {
+ 		fmt.Printf("")
+`)
+	r.Run(args...).
+		ExpectOutputContains("examples_no_skip/with_issue.go:8:9: if block ends with a return statement").
+		ExpectOutputNotContains("skip_me/nested/with_issue.go")
+
+	writePatch("--- a/with_issue.go\n+++ b/with_issue.go\n@@ -7,3 +7,3 @@\n \t\treturn\n")
+	r.Run(args...).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("hunk of b/with_issue.go at line 3 is truncated at the end of the patch")
+}
+
+func TestModulesDownloadMode(t *testing.T) {
+	args := []string{"--no-config", "--disable-all", "-Egolint", getTestDataDir("skipdirs", "...")}
+
+	// -mod is passed to go list only in module mode
+	out, err := exec.Command("go", "env", "GOMOD").Output()
+	require.NoError(t, err)
+	gomod := strings.TrimSpace(string(out))
+	isModuleMode := gomod != "" && gomod != os.DevNull
+
+	const ignoredMsg = "Modules download mode vendor is ignored: not in module mode"
+	r := testshared.NewLintRunner(t)
+	res := r.Run(append(args, "-v", "--modules-download-mode=vendor")...).
+		ExpectHasIssue("if block ends with a return statement")
+	if isModuleMode {
+		res.ExpectOutputNotContains(ignoredMsg)
+	} else {
+		res.ExpectOutputContains(ignoredMsg)
+	}
+	r.Run(append(args, "--modules-download-mode=download")...).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains(`invalid modules download mode \"download\": allowed are mod|readonly|vendor`)
+}
+
+func TestGoVersion(t *testing.T) {
+	args := []string{"--no-config", "--disable-all", "-Egolint", getTestDataDir("skipdirs", "...")}
+
+	r := testshared.NewLintRunner(t)
+	r.Run(append(args, "--go=1.10")...).
+		ExpectHasIssue("if block ends with a return statement")
+	r.Run(append(args, "--go=go1.10")...).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains(`invalid Go version \"go1.10\": it must be in format 1.N, e.g. 1.21`)
+
+	// the config is validated before commands use it
+	r.RunWithYamlConfig(`
+run:
+  go: "1.x"
+`, "--disable-all", "-Egolint", getTestDataDir("skipdirs", "...")).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains(`invalid run.go option: invalid Go version \"1.x\"`)
+	r.RunCommand("cache warm", append(args, "--go=1.x")...).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains(`invalid Go version \"1.x\"`)
+}
+
+func TestColor(t *testing.T) {
+	args := []string{"--no-config", "--disable-all", "-Egolint", getTestDataDir("skipdirs", "...")}
+
+	r := testshared.NewLintRunner(t)
+	r.Run(append(args, "--color=always")...).
+		ExpectExitCode(exitcodes.IssuesFound).
+		ExpectOutputContains("(\x1b[36mgolint\x1b[0m)")
+	r.Run(append(args, "--color=never")...).
+		ExpectHasIssue("if block ends with a return statement, so drop this else and outdent its block (golint)")
+	r.Run(append(args, "--color=yes")...).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains(`invalid color mode \"yes\": allowed are auto, always, never`)
+}
+
+func TestDryRun(t *testing.T) {
+	r := testshared.NewLintRunner(t)
+	r.Run("--no-config", "--disable-all", "-Egolint", "--dry-run", getTestDataDir("skipdirs", "examples_no_skip")).
+		ExpectExitCode(exitcodes.Success).
+		ExpectOutputContains("Packages:\n").
+		ExpectOutputContains("testdata/skipdirs/examples_no_skip/with_issue.go\n").
+		ExpectOutputContains("Enabled linters:\n  golint\n").
+		ExpectOutputContains("  run:\n").
+		ExpectOutputNotContains("if block ends with a return statement")
+}
+
+func TestTextGroupedOutput(t *testing.T) {
+	testshared.NewLintRunner(t).Run("--no-config", "--disable-all", "-Egolint", "--out-format=text-grouped",
+		"--print-issued-lines=false", getTestDataDir("skipdirs", "...")).
+		ExpectOutputEq("testdata/skipdirs/examples_no_skip/with_issue.go:\n" +
+			"  8:9: if block ends with a return statement, so drop this else and outdent its block (golint)\n" +
+			"testdata/skipdirs/skip_me/nested/with_issue.go:\n" +
+			"  8:9: if block ends with a return statement, so drop this else and outdent its block (golint)\n")
+}
+
+func TestJSONIsTest(t *testing.T) {
+	r := testshared.NewLintRunner(t)
+	r.Run("--no-config", "--disable-all", "-Egolint", "--out-format=json", getTestDataDir("withtests")).
+		ExpectOutputContains(`"Filename":"testdata/withtests/p_test.go"`).
+		ExpectOutputContains(`"IsTest":true`)
+	r.Run("--no-config", "--disable-all", "-Egolint", "--out-format=json", getTestDataDir("skipdirs", "examples_no_skip")).
+		ExpectOutputContains(`if block ends with a return statement`).
+		ExpectOutputNotContains(`"IsTest"`)
+}
+
+func TestUpdateSnapshotIncompatibleOptions(t *testing.T) {
+	args := []string{"--no-config", "--disable-all", "-Egolint", "--only-new", "--update-snapshot",
+		getTestDataDir("skipdirs", "...")}
+
+	r := testshared.NewLintRunner(t)
+	r.Run(append(args, "--write-baseline=baseline.json")...).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("options --update-snapshot and --write-baseline can't be used together")
+	r.Run(append(args, "--new-from-rev=HEAD")...).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("option --update-snapshot can't be used with --new, --new-from-rev and --new-from-patch")
+}
+
+func TestIssuesCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golangci-lint-cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cacheDir := filepath.Join(dir, "cache")
+	args := []string{"--no-config", "--disable-all", "-Egolint", "-v", "--cache-issues", "--cache-dir=" + cacheDir,
+		getTestDataDir("skipdirs", "...")}
+
+	r := testshared.NewLintRunner(t)
+	r.Run(args...).
+		ExpectHasIssue("if block ends with a return statement").
+		ExpectOutputNotContains("from cache")
+	r.Run(args...).
+		ExpectHasIssue("if block ends with a return statement").
+		ExpectOutputContains("issues of linter golint from cache")
+
+	r.RunCommand("cache clean", "--cache-dir="+cacheDir).ExpectExitCode(exitcodes.Success)
+	_, err = os.Stat(cacheDir)
+	assert.True(t, os.IsNotExist(err))
+
+	// not empty dir which wasn't created by golangci-lint isn't used and removed
+	userFile := filepath.Join(dir, "main.go")
+	require.NoError(t, ioutil.WriteFile(userFile, []byte("package main\n"), os.ModePerm))
+	r.Run("--no-config", "--disable-all", "-Egolint", "--cache-issues", "--cache-dir="+dir,
+		getTestDataDir("skipdirs", "...")).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("isn't empty and wasn't created by golangci-lint")
+	r.RunCommand("cache clean", "--cache-dir="+dir).
+		ExpectExitCode(exitcodes.Failure).
+		ExpectOutputContains("wasn't created by golangci-lint")
+	_, err = os.Stat(userFile)
+	assert.NoError(t, err)
+}
+
+func TestLintersCommand(t *testing.T) {
+	r := testshared.NewLintRunner(t)
+	args := []string{"--no-config", "--disable-all", "-Egolint,gas"}
+
+	r.RunCommand("linters", args...).
+		ExpectExitCode(exitcodes.Success).
+		ExpectOutputContains("Enabled by your configuration linters:\n" +
+			"golint: Golint differs from gofmt. Gofmt reformats Go source code, whereas golint prints out " +
+			"style mistakes [fast: true, presets: style]\n" +
+			"gosec (gas): Inspects source code for security problems [fast: true, presets: bugs]\n" +
+			"\nDisabled by your configuration linters:\n").
+		ExpectOutputContains("\nstaticcheck: Staticcheck is a go vet on steroids, applying a ton of static analysis " +
+			"checks [fast: false, presets: bugs]\n")
+
+	r.RunCommand("linters", append(args, "--out-format=json")...).
+		ExpectExitCode(exitcodes.Success).
+		ExpectOutputContains(`{"Linters":[{"Name":"golint","Desc":"Golint differs from gofmt. `).
+		ExpectOutputContains(`{"Name":"gosec","AlternativeNames":["gas"],` +
+			`"Desc":"Inspects source code for security problems","Enabled":true,"Presets":["bugs"],"Fast":true}`).
+		ExpectOutputContains(`{"Name":"staticcheck","Desc":"Staticcheck is a go vet on steroids, applying a ton ` +
+			`of static analysis checks","Enabled":false,"Presets":["bugs"],"Fast":false}`)
+}
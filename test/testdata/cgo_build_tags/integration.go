@@ -0,0 +1,10 @@
+//go:build integration
+// +build integration
+
+package cgoexample
+
+func Integration() int {
+	x := 1
+	x = 2
+	return x
+}
@@ -0,0 +1,10 @@
+//args: -Enolintlint
+//config: linters-settings.nolintlint.min-explanation-length=200
+package testdata
+
+import "fmt"
+
+func Nolintlint() {
+	fmt.Println() //nolint:errcheck // ERROR "directive `//nolint:errcheck` should have explanation of at least 200 chars"
+	fmt.Println() //nolint // ERROR "directive `//nolint` should have explanation of at least 200 chars"
+}
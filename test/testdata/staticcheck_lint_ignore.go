@@ -0,0 +1,12 @@
+//args: -Emegacheck
+package testdata
+
+func StaticcheckLintIgnore() {
+	var x int
+	//lint:ignore SA4018 self-assignment is intended
+	x = x
+	x = x // ERROR "self-assignment of x to x"
+
+	//lint:ignore SA4006 directive without matched issues is reported // ERROR "this linter directive didn't match anything"
+	_ = x
+}
@@ -0,0 +1,14 @@
+package main
+
+func F(a int) int {
+	if a > 0 {
+		return 1
+	} else {
+		return 2
+	}
+}
+
+func main() {
+	var s string = F(1)
+	_ = s
+}
@@ -66,6 +66,11 @@ func (r *RunResult) ExpectOutputContains(s string) *RunResult {
 	return r
 }
 
+func (r *RunResult) ExpectOutputNotContains(s string) *RunResult {
+	assert.NotContains(r.t, r.output, s, "exit code is %d", r.exitCode)
+	return r
+}
+
 func (r *RunResult) ExpectOutputEq(s string) *RunResult {
 	assert.Equal(r.t, r.output, s, "exit code is %d", r.exitCode)
 	return r
@@ -76,9 +81,13 @@ func (r *RunResult) ExpectHasIssue(issueText string) *RunResult {
 }
 
 func (r *LintRunner) Run(args ...string) *RunResult {
+	return r.RunCommand("run", args...)
+}
+
+func (r *LintRunner) RunCommand(command string, args ...string) *RunResult {
 	r.Install()
 
-	runArgs := append([]string{"run"}, args...)
+	runArgs := append(strings.Fields(command), args...)
 	r.log.Infof("golangci-lint %s", strings.Join(runArgs, " "))
 	cmd := exec.Command("golangci-lint", runArgs...)
 	out, err := cmd.CombinedOutput()